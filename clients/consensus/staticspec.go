@@ -0,0 +1,110 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadChainSpecFile loads chain specs from a file for offline use without any client connection.
+// The file may either be a consensus config yaml or the json response of the /eth/v1/config/spec endpoint.
+// Values are converted the same way as the specs received from a beacon node.
+func LoadChainSpecFile(path string) (map[string]interface{}, error) {
+	specData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading spec file: %v", err)
+	}
+
+	// yaml nodes keep the raw values, so hex values are not turned into integers
+	specFile := struct {
+		Data map[string]yaml.Node `yaml:"data"`
+	}{}
+	rawValues := map[string]yaml.Node{}
+	if err := yaml.Unmarshal(specData, &specFile); err == nil && len(specFile.Data) > 0 {
+		rawValues = specFile.Data
+	} else if err := yaml.Unmarshal(specData, &rawValues); err != nil {
+		return nil, fmt.Errorf("error parsing spec file: %v", err)
+	}
+
+	specValues := map[string]interface{}{}
+	for key, node := range rawValues {
+		if node.Kind != yaml.ScalarNode {
+			continue
+		}
+		specValues[key] = parseChainSpecValue(key, node.Value)
+	}
+
+	return specValues, nil
+}
+
+func parseChainSpecValue(key string, value string) interface{} {
+	if strings.HasPrefix(key, "DOMAIN_") {
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			var domainType phase0.DomainType
+			copy(domainType[:], byteVal)
+			return domainType
+		}
+	}
+
+	if strings.HasSuffix(key, "_FORK_VERSION") {
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			var version phase0.Version
+			copy(version[:], byteVal)
+			return version
+		}
+	}
+
+	if strings.HasPrefix(value, "0x") {
+		if byteVal, err := hex.DecodeString(strings.TrimPrefix(value, "0x")); err == nil {
+			return byteVal
+		}
+	}
+
+	if strings.HasSuffix(key, "_TIME") {
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil && intVal != 0 {
+			return time.Unix(intVal, 0)
+		}
+	}
+
+	if strings.HasPrefix(key, "SECONDS_PER_") || key == "GENESIS_DELAY" {
+		if intVal, err := strconv.ParseInt(value, 10, 64); err == nil && intVal >= 0 {
+			return time.Duration(intVal) * time.Second
+		}
+	}
+
+	if intVal, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return intVal
+	}
+
+	return value
+}
+
+// SetStaticChainSpecs initializes the chain specs of the pool without a client connection.
+func (pool *Pool) SetStaticChainSpecs(specValues map[string]interface{}) error {
+	warning, err := pool.chainState.setClientSpecs(specValues)
+	if err != nil {
+		return err
+	}
+	if warning != nil {
+		pool.logger.Warnf("static chain specs: %v", warning)
+	}
+
+	return nil
+}
+
+// SetStaticGenesis initializes the genesis of the pool without a client connection.
+func (pool *Pool) SetStaticGenesis(genesis *v1.Genesis) error {
+	if err := pool.chainState.setGenesis(genesis); err != nil {
+		return err
+	}
+
+	pool.chainState.initWallclock()
+	return nil
+}
@@ -0,0 +1,450 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// explorerCommand is an administrative subcommand of the explorer binary.
+type explorerCommand struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+func getExplorerCommands() []*explorerCommand {
+	return []*explorerCommand{
+		{
+			name:  "schema",
+			usage: "schema up|status [-config <file>] [-version <version>]\n\tmigrate the db schema up to a version (default: latest version)\n\tdown migrations are not supported, as the schema migrations have no down steps",
+			run:   runSchemaCommand,
+		},
+		{
+			name:  "reset-sync",
+			usage: "reset-sync -epoch <epoch> [-config <file>]\n\treset the synchronizer to resume from the given epoch",
+			run:   runResetSyncCommand,
+		},
+		{
+			name:  "reindex",
+			usage: "reindex -from <epoch> -to <epoch> [-config <file>]\n\tsynchronize the given epoch range again, overwriting the existing db entries",
+			run:   runReindexCommand,
+		},
+		{
+			name:  "validator-names",
+			usage: "validator-names import|clear [-config <file>] [-file <yaml>]\n\tmerge validator names from a yaml file into the configured names file & db or clear all validator names from the db",
+			run:   runValidatorNamesCommand,
+		},
+		{
+			name:  "state",
+			usage: "state [-config <file>]\n\tprint the db schema version and indexer state",
+			run:   runStateCommand,
+		},
+		{
+			name:  "check-config",
+			usage: "check-config -config <file>\n\tvalidate a config file",
+			run:   runCheckConfigCommand,
+		},
+		{
+			name:  "import-era",
+			usage: "import-era -spec <file> [-config <file>] <era file or directory>...\n\timport finalized blocks & states from .era files",
+			run:   runImportEra,
+		},
+	}
+}
+
+// runExplorerCommand runs the subcommand with the given name and exits the process afterwards.
+func runExplorerCommand(name string, args []string) {
+	for _, command := range getExplorerCommands() {
+		if command.name != name {
+			continue
+		}
+
+		if err := command.run(args); err != nil {
+			fmt.Fprintf(os.Stderr, "%v failed: %v\n", name, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	printExplorerUsage()
+	os.Exit(2)
+}
+
+func printExplorerUsage() {
+	fmt.Fprintf(os.Stderr, "usage: dora-explorer [-config <file>]\n\trun the explorer\n\n")
+	fmt.Fprintf(os.Stderr, "administrative commands:\n")
+	for _, command := range getExplorerCommands() {
+		fmt.Fprintf(os.Stderr, "  dora-explorer %v\n\n", command.usage)
+	}
+}
+
+// initCommandConfig loads the config & logger for a subcommand.
+func initCommandConfig(configPath string) (logrus.FieldLogger, func()) {
+	cfg := &types.Config{}
+	err := utils.ReadConfig(cfg, configPath)
+	if err != nil {
+		logrus.Fatalf("error reading config file: %v", err)
+	}
+	utils.Config = cfg
+	logWriter, logger := utils.InitLogger()
+
+	return logger, logWriter.Dispose
+}
+
+// initCommandDb loads the config and connects to the db for a subcommand.
+func initCommandDb(configPath string) (logrus.FieldLogger, func()) {
+	logger, dispose := initCommandConfig(configPath)
+	db.MustInitDB()
+
+	return logger, func() {
+		db.MustCloseDB()
+		dispose()
+	}
+}
+
+func runSchemaCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing action (up or status)")
+	}
+	action := args[0]
+	if action == "down" {
+		return fmt.Errorf("down migrations are not supported, restore a db backup to roll back the schema")
+	}
+
+	flags := flag.NewFlagSet("schema", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	version := flags.Int64("version", -1, "Target schema version (default: latest)")
+	flags.Parse(args[1:])
+
+	_, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	// there is no "down" action, as the schema migrations have no down steps and rolling back the version
+	// would re-run non-idempotent migrations on the next start.
+	switch action {
+	case "up":
+		targetVersion := *version
+		if targetVersion == -1 {
+			targetVersion = -2
+		}
+		if err := db.ApplyEmbeddedDbSchema(targetVersion); err != nil {
+			return err
+		}
+	case "status":
+	default:
+		return fmt.Errorf("unknown action %q (up or status)", action)
+	}
+
+	schemaVersion, err := db.GetDbSchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("schema version: %v\n", schemaVersion)
+	return nil
+}
+
+func runResetSyncCommand(args []string) error {
+	flags := flag.NewFlagSet("reset-sync", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	epoch := flags.Int64("epoch", -1, "Epoch to resume the synchronization from")
+	flags.Parse(args)
+
+	if *epoch < 0 {
+		return fmt.Errorf("missing epoch (-epoch)")
+	}
+
+	logger, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
+			Epoch: uint64(*epoch),
+		}, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("reset synchronization status to epoch %v, restart the explorer to apply", *epoch)
+	return nil
+}
+
+func runReindexCommand(args []string) error {
+	flags := flag.NewFlagSet("reindex", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	fromEpoch := flags.Int64("from", -1, "First epoch to reindex")
+	toEpoch := flags.Int64("to", -1, "Last epoch to reindex")
+	flags.Parse(args)
+
+	if *fromEpoch < 0 || *toEpoch < 0 {
+		return fmt.Errorf("missing epoch range (-from / -to)")
+	}
+	if *toEpoch < *fromEpoch {
+		return fmt.Errorf("invalid epoch range: %v - %v", *fromEpoch, *toEpoch)
+	}
+
+	logger, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.SetExplorerState("indexer.reindexstate", &dbtypes.IndexerReindexState{
+			FromEpoch: uint64(*fromEpoch),
+			ToEpoch:   uint64(*toEpoch),
+		}, tx)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("scheduled reindexing of epochs %v - %v, restart the explorer to apply", *fromEpoch, *toEpoch)
+	return nil
+}
+
+func runValidatorNamesCommand(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("missing action (import or clear)")
+	}
+	action := args[0]
+
+	flags := flag.NewFlagSet("validator-names", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	namesFile := flags.String("file", "", "Path to the validator names yaml file (import only)")
+	flags.Parse(args[1:])
+
+	switch action {
+	case "import":
+		if *namesFile == "" {
+			return fmt.Errorf("missing validator names file (-file)")
+		}
+	case "clear":
+	default:
+		return fmt.Errorf("unknown action %q (import or clear)", action)
+	}
+
+	logger, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	if action == "clear" {
+		var deleted int64
+		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			var err error
+			deleted, err = db.ClearValidatorNames(tx)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		logger.Infof("deleted %v validator names", deleted)
+		return nil
+	}
+
+	// the explorer reconciles the db with its names sources, so the imported names need to be added to the configured names file to persist
+	targetFile := utils.Config.Frontend.ValidatorNamesYaml
+	if targetFile == "" || strings.HasPrefix(targetFile, "~internal/") {
+		return fmt.Errorf("importing validator names requires frontend.validatorNamesYaml to point to a writable names file")
+	}
+
+	namesYaml, err := readValidatorNamesYaml(*namesFile)
+	if err != nil {
+		return err
+	}
+
+	validatorNames, skipped, err := parseValidatorNamesYaml(namesYaml)
+	if err != nil {
+		return err
+	}
+
+	merged, err := mergeValidatorNamesYaml(targetFile, namesYaml)
+	if err != nil {
+		return err
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		batchSize := 10000
+		for start := 0; start < len(validatorNames); start += batchSize {
+			end := start + batchSize
+			if end > len(validatorNames) {
+				end = len(validatorNames)
+			}
+			if err := db.InsertValidatorNames(validatorNames[start:end], tx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("merged %v entries into %v and imported %v validator names into the db (%v non-index entries are resolved by the explorer)", merged, targetFile, len(validatorNames), skipped)
+	return nil
+}
+
+// maximum number of validators covered by a single index range key in a validator names file
+const validatorNamesMaxRange = 1 << 22
+
+// readValidatorNamesYaml reads a validator names yaml file (a map of index, index range or address keys to names).
+func readValidatorNamesYaml(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening validator names file %v: %v", path, err)
+	}
+	defer f.Close()
+
+	namesYaml := map[string]string{}
+	if err := yaml.NewDecoder(f).Decode(&namesYaml); err != nil {
+		return nil, fmt.Errorf("error decoding validator names file %v: %v", path, err)
+	}
+
+	return namesYaml, nil
+}
+
+// parseValidatorNamesYaml expands the index & index range keys of a validator names file.
+// Withdrawal & depositor keys are resolved at runtime by the explorer and are skipped.
+func parseValidatorNamesYaml(namesYaml map[string]string) ([]*dbtypes.ValidatorName, int, error) {
+	namesByIndex := map[uint64]string{}
+	skipped := 0
+	for idxStr, name := range namesYaml {
+		rangeParts := strings.Split(idxStr, "-")
+		minIdx, err := strconv.ParseUint(rangeParts[0], 10, 64)
+		if err != nil {
+			skipped++
+			continue
+		}
+		maxIdx := minIdx
+		if len(rangeParts) > 1 {
+			maxIdx, err = strconv.ParseUint(rangeParts[1], 10, 64)
+			if err != nil {
+				skipped++
+				continue
+			}
+		}
+		if maxIdx < minIdx || maxIdx-minIdx >= validatorNamesMaxRange {
+			return nil, 0, fmt.Errorf("invalid validator index range %q (max %v validators per range)", idxStr, validatorNamesMaxRange)
+		}
+
+		for idx := minIdx; ; idx++ {
+			namesByIndex[idx] = name
+			if idx == maxIdx {
+				break
+			}
+		}
+	}
+
+	validatorNames := make([]*dbtypes.ValidatorName, 0, len(namesByIndex))
+	for index, name := range namesByIndex {
+		validatorNames = append(validatorNames, &dbtypes.ValidatorName{
+			Index: index,
+			Name:  name,
+		})
+	}
+	sort.Slice(validatorNames, func(a, b int) bool {
+		return validatorNames[a].Index < validatorNames[b].Index
+	})
+
+	return validatorNames, skipped, nil
+}
+
+// mergeValidatorNamesYaml adds the given entries to the validator names file (created if missing) and returns the number of added or changed entries.
+func mergeValidatorNamesYaml(path string, namesYaml map[string]string) (int, error) {
+	targetYaml := map[string]string{}
+	if _, err := os.Stat(path); err == nil {
+		targetYaml, err = readValidatorNamesYaml(path)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	merged := 0
+	for key, name := range namesYaml {
+		if targetYaml[key] != name {
+			targetYaml[key] = name
+			merged++
+		}
+	}
+	if merged == 0 {
+		return 0, nil
+	}
+
+	yamlBytes, err := yaml.Marshal(targetYaml)
+	if err != nil {
+		return 0, fmt.Errorf("error encoding validator names file: %v", err)
+	}
+
+	// write to a temporary file first, so a running explorer never reads a partially written file
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, yamlBytes, 0o644); err != nil {
+		return 0, fmt.Errorf("error writing validator names file %v: %v", tmpPath, err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return 0, fmt.Errorf("error replacing validator names file %v: %v", path, err)
+	}
+
+	return merged, nil
+}
+
+func runStateCommand(args []string) error {
+	flags := flag.NewFlagSet("state", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	flags.Parse(args)
+
+	_, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	schemaVersion, err := db.GetDbSchemaVersion()
+	if err != nil {
+		return err
+	}
+	fmt.Printf("schema version: %v\n", schemaVersion)
+
+	states, err := db.GetExplorerStates()
+	if err != nil {
+		return err
+	}
+	for _, state := range states {
+		fmt.Printf("%v: %v\n", state.Key, state.Value)
+	}
+
+	return nil
+}
+
+func runCheckConfigCommand(args []string) error {
+	flags := flag.NewFlagSet("check-config", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file")
+	flags.Parse(args)
+
+	if *configPath == "" {
+		return fmt.Errorf("missing config file (-config)")
+	}
+
+	cfg := &types.Config{}
+	if err := utils.ReadConfig(cfg, *configPath); err != nil {
+		return err
+	}
+
+	problems := utils.ValidateConfig(cfg, *configPath)
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			fmt.Fprintf(os.Stderr, "  %v\n", problem)
+		}
+		return fmt.Errorf("found %v problems in %v", len(problems), *configPath)
+	}
+
+	fmt.Printf("config %v is valid\n", *configPath)
+	return nil
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// runImportEra imports finalized blocks & states from .era files into the database without connecting to any beacon node.
+func runImportEra(args []string) error {
+	flags := flag.NewFlagSet("import-era", flag.ExitOnError)
+	configPath := flags.String("config", "", "Path to the config file, if empty string defaults will be used")
+	specPath := flags.String("spec", "", "Path to the chain spec file (consensus config yaml or /eth/v1/config/spec response)")
+	flags.Parse(args)
+
+	if *specPath == "" {
+		return fmt.Errorf("missing chain spec file (-spec)")
+	}
+
+	eraFiles, err := getEraFilePaths(flags.Args())
+	if err != nil {
+		return fmt.Errorf("error listing era files: %v", err)
+	}
+	if len(eraFiles) == 0 {
+		return fmt.Errorf("no era files given")
+	}
+
+	specValues, err := consensus.LoadChainSpecFile(*specPath)
+	if err != nil {
+		return fmt.Errorf("error loading chain specs: %v", err)
+	}
+
+	logger, dispose := initCommandDb(*configPath)
+	defer dispose()
+
+	err = db.ApplyEmbeddedDbSchema(-2)
+	if err != nil {
+		return fmt.Errorf("error initializing db schema: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	consensusPool := consensus.NewPool(ctx, logger.WithField("service", "cl-pool"))
+	err = consensusPool.SetStaticChainSpecs(specValues)
+	if err != nil {
+		return fmt.Errorf("error initializing chain specs: %v", err)
+	}
+
+	beaconIndexer := beacon.NewIndexer(logger.WithField("service", "cl-indexer"), consensusPool)
+	defer beaconIndexer.StopIndexer()
+
+	logger.Infof("importing %v era files", len(eraFiles))
+	stats, err := beaconIndexer.ImportEraFiles(ctx, eraFiles)
+	if stats != nil {
+		logger.WithFields(logrus.Fields{
+			"files":      stats.Files,
+			"blocks":     stats.Blocks,
+			"epochs":     stats.Epochs,
+			"skipped":    stats.SkippedEpochs,
+			"incomplete": stats.IncompleteEpochs,
+		}).Infof("era import finished")
+	}
+
+	return err
+}
+
+// getEraFilePaths returns the era files from the given list of files & directories.
+func getEraFilePaths(args []string) ([]string, error) {
+	eraFiles := []string{}
+	for _, arg := range args {
+		fileInfo, err := os.Stat(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fileInfo.IsDir() {
+			eraFiles = append(eraFiles, arg)
+			continue
+		}
+
+		entries, err := os.ReadDir(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".era") {
+				eraFiles = append(eraFiles, filepath.Join(arg, entry.Name()))
+			}
+		}
+	}
+
+	sort.Strings(eraFiles)
+	return eraFiles, nil
+}
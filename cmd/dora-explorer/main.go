@@ -7,6 +7,8 @@ import (
 	"net"
 	"net/http"
 	_ "net/http/pprof"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -23,6 +25,11 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		runExplorerCommand(os.Args[1], os.Args[2:])
+		return
+	}
+
 	configPath := flag.String("config", "", "Path to the config file, if empty string defaults will be used")
 	flag.Parse()
 
@@ -147,19 +154,38 @@ func startFrontend(webserver *http.Server) {
 	router.HandleFunc("/index/data", handlers.IndexData).Methods("GET")
 	router.HandleFunc("/clients/consensus", handlers.ClientsCL).Methods("GET")
 	router.HandleFunc("/clients/execution", handlers.ClientsEl).Methods("GET")
+	router.HandleFunc("/clients/diversity", handlers.ClientsDiversity).Methods("GET")
+	router.HandleFunc("/clients/diversity/data", handlers.ClientsDiversityData).Methods("GET")
 	router.HandleFunc("/forks", handlers.Forks).Methods("GET")
+	router.HandleFunc("/forks/tree", handlers.ForksTree).Methods("GET")
 	router.HandleFunc("/epochs", handlers.Epochs).Methods("GET")
 	router.HandleFunc("/epoch/{epoch}", handlers.Epoch).Methods("GET")
 	router.HandleFunc("/slots", handlers.Slots).Methods("GET")
 	router.HandleFunc("/slots/filtered", handlers.SlotsFiltered).Methods("GET")
 	router.HandleFunc("/slot/{slotOrHash}", handlers.Slot).Methods("GET")
 	router.HandleFunc("/slot/{root}/blob/{commitment}", handlers.SlotBlob).Methods("GET")
+	router.HandleFunc("/slot/{slot:[0-9]+}/compare", handlers.SlotCompare).Methods("GET")
+	router.HandleFunc("/blobs", handlers.Blobs).Methods("GET")
+	router.HandleFunc("/blobs/data", handlers.BlobsData).Methods("GET")
+	router.HandleFunc("/gas", handlers.Gas).Methods("GET")
+	router.HandleFunc("/gas/data", handlers.GasData).Methods("GET")
+	router.HandleFunc("/feerecipient/{address}", handlers.FeeRecipient).Methods("GET")
 	router.HandleFunc("/mev/blocks", handlers.MevBlocks).Methods("GET")
+	router.HandleFunc("/mev/builders", handlers.MevBuilders).Methods("GET")
+	router.HandleFunc("/mev/relays", handlers.MevRelays).Methods("GET")
+	router.HandleFunc("/mev/faults", handlers.MevFaults).Methods("GET")
+	router.HandleFunc("/mev/analytics/data", handlers.MevAnalyticsData).Methods("GET")
+	router.HandleFunc("/alerts", handlers.Alerts).Methods("GET")
 
 	router.HandleFunc("/search", handlers.Search).Methods("GET")
 	router.HandleFunc("/search/{type}", handlers.SearchAhead).Methods("GET")
 	router.HandleFunc("/validators", handlers.Validators).Methods("GET")
 	router.HandleFunc("/validators/activity", handlers.ValidatorsActivity).Methods("GET")
+	router.HandleFunc("/validators/dashboard", handlers.ValidatorsDashboard).Methods("GET")
+	router.HandleFunc("/validators/dashboard/save", handlers.ValidatorsDashboardSave).Methods("POST")
+	router.HandleFunc("/validators/duties", handlers.ValidatorsDuties).Methods("GET")
+	router.HandleFunc("/validators/operators", handlers.ValidatorsOperators).Methods("GET")
+	router.HandleFunc("/validators/duties/ical", handlers.ValidatorsDutiesICal).Methods("GET")
 	router.HandleFunc("/validators/deposits", handlers.Deposits).Methods("GET")
 	router.HandleFunc("/validators/deposits/submit", handlers.SubmitDeposit).Methods("GET", "POST")
 	router.HandleFunc("/validators/initiated_deposits", handlers.InitiatedDeposits).Methods("GET")
@@ -170,6 +196,7 @@ func startFrontend(webserver *http.Server) {
 	router.HandleFunc("/validators/el_consolidations", handlers.ElConsolidations).Methods("GET")
 	router.HandleFunc("/validators/submit_consolidations", handlers.SubmitConsolidation).Methods("GET")
 	router.HandleFunc("/validators/submit_withdrawals", handlers.SubmitWithdrawal).Methods("GET")
+	router.HandleFunc("/export/{list}", handlers.Export).Methods("GET")
 	router.HandleFunc("/validator/{idxOrPubKey}", handlers.Validator).Methods("GET")
 	router.HandleFunc("/validator/{index}/slots", handlers.ValidatorSlots).Methods("GET")
 
@@ -177,6 +204,8 @@ func startFrontend(webserver *http.Server) {
 		// add pprof handler
 		router.PathPrefix("/debug/pprof/").Handler(http.DefaultServeMux)
 		router.HandleFunc("/debug/cache", handlers.DebugCache).Methods("GET")
+		router.HandleFunc("/debug/consistency", handlers.DebugConsistency).Methods("GET")
+		router.HandleFunc("/debug/consistency", handlers.DebugConsistencyAction).Methods("POST")
 	}
 
 	if utils.Config.Frontend.Debug {
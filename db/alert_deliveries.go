@@ -0,0 +1,40 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertAlertDelivery(delivery *dbtypes.AlertDelivery, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`
+		INSERT INTO alert_deliveries (rule_name, webhook_name, alert_type, title, status, attempts, error, created_at, delivered_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		delivery.RuleName, delivery.WebhookName, delivery.AlertType, delivery.Title, delivery.Status, delivery.Attempts, delivery.Error, delivery.CreatedAt, delivery.DeliveredAt)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetAlertDeliveries(offset uint64, limit uint32) ([]*dbtypes.AlertDelivery, uint64, error) {
+	deliveries := []*dbtypes.AlertDelivery{}
+	err := ReaderDb.Select(&deliveries, `
+	SELECT id, rule_name, webhook_name, alert_type, title, status, attempts, error, created_at, delivered_at
+	FROM alert_deliveries
+	ORDER BY created_at DESC, id DESC
+	LIMIT $1 OFFSET $2
+	`, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching alert deliveries: %v", err)
+		return nil, 0, err
+	}
+
+	var totalCount uint64
+	err = ReaderDb.Get(&totalCount, `SELECT COUNT(*) FROM alert_deliveries`)
+	if err != nil {
+		logger.Errorf("Error while counting alert deliveries: %v", err)
+		return nil, 0, err
+	}
+
+	return deliveries, totalCount, nil
+}
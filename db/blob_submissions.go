@@ -0,0 +1,93 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertBlobSubmissions(blobSubmissions []*dbtypes.BlobSubmission, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO blob_submissions ",
+			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO blob_submissions ",
+		}),
+		"(slot_number, slot_root, tx_index, tx_hash, tx_from, blob_count, orphaned)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 7
+
+	args := make([]any, len(blobSubmissions)*fieldCount)
+	for i, blobSubmission := range blobSubmissions {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = blobSubmission.SlotNumber
+		args[argIdx+1] = blobSubmission.SlotRoot
+		args[argIdx+2] = blobSubmission.TxIndex
+		args[argIdx+3] = blobSubmission.TxHash
+		args[argIdx+4] = blobSubmission.TxFrom
+		args[argIdx+5] = blobSubmission.BlobCount
+		args[argIdx+6] = blobSubmission.Orphaned
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot_root, tx_index) DO UPDATE SET orphaned = excluded.orphaned",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetSlotBlobInfos returns the blob gas details of all canonical blocks within the slot range.
+func GetSlotBlobInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotBlobInfo, error) {
+	blobInfos := []*dbtypes.SlotBlobInfo{}
+	err := ReaderDb.Select(&blobInfos, `
+	SELECT
+		slot, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching slot blob infos: %v", err)
+		return nil, err
+	}
+	return blobInfos, nil
+}
+
+// GetBlobSubmissionInfos returns the blob transactions of all canonical blocks within the slot range, including the blob base fee of the including block.
+func GetBlobSubmissionInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.BlobSubmissionInfo, error) {
+	submissionInfos := []*dbtypes.BlobSubmissionInfo{}
+	err := ReaderDb.Select(&submissionInfos, `
+	SELECT
+		blob_submissions.slot_number, blob_submissions.tx_from, blob_submissions.blob_count, slots.eth_blob_base_fee
+	FROM blob_submissions
+	LEFT JOIN slots ON slots.root = blob_submissions.slot_root
+	WHERE blob_submissions.slot_number >= $1 AND blob_submissions.slot_number <= $2 AND blob_submissions.orphaned = false
+	ORDER BY blob_submissions.slot_number ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching blob submission infos: %v", err)
+		return nil, err
+	}
+	return submissionInfos, nil
+}
@@ -184,7 +184,7 @@ func RunDBTransaction(handler func(tx *sqlx.Tx) error) error {
 	return nil
 }
 
-func ApplyEmbeddedDbSchema(version int64) error {
+func initEmbeddedDbSchema() (string, error) {
 	var engineDialect string
 	var schemaDirectory string
 	switch DbEngine {
@@ -200,6 +200,15 @@ func ApplyEmbeddedDbSchema(version int64) error {
 		logger.Fatalf("unknown database engine")
 	}
 	if err := goose.SetDialect(engineDialect); err != nil {
+		return "", err
+	}
+
+	return schemaDirectory, nil
+}
+
+func ApplyEmbeddedDbSchema(version int64) error {
+	schemaDirectory, err := initEmbeddedDbSchema()
+	if err != nil {
 		return err
 	}
 
@@ -220,6 +229,15 @@ func ApplyEmbeddedDbSchema(version int64) error {
 	return nil
 }
 
+// GetDbSchemaVersion returns the currently applied db schema version.
+func GetDbSchemaVersion() (int64, error) {
+	if _, err := initEmbeddedDbSchema(); err != nil {
+		return 0, err
+	}
+
+	return goose.GetDBVersion(ReaderDb.DB)
+}
+
 func EngineQuery(queryMap map[dbtypes.DBEngineType]string) string {
 	if queryMap[DbEngine] != "" {
 		return queryMap[DbEngine]
@@ -0,0 +1,195 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func GetLowestEpoch() (uint64, bool, error) {
+	var epochs []uint64
+	err := ReaderDb.Select(&epochs, `SELECT epoch FROM epochs ORDER BY epoch ASC LIMIT 1`)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(epochs) == 0 {
+		return 0, false, nil
+	}
+	return epochs[0], true, nil
+}
+
+func GetEpochAggregatesRange(firstEpoch uint64, lastEpoch uint64) ([]*dbtypes.EpochBlockAggregate, error) {
+	aggregates := []*dbtypes.EpochBlockAggregate{}
+	err := ReaderDb.Select(&aggregates, `
+	SELECT
+		epoch, block_count, attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount,
+		attester_slashing_count, proposer_slashing_count, bls_change_count, eth_transaction_count
+	FROM epochs
+	WHERE epoch >= $1 AND epoch <= $2
+	ORDER BY epoch ASC
+	`, firstEpoch, lastEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching epoch aggregates: %v", err)
+		return nil, err
+	}
+	return aggregates, nil
+}
+
+// GetCanonicalSlotAggregates sums up the canonical blocks in the given slot range by epoch.
+func GetCanonicalSlotAggregates(firstSlot uint64, lastSlot uint64, slotsPerEpoch uint64) ([]*dbtypes.EpochBlockAggregate, error) {
+	aggregates := []*dbtypes.EpochBlockAggregate{}
+	err := ReaderDb.Select(&aggregates, `
+	SELECT
+		slot / $3 AS epoch,
+		COUNT(*) AS block_count,
+		COALESCE(SUM(attestation_count), 0) AS attestation_count,
+		COALESCE(SUM(deposit_count), 0) AS deposit_count,
+		COALESCE(SUM(exit_count), 0) AS exit_count,
+		COALESCE(SUM(withdraw_count), 0) AS withdraw_count,
+		COALESCE(SUM(withdraw_amount), 0) AS withdraw_amount,
+		COALESCE(SUM(attester_slashing_count), 0) AS attester_slashing_count,
+		COALESCE(SUM(proposer_slashing_count), 0) AS proposer_slashing_count,
+		COALESCE(SUM(bls_change_count), 0) AS bls_change_count,
+		COALESCE(SUM(eth_transaction_count), 0) AS eth_transaction_count
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1
+	GROUP BY slot / $3
+	ORDER BY epoch ASC
+	`, firstSlot, lastSlot, slotsPerEpoch)
+	if err != nil {
+		logger.Errorf("Error while fetching canonical slot aggregates: %v", err)
+		return nil, err
+	}
+	return aggregates, nil
+}
+
+func GetCanonicalSlotLinks(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotLinkInfo, error) {
+	slotLinks := []*dbtypes.SlotLinkInfo{}
+	err := ReaderDb.Select(&slotLinks, `
+	SELECT
+		slot, root, parent_root
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching canonical slot links: %v", err)
+		return nil, err
+	}
+	return slotLinks, nil
+}
+
+// GetCanonicalSlotsWithOrphanedBlock returns canonical blocks in the given slot range that also have an orphaned block entry.
+func GetCanonicalSlotsWithOrphanedBlock(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotLinkInfo, error) {
+	slotLinks := []*dbtypes.SlotLinkInfo{}
+	err := ReaderDb.Select(&slotLinks, `
+	SELECT
+		slots.slot, slots.root, slots.parent_root
+	FROM slots
+	INNER JOIN orphaned_blocks ON orphaned_blocks.root = slots.root
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 1
+	ORDER BY slots.slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching canonical slots with orphaned block: %v", err)
+		return nil, err
+	}
+	return slotLinks, nil
+}
+
+// GetOrphanedSlotsWithCanonicalChild returns orphaned blocks in the given slot range that are the parent of a canonical block.
+func GetOrphanedSlotsWithCanonicalChild(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotLinkInfo, error) {
+	slotLinks := []*dbtypes.SlotLinkInfo{}
+	err := ReaderDb.Select(&slotLinks, `
+	SELECT DISTINCT
+		parent.slot, parent.root, parent.parent_root
+	FROM slots AS parent
+	INNER JOIN slots AS child ON child.parent_root = parent.root AND child.status = 1
+	WHERE parent.slot >= $1 AND parent.slot <= $2 AND parent.status = 2
+	ORDER BY parent.slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching orphaned slots with canonical child: %v", err)
+		return nil, err
+	}
+	return slotLinks, nil
+}
+
+// GetStaleCanonicalSlots returns the canonical blocks in the given slot range that are not part of the canonical roots.
+func GetStaleCanonicalSlots(firstSlot uint64, lastSlot uint64, canonicalRoots [][]byte) ([]*dbtypes.SlotLinkInfo, error) {
+	var sql strings.Builder
+	args := []any{firstSlot, lastSlot}
+
+	fmt.Fprint(&sql, `
+	SELECT
+		slot, root, parent_root
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1`)
+	if len(canonicalRoots) > 0 {
+		fmt.Fprint(&sql, ` AND root NOT IN (`)
+		for i, root := range canonicalRoots {
+			if i > 0 {
+				fmt.Fprint(&sql, ",")
+			}
+			args = append(args, root)
+			fmt.Fprintf(&sql, "$%v", len(args))
+		}
+		fmt.Fprint(&sql, ")")
+	}
+	fmt.Fprint(&sql, ` ORDER BY slot ASC`)
+
+	slotLinks := []*dbtypes.SlotLinkInfo{}
+	err := ReaderDb.Select(&slotLinks, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching stale canonical slots: %v", err)
+		return nil, err
+	}
+	return slotLinks, nil
+}
+
+// OrphanStaleCanonicalSlots marks all canonical blocks in the given slot range that are not part of the canonical roots as orphaned
+// and removes orphaned block entries of the canonical roots.
+func OrphanStaleCanonicalSlots(firstSlot uint64, lastSlot uint64, canonicalRoots [][]byte, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	args := []any{firstSlot, lastSlot}
+
+	fmt.Fprint(&sql, `UPDATE slots SET status = 2 WHERE slot >= $1 AND slot <= $2 AND status = 1`)
+	if len(canonicalRoots) > 0 {
+		fmt.Fprint(&sql, ` AND root NOT IN (`)
+		for i, root := range canonicalRoots {
+			if i > 0 {
+				fmt.Fprint(&sql, ",")
+			}
+			args = append(args, root)
+			fmt.Fprintf(&sql, "$%v", len(args))
+		}
+		fmt.Fprint(&sql, ")")
+	}
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+
+	if len(canonicalRoots) == 0 {
+		return nil
+	}
+
+	sql = strings.Builder{}
+	args = []any{}
+
+	fmt.Fprint(&sql, `DELETE FROM orphaned_blocks WHERE root IN (`)
+	for i, root := range canonicalRoots {
+		if i > 0 {
+			fmt.Fprint(&sql, ",")
+		}
+		args = append(args, root)
+		fmt.Fprintf(&sql, "$%v", len(args))
+	}
+	fmt.Fprint(&sql, ")")
+
+	_, err = tx.Exec(sql.String(), args...)
+	return err
+}
@@ -40,3 +40,17 @@ func SetExplorerState(key string, value interface{}, tx *sqlx.Tx) error {
 	}
 	return nil
 }
+
+func DeleteExplorerState(key string, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM explorer_state WHERE key = $1`, key)
+	return err
+}
+
+func GetExplorerStates() ([]*dbtypes.ExplorerState, error) {
+	entries := []*dbtypes.ExplorerState{}
+	err := ReaderDb.Select(&entries, `SELECT key, value FROM explorer_state ORDER BY key`)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
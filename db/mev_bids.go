@@ -0,0 +1,89 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertMevBids(mevBids []*dbtypes.MevBid, tx *sqlx.Tx) error {
+	// split into batches to stay below the max number of query parameters
+	batchSize := 1000
+	for start := 0; start < len(mevBids); start += batchSize {
+		err := insertMevBidsBatch(mevBids[start:min(start+batchSize, len(mevBids))], tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func insertMevBidsBatch(mevBids []*dbtypes.MevBid, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO mev_bids ",
+			dbtypes.DBEngineSqlite: "INSERT OR IGNORE INTO mev_bids ",
+		}),
+		"(slot_number, relay_id, block_hash, builder_pubkey, value_gwei, timestamp_ms)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 6
+
+	args := make([]any, len(mevBids)*fieldCount)
+	for i, mevBid := range mevBids {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = mevBid.SlotNumber
+		args[argIdx+1] = mevBid.RelayId
+		args[argIdx+2] = mevBid.BlockHash
+		args[argIdx+3] = mevBid.BuilderPubkey
+		args[argIdx+4] = mevBid.ValueGwei
+		args[argIdx+5] = mevBid.TimestampMs
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (slot_number, relay_id, block_hash) DO NOTHING",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetMevBidsBySlot(slot uint64) ([]*dbtypes.MevBid, error) {
+	mevBids := []*dbtypes.MevBid{}
+	err := ReaderDb.Select(&mevBids, `
+	SELECT
+		slot_number, relay_id, block_hash, builder_pubkey, value_gwei, timestamp_ms
+	FROM mev_bids
+	WHERE slot_number = $1
+	ORDER BY timestamp_ms ASC
+	`, slot)
+	if err != nil {
+		logger.Errorf("Error while fetching mev bids: %v", err)
+		return nil, err
+	}
+	return mevBids, nil
+}
+
+func DeleteMevBidsBefore(slot uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`DELETE FROM mev_bids WHERE slot_number < $1`, slot)
+	return err
+}
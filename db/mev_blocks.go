@@ -13,7 +13,7 @@ func InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx *sqlx.Tx) error {
 	fmt.Fprint(&sql,
 		EngineQuery(map[dbtypes.DBEngineType]string{
 			dbtypes.DBEnginePgsql:  "INSERT INTO mev_blocks ",
-			dbtypes.DBEngineSqlite: "INSERT OR REPLACE INTO mev_blocks ",
+			dbtypes.DBEngineSqlite: "INSERT INTO mev_blocks ",
 		}),
 		"(slot_number, block_hash, block_number, builder_pubkey, proposer_index, proposed, seenby_relays, fee_recipient, tx_count, gas_used, block_value, block_value_gwei)",
 		" VALUES ",
@@ -51,8 +51,8 @@ func InsertMevBlocks(mevBlocks []*dbtypes.MevBlock, tx *sqlx.Tx) error {
 		argIdx += fieldCount
 	}
 	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
-		dbtypes.DBEnginePgsql:  " ON CONFLICT (block_hash) DO UPDATE SET proposed = excluded.proposed, seenby_relays = excluded.seenby_relays",
-		dbtypes.DBEngineSqlite: "",
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (block_hash) DO UPDATE SET proposed = excluded.proposed, seenby_relays = mev_blocks.seenby_relays | excluded.seenby_relays",
+		dbtypes.DBEngineSqlite: " ON CONFLICT (block_hash) DO UPDATE SET proposed = excluded.proposed, seenby_relays = mev_blocks.seenby_relays | excluded.seenby_relays",
 	}))
 
 	_, err := tx.Exec(sql.String(), args...)
@@ -247,3 +247,64 @@ func GetMevBlocksFiltered(offset uint64, limit uint32, filter *dbtypes.MevBlockF
 
 	return mevBlocks[1:], mevBlocks[0].SlotNumber, nil
 }
+
+func GetMevBlockSlotNumbers(firstSlot uint64, lastSlot uint64) ([]uint64, error) {
+	slotNumbers := []uint64{}
+	err := ReaderDb.Select(&slotNumbers, `
+	SELECT DISTINCT
+		slot_number
+	FROM mev_blocks
+	WHERE slot_number >= $1 AND slot_number <= $2
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching mev block slot numbers: %v", err)
+		return nil, err
+	}
+	return slotNumbers, nil
+}
+
+func GetCanonicalPayloadMevInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.PayloadMevInfo, error) {
+	payloadInfos := []*dbtypes.PayloadMevInfo{}
+	err := ReaderDb.Select(&payloadInfos, `
+	SELECT
+		slots.slot,
+		mev_blocks.builder_pubkey,
+		COALESCE(mev_blocks.seenby_relays, 0) AS seenby_relays,
+		COALESCE(mev_blocks.block_value_gwei, 0) AS block_value_gwei
+	FROM slots
+	LEFT JOIN mev_blocks ON mev_blocks.block_hash = slots.eth_block_hash
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 1 AND slots.eth_block_hash IS NOT NULL
+	ORDER BY slots.slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching canonical payload mev infos: %v", err)
+		return nil, err
+	}
+	return payloadInfos, nil
+}
+
+func GetMevBlockPayloadInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.MevBlockPayloadInfo, error) {
+	payloadInfos := []*dbtypes.MevBlockPayloadInfo{}
+	err := ReaderDb.Select(&payloadInfos, `
+	SELECT
+		mev_blocks.slot_number,
+		mev_blocks.block_hash,
+		mev_blocks.proposer_index,
+		mev_blocks.seenby_relays,
+		mev_blocks.fee_recipient,
+		mev_blocks.block_value_gwei,
+		COALESCE(slots.status, 0) AS payload_status,
+		slots.eth_fee_recipient,
+		slots.eth_payment_recipient,
+		(SELECT COUNT(*) FROM slots AS canonical WHERE canonical.slot = mev_blocks.slot_number AND canonical.status = 1) AS canonical_count
+	FROM mev_blocks
+	LEFT JOIN slots ON slots.eth_block_hash = mev_blocks.block_hash AND slots.status IN (1, 2)
+	WHERE mev_blocks.slot_number >= $1 AND mev_blocks.slot_number <= $2
+	ORDER BY mev_blocks.slot_number DESC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching mev block payload infos: %v", err)
+		return nil, err
+	}
+	return payloadInfos, nil
+}
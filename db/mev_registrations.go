@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertMevValidatorRegistrations(registrations []*dbtypes.MevValidatorRegistration, tx *sqlx.Tx) error {
+	var sql strings.Builder
+	fmt.Fprint(&sql,
+		EngineQuery(map[dbtypes.DBEngineType]string{
+			dbtypes.DBEnginePgsql:  "INSERT INTO mev_validator_registrations ",
+			dbtypes.DBEngineSqlite: "INSERT OR IGNORE INTO mev_validator_registrations ",
+		}),
+		"(validator_index, relay_id, timestamp, fee_recipient, gas_limit, first_seen)",
+		" VALUES ",
+	)
+	argIdx := 0
+	fieldCount := 6
+
+	args := make([]any, len(registrations)*fieldCount)
+	for i, registration := range registrations {
+		if i > 0 {
+			fmt.Fprintf(&sql, ", ")
+		}
+		fmt.Fprintf(&sql, "(")
+		for f := 0; f < fieldCount; f++ {
+			if f > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			fmt.Fprintf(&sql, "$%v", argIdx+f+1)
+		}
+		fmt.Fprintf(&sql, ")")
+
+		args[argIdx+0] = registration.ValidatorIndex
+		args[argIdx+1] = registration.RelayId
+		args[argIdx+2] = registration.Timestamp
+		args[argIdx+3] = registration.FeeRecipient
+		args[argIdx+4] = registration.GasLimit
+		args[argIdx+5] = registration.FirstSeen
+		argIdx += fieldCount
+	}
+	fmt.Fprint(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql:  " ON CONFLICT (validator_index, relay_id, timestamp) DO NOTHING",
+		dbtypes.DBEngineSqlite: "",
+	}))
+
+	_, err := tx.Exec(sql.String(), args...)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// GetMevValidatorRegistrations returns the registration history of a validator, newest first.
+func GetMevValidatorRegistrations(validatorIndex uint64, limit uint64) ([]*dbtypes.MevValidatorRegistration, error) {
+	registrations := []*dbtypes.MevValidatorRegistration{}
+	err := ReaderDb.Select(&registrations, `
+	SELECT
+		validator_index, relay_id, timestamp, fee_recipient, gas_limit, first_seen
+	FROM mev_validator_registrations
+	WHERE validator_index = $1
+	ORDER BY timestamp DESC, relay_id ASC
+	LIMIT $2
+	`, validatorIndex, limit)
+	if err != nil {
+		logger.Errorf("Error while fetching mev validator registrations: %v", err)
+		return nil, err
+	}
+	return registrations, nil
+}
@@ -9,15 +9,15 @@ func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
 	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO orphaned_blocks (
-				root, header_ver, header_ssz, block_ver, block_ssz
-			) VALUES ($1, $2, $3, $4, $5)
+				root, header_ver, header_ssz, block_ver, block_ssz, recv_ts
+			) VALUES ($1, $2, $3, $4, $5, $6)
 			ON CONFLICT (root) DO NOTHING`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR IGNORE INTO orphaned_blocks (
-				root, header_ver, header_ssz, block_ver, block_ssz
-			) VALUES ($1, $2, $3, $4, $5)`,
+				root, header_ver, header_ssz, block_ver, block_ssz, recv_ts
+			) VALUES ($1, $2, $3, $4, $5, $6)`,
 	}),
-		block.Root, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ)
+		block.Root, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ, block.RecvTs)
 	if err != nil {
 		return err
 	}
@@ -27,7 +27,7 @@ func InsertOrphanedBlock(block *dbtypes.OrphanedBlock, tx *sqlx.Tx) error {
 func GetOrphanedBlock(root []byte) *dbtypes.OrphanedBlock {
 	block := dbtypes.OrphanedBlock{}
 	err := ReaderDb.Get(&block, `
-	SELECT root, header_ver, header_ssz, block_ver, block_ssz
+	SELECT root, header_ver, header_ssz, block_ver, block_ssz, recv_ts
 	FROM orphaned_blocks
 	WHERE root = $1
 	`, root)
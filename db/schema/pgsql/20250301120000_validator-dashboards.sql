@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."validator_dashboards" (
+    dashboard_key VARCHAR(32) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    filter_args TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    last_access BIGINT NOT NULL,
+    CONSTRAINT validator_dashboards_pkey PRIMARY KEY (dashboard_key)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."alert_deliveries" (
+    id BIGSERIAL NOT NULL,
+    rule_name VARCHAR(100) NOT NULL,
+    webhook_name VARCHAR(100) NOT NULL,
+    alert_type VARCHAR(50) NOT NULL,
+    title TEXT NOT NULL,
+    status SMALLINT NOT NULL,
+    attempts INT NOT NULL,
+    error TEXT NULL,
+    created_at BIGINT NOT NULL,
+    delivered_at BIGINT NOT NULL,
+    CONSTRAINT alert_deliveries_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS "alert_deliveries_created_at_idx"
+    ON public."alert_deliveries" ("created_at" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS mev_bids (
+    slot_number BIGINT NOT NULL,
+    relay_id INT NOT NULL,
+    block_hash bytea NOT NULL,
+    builder_pubkey bytea NOT NULL,
+    value_gwei BIGINT NOT NULL,
+    timestamp_ms BIGINT NOT NULL,
+    CONSTRAINT mev_bids_pkey PRIMARY KEY (slot_number, relay_id, block_hash)
+);
+
+CREATE INDEX IF NOT EXISTS "mev_bids_builder_pubkey_idx"
+    ON public."mev_bids"
+    ("builder_pubkey" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."slots"
+    ADD "eth_fee_recipient" bytea NULL;
+
+ALTER TABLE public."slots"
+    ADD "eth_payment_recipient" bytea NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
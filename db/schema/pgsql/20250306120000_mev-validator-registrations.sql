@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS mev_validator_registrations (
+    validator_index BIGINT NOT NULL,
+    relay_id INT NOT NULL,
+    timestamp BIGINT NOT NULL,
+    fee_recipient bytea NOT NULL,
+    gas_limit BIGINT NOT NULL,
+    first_seen BIGINT NOT NULL,
+    CONSTRAINT mev_validator_registrations_pkey PRIMARY KEY (validator_index, relay_id, timestamp)
+);
+
+CREATE INDEX IF NOT EXISTS "mev_validator_registrations_fee_recipient_idx"
+    ON public."mev_validator_registrations"
+    ("fee_recipient" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
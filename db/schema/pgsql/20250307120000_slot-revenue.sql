@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."slots"
+    ADD "eth_payment_value" BIGINT NULL;
+
+ALTER TABLE public."slots"
+    ADD "eth_burnt_fees" BIGINT NULL;
+
+ALTER TABLE public."slots"
+    ADD "eth_priority_fees" BIGINT NULL;
+
+CREATE INDEX IF NOT EXISTS "slots_eth_fee_recipient_idx"
+    ON public."slots"
+    ("eth_fee_recipient" ASC NULLS LAST);
+
+CREATE INDEX IF NOT EXISTS "slots_eth_payment_recipient_idx"
+    ON public."slots"
+    ("eth_payment_recipient" ASC NULLS LAST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE public."slots"
+    ADD "eth_blob_count" INT NOT NULL DEFAULT 0;
+
+ALTER TABLE public."slots"
+    ADD "eth_blob_gas_used" BIGINT NULL;
+
+ALTER TABLE public."slots"
+    ADD "eth_excess_blob_gas" BIGINT NULL;
+
+ALTER TABLE public."slots"
+    ADD "eth_blob_base_fee" BIGINT NULL;
+
+CREATE TABLE IF NOT EXISTS blob_submissions (
+    slot_number BIGINT NOT NULL,
+    slot_root bytea NOT NULL,
+    tx_index INT NOT NULL,
+    tx_hash bytea NOT NULL,
+    tx_from bytea NOT NULL,
+    blob_count INT NOT NULL,
+    orphaned bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT blob_submissions_pkey PRIMARY KEY (slot_root, tx_index)
+);
+
+CREATE INDEX IF NOT EXISTS "blob_submissions_slot_number_idx"
+    ON public."blob_submissions"
+    ("slot_number" ASC NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS "blob_submissions_tx_from_idx"
+    ON public."blob_submissions"
+    ("tx_from" ASC NULLS FIRST);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
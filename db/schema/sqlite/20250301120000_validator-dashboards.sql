@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "validator_dashboards" (
+    dashboard_key TEXT NOT NULL,
+    name TEXT NOT NULL,
+    filter_args TEXT NOT NULL,
+    created_at BIGINT NOT NULL,
+    last_access BIGINT NOT NULL,
+    PRIMARY KEY (dashboard_key)
+);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "alert_deliveries" (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    rule_name TEXT NOT NULL,
+    webhook_name TEXT NOT NULL,
+    alert_type TEXT NOT NULL,
+    title TEXT NOT NULL,
+    status TINYINT NOT NULL,
+    attempts INT NOT NULL,
+    error TEXT NULL,
+    created_at BIGINT NOT NULL,
+    delivered_at BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "alert_deliveries_created_at_idx"
+    ON "alert_deliveries" ("created_at" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "unfinalized_blocks"
+    ADD "recv_ts" BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE "orphaned_blocks"
+    ADD "recv_ts" BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
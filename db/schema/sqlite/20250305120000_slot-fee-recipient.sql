@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "slots"
+    ADD "eth_fee_recipient" BLOB NULL;
+
+ALTER TABLE "slots"
+    ADD "eth_payment_recipient" BLOB NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "slots"
+    ADD "eth_blob_count" INT NOT NULL DEFAULT 0;
+
+ALTER TABLE "slots"
+    ADD "eth_blob_gas_used" BIGINT NULL;
+
+ALTER TABLE "slots"
+    ADD "eth_excess_blob_gas" BIGINT NULL;
+
+ALTER TABLE "slots"
+    ADD "eth_blob_base_fee" BIGINT NULL;
+
+CREATE TABLE IF NOT EXISTS blob_submissions (
+    slot_number BIGINT NOT NULL,
+    slot_root BLOB NOT NULL,
+    tx_index INT NOT NULL,
+    tx_hash BLOB NOT NULL,
+    tx_from BLOB NOT NULL,
+    blob_count INT NOT NULL,
+    orphaned bool NOT NULL DEFAULT FALSE,
+    CONSTRAINT blob_submissions_pkey PRIMARY KEY (slot_root, tx_index)
+);
+
+CREATE INDEX IF NOT EXISTS "blob_submissions_slot_number_idx"
+    ON "blob_submissions"
+    ("slot_number" ASC);
+
+CREATE INDEX IF NOT EXISTS "blob_submissions_tx_from_idx"
+    ON "blob_submissions"
+    ("tx_from" ASC);
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
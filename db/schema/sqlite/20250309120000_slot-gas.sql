@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "slots"
+    ADD "eth_gas_used" BIGINT NULL;
+
+ALTER TABLE "slots"
+    ADD "eth_gas_limit" BIGINT NULL;
+
+ALTER TABLE "slots"
+    ADD "eth_base_fee" BIGINT NULL;
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "validator_history" (
+    validator_index BIGINT NOT NULL,
+    start_epoch BIGINT NOT NULL,
+    end_epoch BIGINT NOT NULL,
+    changed_fields INT NOT NULL,
+    withdrawal_credentials BLOB NOT NULL,
+    effective_balance BIGINT NOT NULL,
+    slashed BOOLEAN NOT NULL,
+    activation_eligibility_epoch BIGINT NOT NULL,
+    activation_epoch BIGINT NOT NULL,
+    exit_epoch BIGINT NOT NULL,
+    withdrawable_epoch BIGINT NOT NULL,
+    PRIMARY KEY (validator_index, start_epoch)
+);
+
+CREATE INDEX IF NOT EXISTS "validator_history_epoch_range_idx"
+    ON "validator_history" ("start_epoch", "end_epoch");
+
+-- seed the history with the current validator set, which is valid since the last finalized epoch in the db.
+-- earlier states are unknown, so the seeded epoch is stored as history start in the explorer state.
+INSERT OR IGNORE INTO "validator_history" (
+    validator_index, start_epoch, end_epoch, changed_fields, withdrawal_credentials, effective_balance,
+    slashed, activation_eligibility_epoch, activation_epoch, exit_epoch, withdrawable_epoch
+)
+SELECT
+    validator_index, (SELECT COALESCE(MAX(epoch) + 1, 0) FROM "epochs"), 9223372036854775807, 127, withdrawal_credentials, effective_balance,
+    slashed, activation_eligibility_epoch, activation_epoch, exit_epoch, withdrawable_epoch
+FROM "validators";
+
+INSERT OR IGNORE INTO "explorer_state" ("key", "value")
+SELECT 'indexer.validatorhistory', '{"start_epoch":' || (SELECT COALESCE(MAX(epoch) + 1, 0) FROM "epochs") || '}'
+WHERE EXISTS (SELECT 1 FROM "validators");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+const slotRevenueFields = `
+	slots.slot, slots.proposer, slots.root, slots.eth_block_number, slots.eth_block_hash, slots.eth_fee_recipient,
+	slots.eth_payment_recipient, slots.eth_payment_value, slots.eth_burnt_fees, slots.eth_priority_fees,
+	(SELECT MAX(mev_blocks.block_value_gwei) FROM mev_blocks WHERE mev_blocks.block_hash = slots.eth_block_hash) AS mev_block_value
+`
+
+// GetSlotRevenuesWithoutPriorityFees returns the canonical blocks within the slot range that have no priority fees calculated yet.
+func GetSlotRevenuesWithoutPriorityFees(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotRevenue, error) {
+	revenues := []*dbtypes.SlotRevenue{}
+	err := ReaderDb.Select(&revenues, `
+	SELECT `+slotRevenueFields+`
+	FROM slots
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 1 AND slots.eth_block_hash IS NOT NULL AND slots.eth_priority_fees IS NULL
+	ORDER BY slots.slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching slot revenues: %v", err)
+		return nil, err
+	}
+	return revenues, nil
+}
+
+func UpdateSlotPriorityFees(root []byte, priorityFees uint64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`UPDATE slots SET eth_priority_fees = $1 WHERE root = $2`, priorityFees, root)
+	return err
+}
+
+// GetSlotRevenuesByProposer returns the revenue details of the canonical blocks proposed by a validator, newest first.
+func GetSlotRevenuesByProposer(proposer uint64, offset uint64, limit uint64) ([]*dbtypes.SlotRevenue, error) {
+	revenues := []*dbtypes.SlotRevenue{}
+	err := ReaderDb.Select(&revenues, `
+	SELECT `+slotRevenueFields+`
+	FROM slots
+	WHERE slots.proposer = $1 AND slots.status = 1 AND slots.eth_block_hash IS NOT NULL
+	ORDER BY slots.slot DESC
+	LIMIT $2 OFFSET $3
+	`, proposer, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer revenues: %v", err)
+		return nil, err
+	}
+	return revenues, nil
+}
+
+// GetSlotRevenuesByFeeRecipient returns the canonical blocks that paid the given address, either as payload fee recipient or via a builder payment, newest first.
+func GetSlotRevenuesByFeeRecipient(address []byte, offset uint64, limit uint64) ([]*dbtypes.SlotRevenue, uint64, error) {
+	var totalCount uint64
+	err := ReaderDb.Get(&totalCount, `
+	SELECT COUNT(*)
+	FROM slots
+	WHERE (slots.eth_fee_recipient = $1 OR slots.eth_payment_recipient = $1) AND slots.status = 1
+	`, address)
+	if err != nil {
+		logger.Errorf("Error while counting fee recipient revenues: %v", err)
+		return nil, 0, err
+	}
+
+	revenues := []*dbtypes.SlotRevenue{}
+	err = ReaderDb.Select(&revenues, `
+	SELECT `+slotRevenueFields+`
+	FROM slots
+	WHERE (slots.eth_fee_recipient = $1 OR slots.eth_payment_recipient = $1) AND slots.status = 1
+	ORDER BY slots.slot DESC
+	LIMIT $2 OFFSET $3
+	`, address, limit, offset)
+	if err != nil {
+		logger.Errorf("Error while fetching fee recipient revenues: %v", err)
+		return nil, 0, err
+	}
+	return revenues, totalCount, nil
+}
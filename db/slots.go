@@ -19,25 +19,37 @@ func InsertSlot(slot *dbtypes.Slot, tx *sqlx.Tx) error {
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)
+				eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+				eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+				sync_participation, fork_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32, $33, $34, $35)
 			ON CONFLICT (slot, root) DO UPDATE SET
 				status = excluded.status,
 				eth_block_extra = excluded.eth_block_extra,
 				eth_block_extra_text = excluded.eth_block_extra_text,
+				eth_fee_recipient = excluded.eth_fee_recipient,
+				eth_payment_recipient = excluded.eth_payment_recipient,
+				eth_payment_value = excluded.eth_payment_value,
+				eth_burnt_fees = excluded.eth_burnt_fees,
+				eth_priority_fees = COALESCE(excluded.eth_priority_fees, slots.eth_priority_fees),
 				fork_id = excluded.fork_id`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR REPLACE INTO slots (
 				slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 				attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 				proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-				eth_block_extra, eth_block_extra_text, sync_participation, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23)`,
+				eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+				eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+				sync_participation, fork_id
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25,
+				COALESCE($26, (SELECT eth_priority_fees FROM slots WHERE slot = $1 AND root = $4)), $27, $28, $29, $30, $31, $32, $33, $34, $35)`,
 	}),
 		slot.Slot, slot.Proposer, slot.Status, slot.Root, slot.ParentRoot, slot.StateRoot, slot.Graffiti, slot.GraffitiText,
 		slot.AttestationCount, slot.DepositCount, slot.ExitCount, slot.WithdrawCount, slot.WithdrawAmount, slot.AttesterSlashingCount,
 		slot.ProposerSlashingCount, slot.BLSChangeCount, slot.EthTransactionCount, slot.EthBlockNumber, slot.EthBlockHash,
-		slot.EthBlockExtra, slot.EthBlockExtraText, slot.SyncParticipation, slot.ForkId)
+		slot.EthBlockExtra, slot.EthBlockExtraText, slot.EthFeeRecipient, slot.EthPaymentRecipient, slot.EthPaymentValue, slot.EthBurntFees,
+		slot.EthPriorityFees, slot.EthBlobCount, slot.EthBlobGasUsed, slot.EthExcessBlobGas, slot.EthBlobBaseFee, slot.EthGasUsed, slot.EthGasLimit, slot.EthBaseFee,
+		slot.SyncParticipation, slot.ForkId)
 	if err != nil {
 		return err
 	}
@@ -92,7 +104,9 @@ func GetSlotsRange(firstSlot uint64, lastSlot uint64, withMissing bool, withOrph
 		"state_root", "root", "slot", "proposer", "status", "parent_root", "graffiti", "graffiti_text",
 		"attestation_count", "deposit_count", "exit_count", "withdraw_count", "withdraw_amount", "attester_slashing_count",
 		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash",
-		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id",
+		"eth_block_extra", "eth_block_extra_text", "eth_fee_recipient", "eth_payment_recipient", "eth_payment_value", "eth_burnt_fees", "eth_priority_fees",
+		"eth_blob_count", "eth_blob_gas_used", "eth_excess_blob_gas", "eth_blob_base_fee", "eth_gas_used", "eth_gas_limit", "eth_base_fee",
+		"sync_participation", "fork_id",
 	}
 	for _, blockField := range blockFields {
 		fmt.Fprintf(&sql, ", slots.%v AS \"block.%v\"", blockField, blockField)
@@ -124,7 +138,9 @@ func GetSlotsByParentRoot(parentRoot []byte) []*dbtypes.Slot {
 		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+		eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+		sync_participation, fork_id
 	FROM slots
 	WHERE parent_root = $1
 	ORDER BY slot DESC
@@ -143,7 +159,9 @@ func GetSlotByRoot(root []byte) *dbtypes.Slot {
 		root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+		eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+		sync_participation, fork_id
 	FROM slots
 	WHERE root = $1
 	`, root)
@@ -169,7 +187,9 @@ func GetSlotsByRoots(roots [][]byte) map[phase0.Root]*dbtypes.Slot {
 			root, slot, parent_root, state_root, status, proposer, graffiti, graffiti_text,
 			attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 			proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash,
-			eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+			eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+			eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+			sync_participation, fork_id
 		FROM slots
 		WHERE root IN (%v)
 		ORDER BY slot DESC`,
@@ -212,7 +232,9 @@ func GetSlotsByBlockHash(blockHash []byte) []*dbtypes.Slot {
 		slot, proposer, status, root, parent_root, state_root, graffiti, graffiti_text,
 		attestation_count, deposit_count, exit_count, withdraw_count, withdraw_amount, attester_slashing_count, 
 		proposer_slashing_count, bls_change_count, eth_transaction_count, eth_block_number, eth_block_hash, 
-		eth_block_extra, eth_block_extra_text, sync_participation, fork_id
+		eth_block_extra, eth_block_extra_text, eth_fee_recipient, eth_payment_recipient, eth_payment_value, eth_burnt_fees,
+		eth_priority_fees, eth_blob_count, eth_blob_gas_used, eth_excess_blob_gas, eth_blob_base_fee, eth_gas_used, eth_gas_limit, eth_base_fee,
+		sync_participation, fork_id
 	FROM slots
 	WHERE eth_block_hash = $1
 	ORDER BY slot DESC
@@ -272,7 +294,9 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 		"state_root", "root", "slot", "proposer", "status", "parent_root", "graffiti", "graffiti_text",
 		"attestation_count", "deposit_count", "exit_count", "withdraw_count", "withdraw_amount", "attester_slashing_count",
 		"proposer_slashing_count", "bls_change_count", "eth_transaction_count", "eth_block_number", "eth_block_hash",
-		"eth_block_extra", "eth_block_extra_text", "sync_participation", "fork_id",
+		"eth_block_extra", "eth_block_extra_text", "eth_fee_recipient", "eth_payment_recipient", "eth_payment_value", "eth_burnt_fees", "eth_priority_fees",
+		"eth_blob_count", "eth_blob_gas_used", "eth_excess_blob_gas", "eth_blob_base_fee", "eth_gas_used", "eth_gas_limit", "eth_base_fee",
+		"sync_participation", "fork_id",
 	}
 	for _, blockField := range blockFields {
 		fmt.Fprintf(&sql, ", slots.%v AS \"block.%v\"", blockField, blockField)
@@ -289,6 +313,12 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 	fmt.Fprintf(&sql, ` WHERE slots.slot < $%v `, argIdx)
 	args = append(args, firstSlot)
 
+	if filter.MaxSlot > 0 {
+		argIdx++
+		fmt.Fprintf(&sql, ` AND slots.slot <= $%v `, argIdx)
+		args = append(args, filter.MaxSlot)
+	}
+
 	if filter.WithMissing == 0 {
 		fmt.Fprintf(&sql, ` AND slots.status != 0 `)
 	} else if filter.WithMissing == 2 {
@@ -304,6 +334,18 @@ func GetFilteredSlots(filter *dbtypes.BlockFilter, firstSlot uint64, offset uint
 		fmt.Fprintf(&sql, ` AND slots.proposer = $%v `, argIdx)
 		args = append(args, *filter.ProposerIndex)
 	}
+	if len(filter.ProposerIndexes) > 0 {
+		fmt.Fprintf(&sql, ` AND slots.proposer IN (`)
+		for i, proposerIndex := range filter.ProposerIndexes {
+			if i > 0 {
+				fmt.Fprintf(&sql, ", ")
+			}
+			argIdx++
+			fmt.Fprintf(&sql, "$%v", argIdx)
+			args = append(args, proposerIndex)
+		}
+		fmt.Fprintf(&sql, ") ")
+	}
 	if filter.Graffiti != "" {
 		argIdx++
 		fmt.Fprintf(&sql, EngineQuery(map[dbtypes.DBEngineType]string{
@@ -404,3 +446,93 @@ func GetSlotAssignment(slot uint64) uint64 {
 	}
 	return proposer
 }
+
+func GetProposerSlotCounts(firstSlot uint64, lastSlot uint64) ([]*dbtypes.ProposerSlotCount, error) {
+	slotCounts := []*dbtypes.ProposerSlotCount{}
+	err := ReaderDb.Select(&slotCounts, `
+	SELECT
+		proposer, status, COUNT(*) AS count
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2
+	GROUP BY proposer, status
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching proposer slot counts: %v", err)
+		return nil, err
+	}
+	return slotCounts, nil
+}
+
+func GetSlotClientInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotClientInfo, error) {
+	clientInfos := []*dbtypes.SlotClientInfo{}
+	err := ReaderDb.Select(&clientInfos, `
+	SELECT
+		slot, COALESCE(graffiti_text, '') AS graffiti_text, COALESCE(eth_block_extra_text, '') AS eth_block_extra_text
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching slot client infos: %v", err)
+		return nil, err
+	}
+	return clientInfos, nil
+}
+
+func GetSlotGasInfos(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotGasInfo, error) {
+	gasInfos := []*dbtypes.SlotGasInfo{}
+	err := ReaderDb.Select(&gasInfos, `
+	SELECT
+		slot, proposer, eth_gas_used, eth_gas_limit, eth_base_fee
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 1 AND eth_gas_limit IS NOT NULL
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching slot gas infos: %v", err)
+		return nil, err
+	}
+	return gasInfos, nil
+}
+
+func GetMissedSlots(firstSlot uint64, lastSlot uint64) ([]*dbtypes.SlotAssignment, error) {
+	missedSlots := []*dbtypes.SlotAssignment{}
+	err := ReaderDb.Select(&missedSlots, `
+	SELECT
+		slot, proposer
+	FROM slots
+	WHERE slot >= $1 AND slot <= $2 AND status = 0
+	ORDER BY slot ASC
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching missed slots: %v", err)
+		return nil, err
+	}
+	return missedSlots, nil
+}
+
+func GetOrphanedSlotRecvTimes(firstSlot uint64, lastSlot uint64) (map[uint64]int64, error) {
+	rows := []struct {
+		Slot   uint64 `db:"slot"`
+		RecvTs int64  `db:"recv_ts"`
+	}{}
+	err := ReaderDb.Select(&rows, `
+	SELECT
+		slots.slot,
+		COALESCE(MIN(NULLIF(orphaned_blocks.recv_ts, 0)), 0) AS recv_ts
+	FROM slots
+	LEFT JOIN orphaned_blocks ON orphaned_blocks.root = slots.root
+	WHERE slots.slot >= $1 AND slots.slot <= $2 AND slots.status = 2
+	GROUP BY slots.slot
+	`, firstSlot, lastSlot)
+	if err != nil {
+		logger.Errorf("Error while fetching orphaned slot receive times: %v", err)
+		return nil, err
+	}
+
+	recvTimes := make(map[uint64]int64, len(rows))
+	for _, row := range rows {
+		recvTimes[row.Slot] = row.RecvTs
+	}
+	return recvTimes, nil
+}
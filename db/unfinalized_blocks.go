@@ -12,15 +12,15 @@ func InsertUnfinalizedBlock(block *dbtypes.UnfinalizedBlock, tx *sqlx.Tx) error
 	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
 		dbtypes.DBEnginePgsql: `
 			INSERT INTO unfinalized_blocks (
-				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id, recv_ts
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 			ON CONFLICT (root) DO NOTHING`,
 		dbtypes.DBEngineSqlite: `
 			INSERT OR IGNORE INTO unfinalized_blocks (
-				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+				root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id, recv_ts
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
 	}),
-		block.Root, block.Slot, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ, block.Status, block.ForkId)
+		block.Root, block.Slot, block.HeaderVer, block.HeaderSSZ, block.BlockVer, block.BlockSSZ, block.Status, block.ForkId, block.RecvTs)
 	if err != nil {
 		return err
 	}
@@ -83,7 +83,7 @@ func GetUnfinalizedBlocks(filter *dbtypes.UnfinalizedBlockFilter) []*dbtypes.Unf
 	var sql strings.Builder
 	args := []any{}
 
-	fmt.Fprint(&sql, `SELECT root, slot, status, fork_id, header_ver, header_ssz`)
+	fmt.Fprint(&sql, `SELECT root, slot, status, fork_id, recv_ts, header_ver, header_ssz`)
 
 	if filter == nil || filter.WithBody {
 		fmt.Fprint(&sql, `, block_ver, block_ssz`)
@@ -120,7 +120,7 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 	var sql strings.Builder
 	args := []any{slot}
 
-	fmt.Fprint(&sql, `SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id FROM unfinalized_blocks WHERE slot >= $1`)
+	fmt.Fprint(&sql, `SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id, recv_ts FROM unfinalized_blocks WHERE slot >= $1`)
 
 	rows, err := ReaderDb.Query(sql.String(), args...)
 	if err != nil {
@@ -130,7 +130,7 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 
 	for rows.Next() {
 		block := dbtypes.UnfinalizedBlock{}
-		err := rows.Scan(&block.Root, &block.Slot, &block.HeaderVer, &block.HeaderSSZ, &block.BlockVer, &block.BlockSSZ, &block.Status, &block.ForkId)
+		err := rows.Scan(&block.Root, &block.Slot, &block.HeaderVer, &block.HeaderSSZ, &block.BlockVer, &block.BlockSSZ, &block.Status, &block.ForkId, &block.RecvTs)
 		if err != nil {
 			logger.Errorf("Error while scanning unfinalized block: %v", err)
 			return err
@@ -144,7 +144,7 @@ func StreamUnfinalizedBlocks(slot uint64, cb func(block *dbtypes.UnfinalizedBloc
 func GetUnfinalizedBlock(root []byte) *dbtypes.UnfinalizedBlock {
 	block := dbtypes.UnfinalizedBlock{}
 	err := ReaderDb.Get(&block, `
-	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id
+	SELECT root, slot, header_ver, header_ssz, block_ver, block_ssz, status, fork_id, recv_ts
 	FROM unfinalized_blocks
 	WHERE root = $1
 	`, root)
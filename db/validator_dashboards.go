@@ -0,0 +1,40 @@
+package db
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+func InsertValidatorDashboard(dashboard *dbtypes.ValidatorDashboard, tx *sqlx.Tx) error {
+	_, err := tx.Exec(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_dashboards (dashboard_key, name, filter_args, created_at, last_access)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT (dashboard_key) DO NOTHING`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR IGNORE INTO validator_dashboards (dashboard_key, name, filter_args, created_at, last_access)
+			VALUES ($1, $2, $3, $4, $5)`,
+	}), dashboard.DashboardKey, dashboard.Name, dashboard.FilterArgs, dashboard.CreatedAt, dashboard.LastAccess)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func GetValidatorDashboard(key string) *dbtypes.ValidatorDashboard {
+	dashboard := dbtypes.ValidatorDashboard{}
+	err := ReaderDb.Get(&dashboard, `
+	SELECT dashboard_key, name, filter_args, created_at, last_access
+	FROM validator_dashboards
+	WHERE dashboard_key = $1
+	`, key)
+	if err != nil {
+		return nil
+	}
+	return &dashboard
+}
+
+func UpdateValidatorDashboardAccess(key string, lastAccess int64, tx *sqlx.Tx) error {
+	_, err := tx.Exec(`UPDATE validator_dashboards SET last_access = $1 WHERE dashboard_key = $2`, lastAccess, key)
+	return err
+}
@@ -0,0 +1,234 @@
+package db
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/jmoiron/sqlx"
+)
+
+const validatorHistoryColumns = `
+	validator_index, start_epoch, end_epoch, changed_fields, withdrawal_credentials, effective_balance,
+	slashed, activation_eligibility_epoch, activation_epoch, exit_epoch, withdrawable_epoch`
+
+// GetValidatorHistoryChanges returns the bitmask of fields that differ between two validator history entries
+func GetValidatorHistoryChanges(prevEntry *dbtypes.ValidatorHistory, entry *dbtypes.ValidatorHistory) uint32 {
+	changedFields := uint32(0)
+	if !bytes.Equal(prevEntry.WithdrawalCredentials, entry.WithdrawalCredentials) {
+		changedFields |= dbtypes.ValidatorHistoryWithdrawalCredentials
+	}
+	if prevEntry.EffectiveBalance != entry.EffectiveBalance {
+		changedFields |= dbtypes.ValidatorHistoryEffectiveBalance
+	}
+	if prevEntry.Slashed != entry.Slashed {
+		changedFields |= dbtypes.ValidatorHistorySlashed
+	}
+	if prevEntry.ActivationEligibilityEpoch != entry.ActivationEligibilityEpoch {
+		changedFields |= dbtypes.ValidatorHistoryActivationEligibilityEpoch
+	}
+	if prevEntry.ActivationEpoch != entry.ActivationEpoch {
+		changedFields |= dbtypes.ValidatorHistoryActivationEpoch
+	}
+	if prevEntry.ExitEpoch != entry.ExitEpoch {
+		changedFields |= dbtypes.ValidatorHistoryExitEpoch
+	}
+	if prevEntry.WithdrawableEpoch != entry.WithdrawableEpoch {
+		changedFields |= dbtypes.ValidatorHistoryWithdrawableEpoch
+	}
+	return changedFields
+}
+
+// InsertValidatorHistoryBatch records new validator states in the validator history.
+// The currently open history entry of each validator is closed at the start epoch of the new state.
+// States without any field change compared to the open entry are skipped.
+// A batch may contain multiple states per validator, which need to be ordered by start epoch.
+func InsertValidatorHistoryBatch(entries []*dbtypes.ValidatorHistory, tx *sqlx.Tx) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// load currently open history entries
+	var sql strings.Builder
+	args := []interface{}{dbtypes.ValidatorHistoryOpenEpoch}
+	argMap := make(map[uint64]bool, len(entries))
+	fmt.Fprintf(&sql, `SELECT %v FROM validator_history WHERE end_epoch = $1 AND validator_index IN (`, validatorHistoryColumns)
+	for _, entry := range entries {
+		if argMap[entry.ValidatorIndex] {
+			continue
+		}
+		if len(argMap) > 0 {
+			fmt.Fprint(&sql, ", ")
+		}
+		argMap[entry.ValidatorIndex] = true
+		args = append(args, entry.ValidatorIndex)
+		fmt.Fprintf(&sql, "$%v", len(args))
+	}
+	fmt.Fprint(&sql, ")")
+
+	openEntries := []*dbtypes.ValidatorHistory{}
+	err := tx.Select(&openEntries, sql.String(), args...)
+	if err != nil {
+		return fmt.Errorf("error loading open validator history entries: %v", err)
+	}
+
+	openEntryMap := make(map[uint64]*dbtypes.ValidatorHistory, len(openEntries))
+	for _, openEntry := range openEntries {
+		openEntryMap[openEntry.ValidatorIndex] = openEntry
+	}
+
+	// the open entry of a validator may be a row of this batch, which is updated in place instead of being added twice
+	openRowMap := make(map[uint64]int, len(entries))
+
+	rows := make([]*dbtypes.ValidatorHistory, 0, len(entries)*2)
+	for _, entry := range entries {
+		entry.EndEpoch = dbtypes.ValidatorHistoryOpenEpoch
+		openRow, hasOpenRow := openRowMap[entry.ValidatorIndex]
+
+		if openEntry := openEntryMap[entry.ValidatorIndex]; openEntry == nil {
+			entry.ChangedFields = dbtypes.ValidatorHistoryAllFields
+		} else if changedFields := GetValidatorHistoryChanges(openEntry, entry); changedFields == 0 {
+			continue
+		} else if openEntry.StartEpoch >= entry.StartEpoch {
+			// state changed again within the epoch of the open entry, replace it
+			entry.StartEpoch = openEntry.StartEpoch
+			entry.ChangedFields = openEntry.ChangedFields | changedFields
+			if hasOpenRow {
+				rows[openRow] = entry
+				openEntryMap[entry.ValidatorIndex] = entry
+				continue
+			}
+		} else {
+			openEntry.EndEpoch = entry.StartEpoch
+			entry.ChangedFields = changedFields
+			if !hasOpenRow {
+				rows = append(rows, openEntry)
+			}
+		}
+
+		openEntryMap[entry.ValidatorIndex] = entry
+		openRowMap[entry.ValidatorIndex] = len(rows)
+		rows = append(rows, entry)
+	}
+
+	const batchSize = 1000
+	for start := 0; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+		err := insertValidatorHistoryRows(rows[start:end], tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertValidatorHistoryRows(rows []*dbtypes.ValidatorHistory, tx *sqlx.Tx) error {
+	valueStrings := make([]string, len(rows))
+	valueArgs := make([]interface{}, 0, len(rows)*11)
+	for i, row := range rows {
+		valueStrings[i] = fmt.Sprintf("($%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v, $%v)",
+			i*11+1, i*11+2, i*11+3, i*11+4, i*11+5, i*11+6, i*11+7, i*11+8, i*11+9, i*11+10, i*11+11)
+		valueArgs = append(valueArgs,
+			row.ValidatorIndex,
+			row.StartEpoch,
+			row.EndEpoch,
+			row.ChangedFields,
+			row.WithdrawalCredentials,
+			row.EffectiveBalance,
+			row.Slashed,
+			row.ActivationEligibilityEpoch,
+			row.ActivationEpoch,
+			row.ExitEpoch,
+			row.WithdrawableEpoch)
+	}
+
+	stmt := fmt.Sprintf(EngineQuery(map[dbtypes.DBEngineType]string{
+		dbtypes.DBEnginePgsql: `
+			INSERT INTO validator_history (` + validatorHistoryColumns + `
+			) VALUES %s
+			ON CONFLICT (validator_index, start_epoch) DO UPDATE SET
+				end_epoch = excluded.end_epoch,
+				changed_fields = excluded.changed_fields,
+				withdrawal_credentials = excluded.withdrawal_credentials,
+				effective_balance = excluded.effective_balance,
+				slashed = excluded.slashed,
+				activation_eligibility_epoch = excluded.activation_eligibility_epoch,
+				activation_epoch = excluded.activation_epoch,
+				exit_epoch = excluded.exit_epoch,
+				withdrawable_epoch = excluded.withdrawable_epoch`,
+		dbtypes.DBEngineSqlite: `
+			INSERT OR REPLACE INTO validator_history (` + validatorHistoryColumns + `
+			) VALUES %s`,
+	}), strings.Join(valueStrings, ","))
+
+	_, err := tx.Exec(stmt, valueArgs...)
+	if err != nil {
+		return fmt.Errorf("error inserting validator history batch: %v", err)
+	}
+
+	return nil
+}
+
+// GetValidatorHistory returns the history entries of a validator, newest first.
+// If fieldMask is not 0, only entries that changed one of the given fields are returned.
+func GetValidatorHistory(validatorIndex uint64, fieldMask uint32) []*dbtypes.ValidatorHistory {
+	var sql strings.Builder
+	args := []interface{}{validatorIndex}
+	fmt.Fprintf(&sql, `SELECT %v FROM validator_history WHERE validator_index = $1`, validatorHistoryColumns)
+	if fieldMask != 0 {
+		args = append(args, fieldMask)
+		fmt.Fprintf(&sql, " AND (changed_fields & $%v) != 0", len(args))
+	}
+	fmt.Fprint(&sql, " ORDER BY start_epoch DESC")
+
+	entries := []*dbtypes.ValidatorHistory{}
+	err := ReaderDb.Select(&entries, sql.String(), args...)
+	if err != nil {
+		logger.Errorf("Error while fetching validator history: %v", err)
+		return nil
+	}
+	return entries
+}
+
+// GetValidatorHistoryAtEpoch returns the history entry of a validator that was valid at the given epoch
+func GetValidatorHistoryAtEpoch(validatorIndex uint64, epoch uint64) *dbtypes.ValidatorHistory {
+	entry := dbtypes.ValidatorHistory{}
+	err := ReaderDb.Get(&entry, fmt.Sprintf(`
+		SELECT %v FROM validator_history
+		WHERE validator_index = $1 AND start_epoch <= $2 AND end_epoch > $3
+	`, validatorHistoryColumns), validatorIndex, epoch, epoch)
+	if err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// GetValidatorStatusCountsAtEpoch returns the number of validators per status at the given epoch
+func GetValidatorStatusCountsAtEpoch(epoch uint64) (map[v1.ValidatorState]uint64, error) {
+	statusCounts := []struct {
+		Status uint64 `db:"status"`
+		Count  uint64 `db:"count"`
+	}{}
+
+	err := ReaderDb.Select(&statusCounts, fmt.Sprintf(`
+		SELECT status, COUNT(*) AS count FROM (
+			SELECT %v AS status
+			FROM validator_history
+			WHERE start_epoch <= $1 AND end_epoch > $2
+		) AS history_status
+		GROUP BY status
+	`, buildValidatorStatusSql(epoch)), epoch, epoch)
+	if err != nil {
+		logger.Errorf("Error while fetching validator status counts at epoch %v: %v", epoch, err)
+		return nil, err
+	}
+
+	statusMap := make(map[v1.ValidatorState]uint64, len(statusCounts))
+	for _, statusCount := range statusCounts {
+		statusMap[v1.ValidatorState(statusCount.Status)] = statusCount.Count
+	}
+	return statusMap, nil
+}
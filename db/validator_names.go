@@ -66,3 +66,11 @@ func DeleteValidatorNames(validatorNames []uint64, tx *sqlx.Tx) error {
 	}
 	return nil
 }
+
+func ClearValidatorNames(tx *sqlx.Tx) (int64, error) {
+	res, err := tx.Exec(`DELETE FROM validator_names`)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
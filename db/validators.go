@@ -160,9 +160,9 @@ func GetValidatorIndexesByFilter(filter dbtypes.ValidatorFilter, currentEpoch ui
 	fmt.Fprint(&sql, `
 	SELECT
 		validator_index
-	FROM validators
 	`)
 
+	args = buildValidatorSourceSql(filter.AsOfEpoch, &sql, args)
 	args = buildValidatorFilterSql(filter, currentEpoch, &sql, args)
 
 	switch filter.OrderBy {
@@ -269,7 +269,40 @@ func buildValidatorStatusSql(currentEpoch uint64) string {
 	`, math.MaxInt64, ConvertUint64ToInt64(currentEpoch), math.MaxInt64, ConvertUint64ToInt64(currentEpoch), ConvertUint64ToInt64(currentEpoch))
 }
 
+// buildValidatorSourceSql writes the source of the validator set to the query.
+// This is the validators table or, if asOfEpoch is set, the validator history entries that were valid at that epoch.
+func buildValidatorSourceSql(asOfEpoch *uint64, sql *strings.Builder, args []interface{}) []interface{} {
+	if asOfEpoch == nil {
+		fmt.Fprint(sql, ` FROM validators `)
+		return args
+	}
+
+	args = append(args, *asOfEpoch, *asOfEpoch)
+	fmt.Fprintf(sql, ` FROM (
+		SELECT
+			validator_history.validator_index, validators.pubkey, validator_history.withdrawal_credentials,
+			validator_history.effective_balance, validator_history.slashed,
+			validator_history.activation_eligibility_epoch, validator_history.activation_epoch,
+			validator_history.exit_epoch, validator_history.withdrawable_epoch
+		FROM validator_history
+		JOIN validators ON validators.validator_index = validator_history.validator_index
+		WHERE validator_history.start_epoch <= $%v AND validator_history.end_epoch > $%v
+	) AS validators `, len(args)-1, len(args))
+
+	return args
+}
+
+// StreamValidatorsByIndexes streams the current state of the validators with the given indexes in the given order
 func StreamValidatorsByIndexes(indexes []uint64, cb func(validator *dbtypes.Validator) bool) error {
+	return streamValidatorsByIndexes(indexes, nil, cb)
+}
+
+// StreamValidatorsByIndexesAtEpoch streams the state of the validators with the given indexes at a specific epoch
+func StreamValidatorsByIndexesAtEpoch(indexes []uint64, epoch uint64, cb func(validator *dbtypes.Validator) bool) error {
+	return streamValidatorsByIndexes(indexes, &epoch, cb)
+}
+
+func streamValidatorsByIndexes(indexes []uint64, asOfEpoch *uint64, cb func(validator *dbtypes.Validator) bool) error {
 	const batchSize = 1000
 
 	// Process in batches
@@ -286,16 +319,18 @@ func StreamValidatorsByIndexes(indexes []uint64, cb func(validator *dbtypes.Vali
 			validator_index, pubkey, withdrawal_credentials, effective_balance,
 			slashed, activation_eligibility_epoch, activation_epoch,
 			exit_epoch, withdrawable_epoch
-		FROM validators
-		WHERE validator_index in (`)
+		`)
+
+		args := make([]any, 0, len(batch)+2)
+		args = buildValidatorSourceSql(asOfEpoch, &sql, args)
+		fmt.Fprintf(&sql, ` WHERE validator_index in (`)
 
-		args := make([]any, len(batch))
 		for j, index := range batch {
 			if j > 0 {
 				fmt.Fprintf(&sql, ", ")
 			}
-			fmt.Fprintf(&sql, "$%v", j+1)
-			args[j] = index
+			args = append(args, index)
+			fmt.Fprintf(&sql, "$%v", len(args))
 		}
 		fmt.Fprintf(&sql, ")")
 
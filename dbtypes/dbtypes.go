@@ -1,5 +1,7 @@
 package dbtypes
 
+import "math"
+
 type ExplorerState struct {
 	Key   string `db:"key"`
 	Value string `db:"value"`
@@ -46,6 +48,18 @@ type Slot struct {
 	EthBlockHash          []byte     `db:"eth_block_hash"`
 	EthBlockExtra         []byte     `db:"eth_block_extra"`
 	EthBlockExtraText     string     `db:"eth_block_extra_text"`
+	EthFeeRecipient       []byte     `db:"eth_fee_recipient"`
+	EthPaymentRecipient   []byte     `db:"eth_payment_recipient"`
+	EthPaymentValue       *uint64    `db:"eth_payment_value"`
+	EthBurntFees          *uint64    `db:"eth_burnt_fees"`
+	EthPriorityFees       *uint64    `db:"eth_priority_fees"`
+	EthBlobCount          uint64     `db:"eth_blob_count"`
+	EthBlobGasUsed        *uint64    `db:"eth_blob_gas_used"`
+	EthExcessBlobGas      *uint64    `db:"eth_excess_blob_gas"`
+	EthBlobBaseFee        *uint64    `db:"eth_blob_base_fee"`
+	EthGasUsed            *uint64    `db:"eth_gas_used"`
+	EthGasLimit           *uint64    `db:"eth_gas_limit"`
+	EthBaseFee            *uint64    `db:"eth_base_fee"`
 	SyncParticipation     float32    `db:"sync_participation"`
 	ForkId                uint64     `db:"fork_id"`
 }
@@ -78,6 +92,7 @@ type OrphanedBlock struct {
 	HeaderSSZ []byte `db:"header_ssz"`
 	BlockVer  uint64 `db:"block_ver"`
 	BlockSSZ  []byte `db:"block_ssz"`
+	RecvTs    int64  `db:"recv_ts"`
 }
 
 type SlotAssignment struct {
@@ -108,6 +123,7 @@ type UnfinalizedBlock struct {
 	BlockSSZ  []byte                 `db:"block_ssz"`
 	Status    UnfinalizedBlockStatus `db:"status"`
 	ForkId    uint64                 `db:"fork_id"`
+	RecvTs    int64                  `db:"recv_ts"`
 }
 
 type UnfinalizedEpoch struct {
@@ -194,6 +210,48 @@ type MevBlock struct {
 	BlockValueGwei uint64 `db:"block_value_gwei"`
 }
 
+type MevBid struct {
+	SlotNumber    uint64 `db:"slot_number"`
+	RelayId       uint8  `db:"relay_id"`
+	BlockHash     []byte `db:"block_hash"`
+	BuilderPubkey []byte `db:"builder_pubkey"`
+	ValueGwei     uint64 `db:"value_gwei"`
+	TimestampMs   int64  `db:"timestamp_ms"`
+}
+
+type SlotRevenue struct {
+	Slot                uint64  `db:"slot"`
+	Proposer            uint64  `db:"proposer"`
+	Root                []byte  `db:"root"`
+	EthBlockNumber      *uint64 `db:"eth_block_number"`
+	EthBlockHash        []byte  `db:"eth_block_hash"`
+	EthFeeRecipient     []byte  `db:"eth_fee_recipient"`
+	EthPaymentRecipient []byte  `db:"eth_payment_recipient"`
+	EthPaymentValue     *uint64 `db:"eth_payment_value"`
+	EthBurntFees        *uint64 `db:"eth_burnt_fees"`
+	EthPriorityFees     *uint64 `db:"eth_priority_fees"`
+	MevBlockValue       *uint64 `db:"mev_block_value"`
+}
+
+type BlobSubmission struct {
+	SlotNumber uint64 `db:"slot_number"`
+	SlotRoot   []byte `db:"slot_root"`
+	TxIndex    uint64 `db:"tx_index"`
+	TxHash     []byte `db:"tx_hash"`
+	TxFrom     []byte `db:"tx_from"`
+	BlobCount  uint64 `db:"blob_count"`
+	Orphaned   bool   `db:"orphaned"`
+}
+
+type MevValidatorRegistration struct {
+	ValidatorIndex uint64 `db:"validator_index"`
+	RelayId        uint8  `db:"relay_id"`
+	Timestamp      uint64 `db:"timestamp"`
+	FeeRecipient   []byte `db:"fee_recipient"`
+	GasLimit       uint64 `db:"gas_limit"`
+	FirstSeen      uint64 `db:"first_seen"`
+}
+
 type DepositTx struct {
 	Index                 uint64 `db:"deposit_index"`
 	BlockNumber           uint64 `db:"block_number"`
@@ -368,3 +426,60 @@ type Validator struct {
 	ExitEpoch                  int64  `db:"exit_epoch"`
 	WithdrawableEpoch          int64  `db:"withdrawable_epoch"`
 }
+
+type ValidatorHistory struct {
+	ValidatorIndex             uint64 `db:"validator_index"`
+	StartEpoch                 uint64 `db:"start_epoch"`
+	EndEpoch                   uint64 `db:"end_epoch"`
+	ChangedFields              uint32 `db:"changed_fields"`
+	WithdrawalCredentials      []byte `db:"withdrawal_credentials"`
+	EffectiveBalance           uint64 `db:"effective_balance"`
+	Slashed                    bool   `db:"slashed"`
+	ActivationEligibilityEpoch int64  `db:"activation_eligibility_epoch"`
+	ActivationEpoch            int64  `db:"activation_epoch"`
+	ExitEpoch                  int64  `db:"exit_epoch"`
+	WithdrawableEpoch          int64  `db:"withdrawable_epoch"`
+}
+
+const (
+	ValidatorHistoryWithdrawalCredentials uint32 = 1 << iota
+	ValidatorHistoryEffectiveBalance
+	ValidatorHistorySlashed
+	ValidatorHistoryActivationEligibilityEpoch
+	ValidatorHistoryActivationEpoch
+	ValidatorHistoryExitEpoch
+	ValidatorHistoryWithdrawableEpoch
+
+	ValidatorHistoryAllFields uint32 = 1<<iota - 1
+)
+
+// ValidatorHistoryOpenEpoch is the end epoch of the currently valid history entry
+const ValidatorHistoryOpenEpoch uint64 = math.MaxInt64
+
+type ValidatorDashboard struct {
+	DashboardKey string `db:"dashboard_key"`
+	Name         string `db:"name"`
+	FilterArgs   string `db:"filter_args"`
+	CreatedAt    int64  `db:"created_at"`
+	LastAccess   int64  `db:"last_access"`
+}
+
+type AlertDeliveryStatus uint8
+
+const (
+	AlertDeliveryStatusDelivered AlertDeliveryStatus = iota + 1
+	AlertDeliveryStatusFailed
+)
+
+type AlertDelivery struct {
+	Id          uint64              `db:"id"`
+	RuleName    string              `db:"rule_name"`
+	WebhookName string              `db:"webhook_name"`
+	AlertType   string              `db:"alert_type"`
+	Title       string              `db:"title"`
+	Status      AlertDeliveryStatus `db:"status"`
+	Attempts    uint32              `db:"attempts"`
+	Error       *string             `db:"error"`
+	CreatedAt   int64               `db:"created_at"`
+	DeliveredAt int64               `db:"delivered_at"`
+}
@@ -20,6 +20,61 @@ type BlockHead struct {
 	ForkId     uint64 `db:"fork_id"`
 }
 
+type ProposerSlotCount struct {
+	Proposer uint64     `db:"proposer"`
+	Status   SlotStatus `db:"status"`
+	Count    uint64     `db:"count"`
+}
+
+type SlotClientInfo struct {
+	Slot              uint64 `db:"slot"`
+	GraffitiText      string `db:"graffiti_text"`
+	EthBlockExtraText string `db:"eth_block_extra_text"`
+}
+
+type SlotGasInfo struct {
+	Slot        uint64  `db:"slot"`
+	Proposer    uint64  `db:"proposer"`
+	EthGasUsed  *uint64 `db:"eth_gas_used"`
+	EthGasLimit *uint64 `db:"eth_gas_limit"`
+	EthBaseFee  *uint64 `db:"eth_base_fee"`
+}
+
+type SlotBlobInfo struct {
+	Slot             uint64  `db:"slot"`
+	EthBlobCount     uint64  `db:"eth_blob_count"`
+	EthBlobGasUsed   *uint64 `db:"eth_blob_gas_used"`
+	EthExcessBlobGas *uint64 `db:"eth_excess_blob_gas"`
+	EthBlobBaseFee   *uint64 `db:"eth_blob_base_fee"`
+}
+
+type BlobSubmissionInfo struct {
+	SlotNumber     uint64  `db:"slot_number"`
+	TxFrom         []byte  `db:"tx_from"`
+	BlobCount      uint64  `db:"blob_count"`
+	EthBlobBaseFee *uint64 `db:"eth_blob_base_fee"`
+}
+
+type PayloadMevInfo struct {
+	Slot           uint64 `db:"slot"`
+	BuilderPubkey  []byte `db:"builder_pubkey"`
+	SeenbyRelays   uint64 `db:"seenby_relays"`
+	BlockValueGwei uint64 `db:"block_value_gwei"`
+}
+
+type MevBlockPayloadInfo struct {
+	SlotNumber          uint64     `db:"slot_number"`
+	BlockHash           []byte     `db:"block_hash"`
+	ProposerIndex       uint64     `db:"proposer_index"`
+	SeenbyRelays        uint64     `db:"seenby_relays"`
+	FeeRecipient        []byte     `db:"fee_recipient"`
+	BlockValueGwei      uint64     `db:"block_value_gwei"`
+	PayloadStatus       SlotStatus `db:"payload_status"`
+	EthFeeRecipient     []byte     `db:"eth_fee_recipient"`
+	EthPaymentRecipient []byte     `db:"eth_payment_recipient"`
+	CanonicalCount      uint64     `db:"canonical_count"`
+}
+
 type AssignedBlob struct {
 	Root       []byte `db:"root"`
 	Commitment []byte `db:"commitment"`
@@ -34,12 +89,14 @@ type UnfinalizedBlockFilter struct {
 }
 
 type BlockFilter struct {
-	Graffiti      string
-	ExtraData     string
-	ProposerIndex *uint64
-	ProposerName  string
-	WithOrphaned  uint8
-	WithMissing   uint8
+	Graffiti        string
+	ExtraData       string
+	ProposerIndex   *uint64
+	ProposerIndexes []uint64
+	ProposerName    string
+	WithOrphaned    uint8
+	WithMissing     uint8
+	MaxSlot         uint64
 }
 
 type MevBlockFilter struct {
@@ -175,8 +232,29 @@ type ValidatorFilter struct {
 	WithdrawalAddress []byte
 	ValidatorName     string
 	Status            []v1.ValidatorState
+	AsOfEpoch         *uint64
 
 	OrderBy ValidatorOrder
 	Limit   uint64
 	Offset  uint64
 }
+
+type SlotLinkInfo struct {
+	Slot       uint64 `db:"slot"`
+	Root       []byte `db:"root"`
+	ParentRoot []byte `db:"parent_root"`
+}
+
+type EpochBlockAggregate struct {
+	Epoch                 uint64 `db:"epoch"`
+	BlockCount            uint64 `db:"block_count"`
+	AttestationCount      uint64 `db:"attestation_count"`
+	DepositCount          uint64 `db:"deposit_count"`
+	ExitCount             uint64 `db:"exit_count"`
+	WithdrawCount         uint64 `db:"withdraw_count"`
+	WithdrawAmount        uint64 `db:"withdraw_amount"`
+	AttesterSlashingCount uint64 `db:"attester_slashing_count"`
+	ProposerSlashingCount uint64 `db:"proposer_slashing_count"`
+	BLSChangeCount        uint64 `db:"bls_change_count"`
+	EthTransactionCount   uint64 `db:"eth_transaction_count"`
+}
@@ -4,6 +4,19 @@ type IndexerSyncState struct {
 	Epoch uint64 `json:"epoch"`
 }
 
+type IndexerReindexState struct {
+	FromEpoch uint64 `json:"from"`
+	ToEpoch   uint64 `json:"to"`
+}
+
+type IndexerResyncState struct {
+	Epochs []uint64 `json:"epochs"`
+}
+
+type ValidatorHistoryState struct {
+	StartEpoch uint64 `json:"start_epoch"`
+}
+
 type IndexerPruneState struct {
 	Epoch uint64 `json:"epoch"`
 }
@@ -18,3 +31,33 @@ type DepositIndexerState struct {
 	HeadBlock    uint64 `json:"head_block"`
 	DepositIndex uint64 `json:"deposit_index"`
 }
+
+type MevRelayBackfillState struct {
+	StartSlot uint64 `json:"start_slot"` // lowest slot of the covered range
+	EndSlot   uint64 `json:"end_slot"`   // highest slot of the covered range (0 = nothing covered yet)
+	WalkStart uint64 `json:"walk_start"` // lowest slot of the running backfill walk
+	WalkEnd   uint64 `json:"walk_end"`   // highest slot of the running backfill walk (0 = no walk running)
+	Cursor    uint64 `json:"cursor"`     // position of the running backfill walk (walks downwards)
+}
+
+type RevenueIndexerState struct {
+	HeadSlot     uint64 `json:"head_slot"`
+	BackfillSlot uint64 `json:"backfill_slot"`
+}
+
+type MevRegistrationSamplerState struct {
+	Cursor       uint64           `json:"cursor"`        // initial cursor for relays without own cursor
+	RelayCursors map[uint8]uint64 `json:"relay_cursors"` // validator index cursor per relay index
+}
+
+type IndexerRecentSyncState struct {
+	Ranges []*IndexerRecentSyncRange `json:"ranges"`
+}
+
+// IndexerRecentSyncRange is a range of epochs synchronized backwards from HeadEpoch towards TargetEpoch.
+// Epochs from TailEpoch up to HeadEpoch are synchronized.
+type IndexerRecentSyncRange struct {
+	HeadEpoch   uint64 `json:"head"`
+	TailEpoch   uint64 `json:"tail"`
+	TargetEpoch uint64 `json:"target"`
+}
@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Alerts will return the "alerts" page using a go template
+func Alerts(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"alerts/alerts.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/alerts", "Alerts", templateFiles)
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
+	if pageError == nil {
+		data.Data, pageError = getAlertsPageData(pageIdx, pageSize)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "alerts.go", "Alerts", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getAlertsPageData(pageIdx uint64, pageSize uint64) (*models.AlertsPageData, error) {
+	pageData := &models.AlertsPageData{}
+	pageCacheKey := fmt.Sprintf("alerts:%v:%v", pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildAlertsPageData(pageIdx, pageSize)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.AlertsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildAlertsPageData(pageIdx uint64, pageSize uint64) (*models.AlertsPageData, time.Duration) {
+	logrus.Debugf("alerts page called: %v:%v", pageIdx, pageSize)
+	pageData := &models.AlertsPageData{
+		Rules:      []*models.AlertsPageDataRule{},
+		Deliveries: []*models.AlertsPageDataDelivery{},
+	}
+
+	if alertManager := services.GlobalBeaconService.GetAlertManager(); alertManager != nil {
+		for _, rule := range alertManager.GetRules() {
+			pageData.Rules = append(pageData.Rules, &models.AlertsPageDataRule{
+				Name:           rule.Name,
+				Type:           string(rule.Type),
+				Webhooks:       rule.Webhooks,
+				Target:         rule.Target,
+				ValidatorCount: rule.ValidatorCount,
+				Threshold:      rule.Threshold,
+			})
+		}
+	}
+	pageData.RuleCount = uint64(len(pageData.Rules))
+
+	if pageIdx == 1 {
+		pageData.IsDefaultPage = true
+	}
+
+	if pageSize > 100 {
+		pageSize = 100
+	} else if pageSize == 0 {
+		pageSize = 50
+	}
+	pageData.PageSize = pageSize
+	pageData.TotalPages = pageIdx
+	pageData.CurrentPageIndex = pageIdx
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+
+	offset := (pageIdx - 1) * pageSize
+	dbDeliveries, totalRows, err := db.GetAlertDeliveries(offset, uint32(pageSize))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, delivery := range dbDeliveries {
+		deliveryData := &models.AlertsPageDataDelivery{
+			RuleName:    delivery.RuleName,
+			WebhookName: delivery.WebhookName,
+			AlertType:   delivery.AlertType,
+			Title:       delivery.Title,
+			Delivered:   delivery.Status == dbtypes.AlertDeliveryStatusDelivered,
+			Attempts:    delivery.Attempts,
+			CreatedAt:   time.Unix(delivery.CreatedAt, 0),
+			DeliveredAt: time.Unix(delivery.DeliveredAt, 0),
+		}
+		if delivery.Error != nil {
+			deliveryData.Error = *delivery.Error
+		}
+
+		pageData.Deliveries = append(pageData.Deliveries, deliveryData)
+	}
+	pageData.DeliveryCount = uint64(len(pageData.Deliveries))
+
+	pageData.TotalPages = totalRows / pageSize
+	if totalRows%pageSize > 0 {
+		pageData.TotalPages++
+	}
+	pageData.LastPageIndex = pageData.TotalPages
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	pageData.FirstPageLink = fmt.Sprintf("/alerts?c=%v", pageData.PageSize)
+	pageData.PrevPageLink = fmt.Sprintf("/alerts?c=%v&p=%v", pageData.PageSize, pageData.PrevPageIndex)
+	pageData.NextPageLink = fmt.Sprintf("/alerts?c=%v&p=%v", pageData.PageSize, pageData.NextPageIndex)
+	pageData.LastPageLink = fmt.Sprintf("/alerts?c=%v&p=%v", pageData.PageSize, pageData.LastPageIndex)
+
+	return pageData, 30 * time.Second
+}
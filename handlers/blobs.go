@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// number of time buckets shown in the blob charts
+const blobsBucketCount = 50
+
+// max number of blob submitters shown in the leaderboard
+const blobsSubmitterLimit = 100
+
+// Blobs will return the "blobs" page using a go template
+func Blobs(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"blobs/blobs.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/blobs", "Blobs", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseBlobsArgs(r.URL.Query())
+		data.Data, pageError = getBlobsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "blobs.go", "Blobs", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// BlobsData will return the blob analytics as json
+func BlobsData(w http.ResponseWriter, r *http.Request) {
+	var pageData *models.BlobsPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseBlobsArgs(r.URL.Query())
+		pageData, pageError = getBlobsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding blob analytics data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseBlobsArgs(urlArgs url.Values) (startEpoch uint64, endEpoch uint64) {
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	if finalizedEpoch > 0 {
+		endEpoch = uint64(finalizedEpoch) - 1
+	}
+	if endEpoch > 224 {
+		startEpoch = endEpoch - 224
+	}
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.start") {
+			startEpoch, _ = strconv.ParseUint(urlArgs.Get("f.start"), 10, 64)
+		}
+		if urlArgs.Has("f.end") {
+			endEpoch, _ = strconv.ParseUint(urlArgs.Get("f.end"), 10, 64)
+		}
+	}
+
+	if endEpoch < startEpoch {
+		startEpoch, endEpoch = endEpoch, startEpoch
+	}
+
+	maxEpochRange := utils.Config.BlobAnalytics.MaxEpochRange
+	if maxEpochRange > 0 && endEpoch-startEpoch >= maxEpochRange {
+		startEpoch = endEpoch - maxEpochRange + 1
+	}
+
+	return
+}
+
+func getBlobsPageData(startEpoch uint64, endEpoch uint64) (*models.BlobsPageData, error) {
+	pageData := &models.BlobsPageData{}
+	pageCacheKey := fmt.Sprintf("blobs:%v:%v", startEpoch, endEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildBlobsPageData(startEpoch, endEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.BlobsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildBlobsPageData(startEpoch uint64, endEpoch uint64) (*models.BlobsPageData, time.Duration) {
+	logrus.Debugf("blobs page called: %v-%v", startEpoch, endEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+
+	pageData := &models.BlobsPageData{
+		FilterStartEpoch:    startEpoch,
+		FilterEndEpoch:      endEpoch,
+		MaxEpochRange:       utils.Config.BlobAnalytics.MaxEpochRange,
+		FinalizedEpoch:      uint64(finalizedEpoch),
+		TargetBlobsPerBlock: params.BlobTxTargetBlobGasPerBlock / params.BlobTxBlobGasPerBlob,
+		MaxBlobsPerBlock:    params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob,
+		Buckets:             []*models.BlobsPageDataBucket{},
+		Submitters:          []*models.BlobsPageDataSubmitter{},
+	}
+
+	cacheTime := 5 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+
+		// blob target & limit got raised with EIP-7691 (electra)
+		if specs.ElectraForkEpoch != nil && endEpoch >= *specs.ElectraForkEpoch {
+			pageData.TargetBlobsPerBlock = params.TargetBlobGasPerBlockEIP7691 / params.BlobTxBlobGasPerBlob
+			pageData.MaxBlobsPerBlock = params.MaxBlobGasPerBlockEIP7691 / params.BlobTxBlobGasPerBlob
+		}
+	}
+
+	analytics, err := services.GlobalBeaconService.GetBlobAnalytics(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch), blobsBucketCount)
+	if err != nil {
+		panic(err)
+	}
+
+	pageData.BlockCount = analytics.BlockCount
+	pageData.BlobBlockCount = analytics.BlobBlockCount
+	pageData.BlobCount = analytics.BlobCount
+	pageData.BlobGasUsed = analytics.BlobGasUsed
+	pageData.BlobFees = analytics.BlobFees / 1e18
+	pageData.BucketSize = analytics.BucketSize
+	if analytics.BlockCount > 0 {
+		pageData.BlobsPerBlock = float64(analytics.BlobCount) / float64(analytics.BlockCount)
+	}
+
+	for _, bucket := range analytics.Buckets {
+		bucketData := &models.BlobsPageDataBucket{
+			StartEpoch:     uint64(bucket.StartEpoch),
+			EndEpoch:       uint64(bucket.EndEpoch),
+			BlockCount:     bucket.BlockCount,
+			BlobCount:      bucket.BlobCount,
+			MaxBlobBaseFee: float64(bucket.BlobBaseFeeMax) / 1e9,
+		}
+		if bucket.BlockCount > 0 {
+			bucketData.BlobsPerBlock = float64(bucket.BlobCount) / float64(bucket.BlockCount)
+		}
+		if bucket.BaseFeeCount > 0 {
+			bucketData.AvgBlobBaseFee = bucket.BlobBaseFeeSum / float64(bucket.BaseFeeCount) / 1e9
+		}
+
+		pageData.Buckets = append(pageData.Buckets, bucketData)
+	}
+
+	pageData.SubmitterCount = uint64(len(analytics.Submitters))
+	for idx, submitter := range analytics.Submitters {
+		if idx >= blobsSubmitterLimit {
+			break
+		}
+
+		submitterData := &models.BlobsPageDataSubmitter{
+			Address:   submitter.Address[:],
+			Name:      submitter.Name,
+			TxCount:   submitter.TxCount,
+			BlobCount: submitter.BlobCount,
+			BlobFees:  submitter.BlobFees / 1e18,
+		}
+		if analytics.BlobCount > 0 {
+			submitterData.Share = float64(submitter.BlobCount) * 100 / float64(analytics.BlobCount)
+		}
+
+		pageData.Submitters = append(pageData.Submitters, submitterData)
+	}
+
+	return pageData, cacheTime
+}
@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// number of time buckets shown in the client diversity charts
+const clientsDiversityBucketCount = 50
+
+var clientsDiversityColors = map[string]string{
+	"Lighthouse":                    "#9933cc",
+	"Prysm":                         "#22a7f0",
+	"Teku":                          "#ff7b00",
+	"Nimbus":                        "#d4a017",
+	"Lodestar":                      "#4e68c8",
+	"Grandine":                      "#2e8b57",
+	"Geth":                          "#6d7fcc",
+	"Nethermind":                    "#1bc0d3",
+	"Besu":                          "#ea4c89",
+	"Erigon":                        "#20c997",
+	"Reth":                          "#e64a19",
+	"EthereumJS":                    "#8d6e63",
+	services.ClientDiversityUnknown: "#9e9e9e",
+}
+
+var clientsDiversityFallbackColors = []string{"#3366cc", "#dc3912", "#ff9900", "#109618", "#990099", "#0099c6", "#dd4477", "#66aa00"}
+
+// ClientsDiversity will return the "clients_diversity" page using a go template
+func ClientsDiversity(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"clients/clients_diversity.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "clients", "/clients/diversity", "Client Diversity", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseClientsDiversityArgs(r.URL.Query())
+		data.Data, pageError = getClientsDiversityPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "clients_diversity.go", "ClientsDiversity", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ClientsDiversityData will return the client diversity estimation as json
+func ClientsDiversityData(w http.ResponseWriter, r *http.Request) {
+	var pageData *models.ClientsDiversityPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseClientsDiversityArgs(r.URL.Query())
+		pageData, pageError = getClientsDiversityPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding client diversity data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseClientsDiversityArgs(urlArgs url.Values) (startEpoch uint64, endEpoch uint64) {
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	if finalizedEpoch > 0 {
+		endEpoch = uint64(finalizedEpoch) - 1
+	}
+	if endEpoch > 224 {
+		startEpoch = endEpoch - 224
+	}
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.start") {
+			startEpoch, _ = strconv.ParseUint(urlArgs.Get("f.start"), 10, 64)
+		}
+		if urlArgs.Has("f.end") {
+			endEpoch, _ = strconv.ParseUint(urlArgs.Get("f.end"), 10, 64)
+		}
+	}
+
+	if endEpoch < startEpoch {
+		startEpoch, endEpoch = endEpoch, startEpoch
+	}
+
+	maxEpochRange := utils.Config.ClientDiversity.MaxEpochRange
+	if maxEpochRange > 0 && endEpoch-startEpoch >= maxEpochRange {
+		startEpoch = endEpoch - maxEpochRange + 1
+	}
+
+	return
+}
+
+func getClientsDiversityPageData(startEpoch uint64, endEpoch uint64) (*models.ClientsDiversityPageData, error) {
+	pageData := &models.ClientsDiversityPageData{}
+	pageCacheKey := fmt.Sprintf("clients_diversity:%v:%v", startEpoch, endEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildClientsDiversityPageData(startEpoch, endEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ClientsDiversityPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildClientsDiversityPageData(startEpoch uint64, endEpoch uint64) (*models.ClientsDiversityPageData, time.Duration) {
+	logrus.Debugf("clients diversity page called: %v-%v", startEpoch, endEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+
+	pageData := &models.ClientsDiversityPageData{
+		FilterStartEpoch: startEpoch,
+		FilterEndEpoch:   endEpoch,
+		MaxEpochRange:    utils.Config.ClientDiversity.MaxEpochRange,
+		FinalizedEpoch:   uint64(finalizedEpoch),
+		ClClients:        []*models.ClientsDiversityPageDataClient{},
+		ElClients:        []*models.ClientsDiversityPageDataClient{},
+		Pairs:            []*models.ClientsDiversityPageDataClient{},
+		Buckets:          []*models.ClientsDiversityPageDataBucket{},
+	}
+
+	cacheTime := 5 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	}
+
+	diversity, err := services.GlobalBeaconService.GetClientDiversity(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch), clientsDiversityBucketCount)
+	if err != nil {
+		panic(err)
+	}
+
+	pageData.BlockCount = diversity.BlockCount
+	pageData.BucketSize = diversity.BucketSize
+
+	buildClients := func(clients []string, counts map[string]uint64) []*models.ClientsDiversityPageDataClient {
+		clientsData := make([]*models.ClientsDiversityPageDataClient, 0, len(clients))
+		for idx, client := range clients {
+			clientData := &models.ClientsDiversityPageDataClient{
+				Name:       client,
+				BlockCount: counts[client],
+				Color:      clientsDiversityColors[client],
+			}
+			if clientData.Color == "" {
+				clientData.Color = clientsDiversityFallbackColors[idx%len(clientsDiversityFallbackColors)]
+			}
+			if diversity.BlockCount > 0 {
+				clientData.Share = float64(clientData.BlockCount) * 100 / float64(diversity.BlockCount)
+			}
+			clientsData = append(clientsData, clientData)
+		}
+		return clientsData
+	}
+
+	pageData.ClClients = buildClients(diversity.ClClients, diversity.ClCounts)
+	pageData.ElClients = buildClients(diversity.ElClients, diversity.ElCounts)
+
+	for pair, count := range diversity.PairCounts {
+		pairData := &models.ClientsDiversityPageDataClient{
+			Name:       pair,
+			BlockCount: count,
+		}
+		if diversity.BlockCount > 0 {
+			pairData.Share = float64(count) * 100 / float64(diversity.BlockCount)
+		}
+		pageData.Pairs = append(pageData.Pairs, pairData)
+	}
+	sort.Slice(pageData.Pairs, func(a, b int) bool {
+		if pageData.Pairs[a].BlockCount != pageData.Pairs[b].BlockCount {
+			return pageData.Pairs[a].BlockCount > pageData.Pairs[b].BlockCount
+		}
+		return pageData.Pairs[a].Name < pageData.Pairs[b].Name
+	})
+
+	for _, bucket := range diversity.Buckets {
+		bucketData := &models.ClientsDiversityPageDataBucket{
+			StartEpoch: uint64(bucket.StartEpoch),
+			EndEpoch:   uint64(bucket.EndEpoch),
+			BlockCount: bucket.BlockCount,
+			ClShares:   make([]float64, len(diversity.ClClients)),
+			ElShares:   make([]float64, len(diversity.ElClients)),
+		}
+
+		if bucket.BlockCount > 0 {
+			for idx, client := range diversity.ClClients {
+				bucketData.ClShares[idx] = float64(bucket.ClCounts[client]) * 100 / float64(bucket.BlockCount)
+			}
+			for idx, client := range diversity.ElClients {
+				bucketData.ElShares[idx] = float64(bucket.ElCounts[client]) * 100 / float64(bucket.BlockCount)
+			}
+		}
+
+		pageData.Buckets = append(pageData.Buckets, bucketData)
+	}
+
+	return pageData, cacheTime
+}
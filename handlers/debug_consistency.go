@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// DebugConsistency will return the "debug consistency" page with the last db consistency check report
+func DebugConsistency(w http.ResponseWriter, r *http.Request) {
+	var debugConsistencyTemplateFiles = append(layoutTemplateFiles,
+		"debug_consistency/debug_consistency.html",
+	)
+	var pageTemplate = templates.GetTemplate(debugConsistencyTemplateFiles...)
+
+	if !utils.Config.Frontend.Pprof {
+		handlePageError(w, r, errors.New("debug pages are not enabled"))
+		return
+	}
+
+	pageData := buildDebugConsistencyPageData()
+	data := InitPageData(w, r, "blockchain", "/debug/consistency", "DB Consistency", debugConsistencyTemplateFiles)
+	data.Data = pageData
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "debug_consistency.go", "DB Consistency", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// DebugConsistencyAction triggers a db consistency check or the repair of the affected epochs
+func DebugConsistencyAction(w http.ResponseWriter, r *http.Request) {
+	if !utils.Config.Frontend.Pprof {
+		handlePageError(w, r, errors.New("debug pages are not enabled"))
+		return
+	}
+
+	consistencyChecker := services.GlobalBeaconService.GetConsistencyChecker()
+	if consistencyChecker == nil {
+		handlePageError(w, r, errors.New("consistency checker is not enabled"))
+		return
+	}
+
+	err := r.ParseForm()
+	if err != nil {
+		handlePageError(w, r, fmt.Errorf("invalid form data: %v", err))
+		return
+	}
+
+	switch r.PostForm.Get("action") {
+	case "check":
+		consistencyChecker.TriggerCheck()
+	case "repair":
+		_, err := consistencyChecker.RepairAffectedEpochs()
+		if err != nil {
+			logrus.Warnf("error scheduling consistency repair: %v", err)
+		}
+	default:
+		handlePageError(w, r, errors.New("unknown action"))
+		return
+	}
+
+	http.Redirect(w, r, "/debug/consistency", http.StatusSeeOther)
+}
+
+func buildDebugConsistencyPageData() *models.DebugConsistencyPageData {
+	logrus.Debugf("debug consistency page called")
+
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+	consistencyChecker := services.GlobalBeaconService.GetConsistencyChecker()
+
+	pageData := &models.DebugConsistencyPageData{
+		Enabled:        consistencyChecker != nil,
+		AutoRepair:     utils.Config.ConsistencyChecker.AutoRepair,
+		IssueTypes:     []*models.DebugConsistencyPageDataType{},
+		Issues:         []*models.DebugConsistencyPageDataIssue{},
+		AffectedEpochs: []uint64{},
+		PendingEpochs:  []uint64{},
+	}
+
+	syncRunning, syncHead := beaconIndexer.GetSynchronizerState()
+	pageData.SyncRunning = syncRunning
+	pageData.SyncHeadEpoch = uint64(syncHead)
+
+	for _, epoch := range beaconIndexer.GetPendingResyncEpochs() {
+		pageData.PendingEpochs = append(pageData.PendingEpochs, uint64(epoch))
+	}
+
+	if consistencyChecker == nil {
+		return pageData
+	}
+
+	pageData.Running = consistencyChecker.IsRunning()
+
+	report := consistencyChecker.GetReport()
+	if report == nil {
+		return pageData
+	}
+
+	pageData.HasReport = true
+	pageData.StartTime = report.StartTime
+	pageData.Duration = report.EndTime.Sub(report.StartTime)
+	pageData.FirstEpoch = uint64(report.FirstEpoch)
+	pageData.LastEpoch = uint64(report.LastEpoch)
+	pageData.Error = report.Error
+	pageData.RepairTime = report.RepairTime
+	pageData.RepairError = report.RepairError
+
+	for issueType, count := range report.IssueCounts {
+		pageData.IssueCount += count
+		pageData.IssueTypes = append(pageData.IssueTypes, &models.DebugConsistencyPageDataType{
+			Type:  string(issueType),
+			Count: count,
+		})
+	}
+	sort.Slice(pageData.IssueTypes, func(a, b int) bool {
+		return pageData.IssueTypes[a].Type < pageData.IssueTypes[b].Type
+	})
+
+	for _, issue := range report.Issues {
+		issueData := &models.DebugConsistencyPageDataIssue{
+			Type:    string(issue.Type),
+			Epoch:   uint64(issue.Epoch),
+			Details: issue.Details,
+		}
+		if issue.Slot != nil {
+			issueData.HasSlot = true
+			issueData.Slot = uint64(*issue.Slot)
+		}
+		pageData.Issues = append(pageData.Issues, issueData)
+	}
+
+	for _, epoch := range report.AffectedEpochs {
+		pageData.AffectedEpochs = append(pageData.AffectedEpochs, uint64(epoch))
+	}
+
+	return pageData
+}
@@ -21,6 +21,7 @@ import (
 func ElConsolidations(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"el_consolidations/el_consolidations.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -20,6 +20,7 @@ import (
 func ElWithdrawals(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"el_withdrawals/el_withdrawals.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -0,0 +1,1234 @@
+package handlers
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// number of rows loaded per batch while streaming an export
+const exportBatchSize = 1000
+
+// the validator set is filtered in memory on every call, so bigger batches are cheaper there
+const exportValidatorBatchSize = 10000
+
+// max size of a buffered parquet row group
+const exportParquetRowGroupSize = 16 * 1024 * 1024
+
+type exportColumnType uint8
+
+const (
+	exportColumnInt exportColumnType = iota
+	exportColumnFloat
+	exportColumnString
+	exportColumnBool
+)
+
+type exportColumn struct {
+	Name string
+	Type exportColumnType
+}
+
+// exportList describes an exportable list. The export function streams all rows matching the filter in the url args.
+type exportList struct {
+	columns  []exportColumn
+	validate func(urlArgs url.Values) error // optional check of the filter arguments before the export starts
+	export   func(urlArgs url.Values, writeRow func(row []interface{}) error) error
+}
+
+var exportLists = map[string]*exportList{
+	"slots": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"epoch", exportColumnInt},
+			{"time", exportColumnInt},
+			{"status", exportColumnString},
+			{"proposer", exportColumnInt},
+			{"proposer_name", exportColumnString},
+			{"block_root", exportColumnString},
+			{"graffiti", exportColumnString},
+			{"attestation_count", exportColumnInt},
+			{"deposit_count", exportColumnInt},
+			{"exit_count", exportColumnInt},
+			{"proposer_slashing_count", exportColumnInt},
+			{"attester_slashing_count", exportColumnInt},
+			{"sync_participation", exportColumnFloat},
+			{"eth_block_number", exportColumnInt},
+			{"eth_block_hash", exportColumnString},
+			{"eth_extra_data", exportColumnString},
+			{"eth_transaction_count", exportColumnInt},
+			{"eth_gas_used", exportColumnInt},
+			{"eth_gas_limit", exportColumnInt},
+			{"eth_base_fee", exportColumnInt},
+			{"eth_blob_count", exportColumnInt},
+		},
+		export: exportSlots,
+	},
+	"initiated_deposits": {
+		columns: []exportColumn{
+			{"deposit_index", exportColumnInt},
+			{"block_number", exportColumnInt},
+			{"block_time", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"tx_hash", exportColumnString},
+			{"tx_sender", exportColumnString},
+			{"tx_target", exportColumnString},
+			{"pubkey", exportColumnString},
+			{"withdrawal_credentials", exportColumnString},
+			{"amount", exportColumnInt},
+			{"valid_signature", exportColumnBool},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportInitiatedDeposits,
+	},
+	"included_deposits": {
+		columns: []exportColumn{
+			{"deposit_index", exportColumnInt},
+			{"slot", exportColumnInt},
+			{"time", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"pubkey", exportColumnString},
+			{"withdrawal_credentials", exportColumnString},
+			{"amount", exportColumnInt},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportIncludedDeposits,
+	},
+	"voluntary_exits": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"time", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"validator_index", exportColumnInt},
+			{"validator_name", exportColumnString},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportVoluntaryExits,
+	},
+	"slashings": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"time", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"validator_index", exportColumnInt},
+			{"validator_name", exportColumnString},
+			{"slasher_index", exportColumnInt},
+			{"slasher_name", exportColumnString},
+			{"reason", exportColumnString},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportSlashings,
+	},
+	"el_withdrawals": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"block_number", exportColumnInt},
+			{"tx_hash", exportColumnString},
+			{"source_address", exportColumnString},
+			{"validator_index", exportColumnInt},
+			{"validator_name", exportColumnString},
+			{"validator_pubkey", exportColumnString},
+			{"amount", exportColumnInt},
+			{"result", exportColumnInt},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportElWithdrawals,
+	},
+	"el_consolidations": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"block_root", exportColumnString},
+			{"block_number", exportColumnInt},
+			{"tx_hash", exportColumnString},
+			{"source_address", exportColumnString},
+			{"source_index", exportColumnInt},
+			{"source_name", exportColumnString},
+			{"source_pubkey", exportColumnString},
+			{"target_index", exportColumnInt},
+			{"target_name", exportColumnString},
+			{"target_pubkey", exportColumnString},
+			{"result", exportColumnInt},
+			{"orphaned", exportColumnBool},
+		},
+		export: exportElConsolidations,
+	},
+	"mev_blocks": {
+		columns: []exportColumn{
+			{"slot", exportColumnInt},
+			{"time", exportColumnInt},
+			{"block_number", exportColumnInt},
+			{"block_hash", exportColumnString},
+			{"proposer_index", exportColumnInt},
+			{"proposer_name", exportColumnString},
+			{"builder_pubkey", exportColumnString},
+			{"proposed", exportColumnString},
+			{"relays", exportColumnString},
+			{"fee_recipient", exportColumnString},
+			{"tx_count", exportColumnInt},
+			{"gas_used", exportColumnInt},
+			{"block_value", exportColumnInt},
+		},
+		export: exportMevBlocks,
+	},
+	"validators": {
+		columns: []exportColumn{
+			{"index", exportColumnInt},
+			{"name", exportColumnString},
+			{"pubkey", exportColumnString},
+			{"status", exportColumnString},
+			{"balance", exportColumnInt},
+			{"effective_balance", exportColumnInt},
+			{"slashed", exportColumnBool},
+			{"withdrawal_credentials", exportColumnString},
+			{"activation_eligibility_epoch", exportColumnInt},
+			{"activation_epoch", exportColumnInt},
+			{"exit_epoch", exportColumnInt},
+			{"withdrawable_epoch", exportColumnInt},
+		},
+		validate: validateValidatorsExport,
+		export:   exportValidators,
+	},
+}
+
+// Export streams the full filtered result set of a list page as csv, jsonl or parquet file.
+// The list is selected by the route, the filter uses the same url arguments as the corresponding list page.
+func Export(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	listName := vars["list"]
+	list := exportLists[listName]
+	if list == nil {
+		NotFound(w, r)
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	format := urlArgs.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 10)
+	if err != nil {
+		handlePageError(w, r, err)
+		return
+	}
+
+	var contentType string
+	switch format {
+	case "csv":
+		contentType = "text/csv"
+	case "jsonl":
+		contentType = "application/x-ndjson"
+	case "parquet":
+		contentType = "application/vnd.apache.parquet"
+	default:
+		http.Error(w, "Unsupported export format", http.StatusBadRequest)
+		return
+	}
+
+	if list.validate != nil {
+		if err := list.validate(urlArgs); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%v-%v.%v\"", strings.ReplaceAll(listName, "_", "-"), time.Now().UTC().Format("20060102-150405"), format))
+
+	exportWriter, err := newExportWriter(format, w, list.columns)
+	if err != nil {
+		logrus.WithError(err).Errorf("error creating %v export writer", format)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	rowCount := 0
+	err = list.export(urlArgs, func(row []interface{}) error {
+		err := exportWriter.WriteRow(row)
+		if err != nil {
+			return err
+		}
+
+		rowCount++
+		if rowCount%exportBatchSize == 0 {
+			return exportWriter.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		err = exportWriter.Close()
+	}
+	if err != nil {
+		logrus.WithError(err).Warnf("error exporting %v (%v rows written)", listName, rowCount)
+	}
+}
+
+// exportPager pages through a list that is sorted descending by a key (slot or deposit index).
+// Each batch is loaded with the lowest key of the previous batch as upper bound instead of an offset, so the query cost
+// doesn't grow with the export size and rows added while exporting don't shift the following batches.
+// The rows sharing the lowest key of a batch might be cut off by the batch limit, so they're not written but loaded again
+// as the first rows of the next batch. If a single key fills a whole batch, the batch is reloaded with a higher limit.
+// Rows sharing a key are written in the order of the tiebreak function, which has to compare a unique column of the rows.
+type exportPager[T any] struct {
+	key      func(row T) uint64
+	tiebreak func(a, b T) int
+	load     func(maxKey uint64, limit uint32) ([]T, error) // maxKey 0 = no upper bound
+	write    func(row T) error
+}
+
+func (pager *exportPager[T]) run() error {
+	maxKey := uint64(0)
+	limit := uint32(exportBatchSize)
+	for {
+		rows, err := pager.load(maxKey, limit)
+		if err != nil {
+			return err
+		}
+
+		complete := len(rows) < int(limit)
+		if maxKey > 0 {
+			// drop rows above the upper bound (rows without key are sorted on top)
+			rows = slices.DeleteFunc(rows, func(row T) bool {
+				return pager.key(row) > maxKey
+			})
+		}
+
+		lowestKey := uint64(0)
+		if !complete {
+			if len(rows) == 0 {
+				// whole batch above the upper bound, load a bigger batch
+				limit *= 2
+				continue
+			}
+
+			lowestKey = pager.key(rows[len(rows)-1])
+			cutIdx := len(rows) - 1
+			for cutIdx > 0 && pager.key(rows[cutIdx-1]) == lowestKey {
+				cutIdx--
+			}
+
+			if cutIdx == 0 || lowestKey == 0 {
+				// a single key fills the whole batch or the lowest key can't be used as upper bound (0 = no upper bound)
+				limit *= 2
+				continue
+			}
+
+			rows = rows[:cutIdx]
+		}
+
+		slices.SortStableFunc(rows, func(a, b T) int {
+			if keyA, keyB := pager.key(a), pager.key(b); keyA != keyB {
+				return cmp.Compare(keyB, keyA)
+			}
+			return pager.tiebreak(a, b)
+		})
+
+		for _, row := range rows {
+			if err := pager.write(row); err != nil {
+				return err
+			}
+		}
+
+		if complete {
+			return nil
+		}
+
+		maxKey = lowestKey
+		limit = exportBatchSize
+	}
+}
+
+// exportLimitMaxKey returns the lower of the pager and filter upper bound (0 = no upper bound)
+func exportLimitMaxKey(filterMaxKey uint64, pagerMaxKey uint64) uint64 {
+	if pagerMaxKey == 0 || (filterMaxKey > 0 && filterMaxKey < pagerMaxKey) {
+		return filterMaxKey
+	}
+	return pagerMaxKey
+}
+
+type exportWriter interface {
+	WriteRow(row []interface{}) error
+	Flush() error
+	Close() error
+}
+
+func newExportWriter(format string, w http.ResponseWriter, columns []exportColumn) (exportWriter, error) {
+	switch format {
+	case "csv":
+		return newCsvExportWriter(w, columns)
+	case "jsonl":
+		return &jsonlExportWriter{w: w, columns: columns}, nil
+	case "parquet":
+		return newParquetExportWriter(w, columns)
+	}
+	return nil, fmt.Errorf("unsupported export format: %v", format)
+}
+
+func flushResponse(w io.Writer) {
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+type csvExportWriter struct {
+	w      http.ResponseWriter
+	writer *csv.Writer
+	record []string
+}
+
+func newCsvExportWriter(w http.ResponseWriter, columns []exportColumn) (*csvExportWriter, error) {
+	csvWriter := &csvExportWriter{
+		w:      w,
+		writer: csv.NewWriter(w),
+		record: make([]string, len(columns)),
+	}
+
+	for idx, column := range columns {
+		csvWriter.record[idx] = column.Name
+	}
+
+	return csvWriter, csvWriter.writer.Write(csvWriter.record)
+}
+
+func (cw *csvExportWriter) WriteRow(row []interface{}) error {
+	for idx, value := range row {
+		switch v := value.(type) {
+		case nil:
+			cw.record[idx] = ""
+		case int64:
+			cw.record[idx] = strconv.FormatInt(v, 10)
+		case float64:
+			cw.record[idx] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			cw.record[idx] = strconv.FormatBool(v)
+		case string:
+			cw.record[idx] = v
+		default:
+			cw.record[idx] = fmt.Sprintf("%v", v)
+		}
+	}
+	return cw.writer.Write(cw.record)
+}
+
+func (cw *csvExportWriter) Flush() error {
+	cw.writer.Flush()
+	flushResponse(cw.w)
+	return cw.writer.Error()
+}
+
+func (cw *csvExportWriter) Close() error {
+	return cw.Flush()
+}
+
+type jsonlExportWriter struct {
+	w       http.ResponseWriter
+	columns []exportColumn
+	buf     bytes.Buffer
+}
+
+func (jw *jsonlExportWriter) WriteRow(row []interface{}) error {
+	// encode fields manually to keep the column order
+	jw.buf.Reset()
+	jw.buf.WriteByte('{')
+	for idx, value := range row {
+		if idx > 0 {
+			jw.buf.WriteByte(',')
+		}
+
+		name, _ := json.Marshal(jw.columns[idx].Name)
+		jw.buf.Write(name)
+		jw.buf.WriteByte(':')
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		jw.buf.Write(data)
+	}
+	jw.buf.WriteString("}\n")
+
+	_, err := jw.w.Write(jw.buf.Bytes())
+	return err
+}
+
+func (jw *jsonlExportWriter) Flush() error {
+	flushResponse(jw.w)
+	return nil
+}
+
+func (jw *jsonlExportWriter) Close() error {
+	return jw.Flush()
+}
+
+type parquetExportWriter struct {
+	w      http.ResponseWriter
+	writer *writer.CSVWriter
+}
+
+func newParquetExportWriter(w http.ResponseWriter, columns []exportColumn) (*parquetExportWriter, error) {
+	schema := make([]string, len(columns))
+	for idx, column := range columns {
+		var columnType string
+		switch column.Type {
+		case exportColumnInt:
+			columnType = "type=INT64"
+		case exportColumnFloat:
+			columnType = "type=DOUBLE"
+		case exportColumnString:
+			columnType = "type=BYTE_ARRAY, convertedtype=UTF8"
+		case exportColumnBool:
+			columnType = "type=BOOLEAN"
+		}
+		schema[idx] = fmt.Sprintf("name=%v, %v, repetitiontype=OPTIONAL", column.Name, columnType)
+	}
+
+	parquetWriter, err := writer.NewCSVWriterFromWriter(schema, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	parquetWriter.RowGroupSize = exportParquetRowGroupSize
+
+	return &parquetExportWriter{
+		w:      w,
+		writer: parquetWriter,
+	}, nil
+}
+
+func (pw *parquetExportWriter) WriteRow(row []interface{}) error {
+	return pw.writer.Write(row)
+}
+
+func (pw *parquetExportWriter) Flush() error {
+	// row groups are written by the parquet writer as soon as they are full
+	flushResponse(pw.w)
+	return nil
+}
+
+func (pw *parquetExportWriter) Close() error {
+	err := pw.writer.WriteStop()
+	if err != nil {
+		return err
+	}
+	return pw.Flush()
+}
+
+func exportHex(data []byte) interface{} {
+	if len(data) == 0 {
+		return nil
+	}
+	return "0x" + hex.EncodeToString(data)
+}
+
+func exportText(text string) interface{} {
+	if text == "" {
+		return nil
+	}
+	return text
+}
+
+func exportUint(value *uint64) interface{} {
+	if value == nil {
+		return nil
+	}
+	return int64(*value)
+}
+
+func exportEpoch(epoch phase0.Epoch) interface{} {
+	if epoch == beacon.FarFutureEpoch {
+		return nil
+	}
+	return int64(epoch)
+}
+
+func exportSlotTime(slot uint64) int64 {
+	return services.GlobalBeaconService.GetChainState().SlotToTime(phase0.Slot(slot)).Unix()
+}
+
+// exportAssignedSlotRoot returns the block root of an assigned slot (nil for missed slots)
+func exportAssignedSlotRoot(assignedSlot *dbtypes.AssignedSlot) []byte {
+	if assignedSlot.Block == nil {
+		return nil
+	}
+	return assignedSlot.Block.Root
+}
+
+func exportValidatorName(index *uint64) interface{} {
+	if index == nil {
+		return nil
+	}
+	return exportText(services.GlobalBeaconService.GetValidatorName(*index))
+}
+
+func exportSlots(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	blockFilter := &dbtypes.BlockFilter{
+		WithOrphaned: 1,
+		WithMissing:  1,
+	}
+	if urlArgs.Has("f") {
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		withMissing, _ := strconv.ParseUint(urlArgs.Get("f.missing"), 10, 64)
+		blockFilter.Graffiti = urlArgs.Get("f.graffiti")
+		blockFilter.ExtraData = urlArgs.Get("f.extra")
+		blockFilter.ProposerName = urlArgs.Get("f.pname")
+		blockFilter.WithOrphaned = uint8(withOrphaned)
+		blockFilter.WithMissing = uint8(withMissing)
+		if proposer := urlArgs.Get("f.proposer"); proposer != "" {
+			proposerIndex, _ := strconv.ParseUint(proposer, 10, 64)
+			blockFilter.ProposerIndex = &proposerIndex
+		}
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	pager := &exportPager[*dbtypes.AssignedSlot]{
+		key: func(dbBlock *dbtypes.AssignedSlot) uint64 {
+			return dbBlock.Slot
+		},
+		tiebreak: func(a, b *dbtypes.AssignedSlot) int {
+			return bytes.Compare(exportAssignedSlotRoot(a), exportAssignedSlotRoot(b))
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.AssignedSlot, error) {
+			blockFilter.MaxSlot = maxKey
+
+			// GetDbBlocksByFilter returns one more block than requested if there are more pages
+			dbBlocks := services.GlobalBeaconService.GetDbBlocksByFilter(blockFilter, 0, limit, 0)
+			if len(dbBlocks) > int(limit) {
+				dbBlocks = dbBlocks[:limit]
+			}
+			return dbBlocks, nil
+		},
+		write: func(dbBlock *dbtypes.AssignedSlot) error {
+			status := "missed"
+			row := make([]interface{}, 22)
+			row[0] = int64(dbBlock.Slot)
+			row[1] = int64(chainState.EpochOfSlot(phase0.Slot(dbBlock.Slot)))
+			row[2] = exportSlotTime(dbBlock.Slot)
+			row[4] = int64(dbBlock.Proposer)
+			row[5] = exportText(services.GlobalBeaconService.GetValidatorName(dbBlock.Proposer))
+			if block := dbBlock.Block; block != nil {
+				switch block.Status {
+				case dbtypes.Canonical:
+					status = "proposed"
+				case dbtypes.Orphaned:
+					status = "orphaned"
+				}
+				row[6] = exportHex(block.Root)
+				row[7] = exportText(utils.GraffitiToString(block.Graffiti))
+				row[8] = int64(block.AttestationCount)
+				row[9] = int64(block.DepositCount)
+				row[10] = int64(block.ExitCount)
+				row[11] = int64(block.ProposerSlashingCount)
+				row[12] = int64(block.AttesterSlashingCount)
+				row[13] = float64(block.SyncParticipation)
+				row[14] = exportUint(block.EthBlockNumber)
+				row[15] = exportHex(block.EthBlockHash)
+				row[16] = exportText(utils.GraffitiToString(block.EthBlockExtra))
+				row[17] = int64(block.EthTransactionCount)
+				row[18] = exportUint(block.EthGasUsed)
+				row[19] = exportUint(block.EthGasLimit)
+				row[20] = exportUint(block.EthBaseFee)
+				row[21] = int64(block.EthBlobCount)
+			}
+			row[3] = status
+
+			return writeRow(row)
+		},
+	}
+	return pager.run()
+}
+
+func exportInitiatedDeposits(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	depositFilter := &dbtypes.DepositTxFilter{
+		WithOrphaned: 1,
+		WithValid:    1,
+	}
+	if urlArgs.Has("f") {
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		withValid, _ := strconv.ParseUint(urlArgs.Get("f.valid"), 10, 64)
+		depositFilter.Address = common.FromHex(urlArgs.Get("f.address"))
+		depositFilter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+		depositFilter.ValidatorName = urlArgs.Get("f.vname")
+		depositFilter.MinAmount, _ = strconv.ParseUint(urlArgs.Get("f.mina"), 10, 64)
+		depositFilter.MaxAmount, _ = strconv.ParseUint(urlArgs.Get("f.maxa"), 10, 64)
+		depositFilter.WithOrphaned = uint8(withOrphaned)
+		depositFilter.WithValid = uint8(withValid)
+	}
+
+	depositSyncState := dbtypes.DepositIndexerState{}
+	db.GetExplorerState("indexer.depositstate", &depositSyncState)
+
+	filterMaxIndex := depositFilter.MaxIndex
+	pager := &exportPager[*dbtypes.DepositTx]{
+		key: func(depositTx *dbtypes.DepositTx) uint64 {
+			return depositTx.Index
+		},
+		tiebreak: func(a, b *dbtypes.DepositTx) int {
+			if c := bytes.Compare(a.BlockRoot, b.BlockRoot); c != 0 {
+				return c
+			}
+			return bytes.Compare(a.TxHash, b.TxHash)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.DepositTx, error) {
+			depositFilter.MaxIndex = exportLimitMaxKey(filterMaxIndex, maxKey)
+			depositTxs, _, err := db.GetDepositTxsFiltered(0, limit, depositSyncState.FinalBlock, depositFilter)
+			return depositTxs, err
+		},
+		write: func(depositTx *dbtypes.DepositTx) error {
+			return writeRow([]interface{}{
+				int64(depositTx.Index),
+				int64(depositTx.BlockNumber),
+				int64(depositTx.BlockTime),
+				exportHex(depositTx.BlockRoot),
+				exportHex(depositTx.TxHash),
+				exportHex(depositTx.TxSender),
+				exportHex(depositTx.TxTarget),
+				exportHex(depositTx.PublicKey),
+				exportHex(depositTx.WithdrawalCredentials),
+				int64(depositTx.Amount),
+				depositTx.ValidSignature,
+				depositTx.Orphaned,
+			})
+		},
+	}
+	return pager.run()
+}
+
+func exportIncludedDeposits(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	depositFilter := &dbtypes.DepositFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		depositFilter.MinIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		depositFilter.MaxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		depositFilter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+		depositFilter.ValidatorName = urlArgs.Get("f.vname")
+		depositFilter.MinAmount, _ = strconv.ParseUint(urlArgs.Get("f.mina"), 10, 64)
+		depositFilter.MaxAmount, _ = strconv.ParseUint(urlArgs.Get("f.maxa"), 10, 64)
+		depositFilter.WithOrphaned = uint8(withOrphaned)
+	}
+
+	filterMaxIndex := depositFilter.MaxIndex
+	pager := &exportPager[*dbtypes.Deposit]{
+		key: func(deposit *dbtypes.Deposit) uint64 {
+			if deposit.Index == nil {
+				// deposits without index are the most recent ones
+				return math.MaxUint64
+			}
+			return *deposit.Index
+		},
+		tiebreak: func(a, b *dbtypes.Deposit) int {
+			if c := bytes.Compare(a.SlotRoot, b.SlotRoot); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.SlotIndex, b.SlotIndex)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.Deposit, error) {
+			depositFilter.MaxIndex = exportLimitMaxKey(filterMaxIndex, maxKey)
+			deposits, _ := services.GlobalBeaconService.GetIncludedDepositsByFilter(depositFilter, 0, limit)
+			return deposits, nil
+		},
+		write: func(deposit *dbtypes.Deposit) error {
+			return writeRow([]interface{}{
+				exportUint(deposit.Index),
+				int64(deposit.SlotNumber),
+				exportSlotTime(deposit.SlotNumber),
+				exportHex(deposit.SlotRoot),
+				exportHex(deposit.PublicKey),
+				exportHex(deposit.WithdrawalCredentials),
+				int64(deposit.Amount),
+				deposit.Orphaned,
+			})
+		},
+	}
+	return pager.run()
+}
+
+func exportVoluntaryExits(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	voluntaryExitFilter := &dbtypes.VoluntaryExitFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		voluntaryExitFilter.MinSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		voluntaryExitFilter.MaxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		voluntaryExitFilter.MinIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		voluntaryExitFilter.MaxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		voluntaryExitFilter.ValidatorName = urlArgs.Get("f.vname")
+		voluntaryExitFilter.WithOrphaned = uint8(withOrphaned)
+	}
+
+	filterMaxSlot := voluntaryExitFilter.MaxSlot
+	pager := &exportPager[*dbtypes.VoluntaryExit]{
+		key: func(voluntaryExit *dbtypes.VoluntaryExit) uint64 {
+			return voluntaryExit.SlotNumber
+		},
+		tiebreak: func(a, b *dbtypes.VoluntaryExit) int {
+			if c := bytes.Compare(a.SlotRoot, b.SlotRoot); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.SlotIndex, b.SlotIndex)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.VoluntaryExit, error) {
+			voluntaryExitFilter.MaxSlot = exportLimitMaxKey(filterMaxSlot, maxKey)
+			voluntaryExits, _ := services.GlobalBeaconService.GetVoluntaryExitsByFilter(voluntaryExitFilter, 0, limit)
+			return voluntaryExits, nil
+		},
+		write: func(voluntaryExit *dbtypes.VoluntaryExit) error {
+			return writeRow([]interface{}{
+				int64(voluntaryExit.SlotNumber),
+				exportSlotTime(voluntaryExit.SlotNumber),
+				exportHex(voluntaryExit.SlotRoot),
+				int64(voluntaryExit.ValidatorIndex),
+				exportValidatorName(&voluntaryExit.ValidatorIndex),
+				voluntaryExit.Orphaned,
+			})
+		},
+	}
+	return pager.run()
+}
+
+func exportSlashings(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	slashingFilter := &dbtypes.SlashingFilter{
+		WithOrphaned: 1,
+	}
+	if urlArgs.Has("f") {
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		withReason, _ := strconv.ParseUint(urlArgs.Get("f.reason"), 10, 64)
+		slashingFilter.MinSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		slashingFilter.MaxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		slashingFilter.MinIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		slashingFilter.MaxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		slashingFilter.ValidatorName = urlArgs.Get("f.vname")
+		slashingFilter.SlasherName = urlArgs.Get("f.sname")
+		slashingFilter.WithReason = dbtypes.SlashingReason(withReason)
+		slashingFilter.WithOrphaned = uint8(withOrphaned)
+	}
+
+	filterMaxSlot := slashingFilter.MaxSlot
+	pager := &exportPager[*dbtypes.Slashing]{
+		key: func(slashing *dbtypes.Slashing) uint64 {
+			return slashing.SlotNumber
+		},
+		tiebreak: func(a, b *dbtypes.Slashing) int {
+			if c := bytes.Compare(a.SlotRoot, b.SlotRoot); c != 0 {
+				return c
+			}
+			if c := cmp.Compare(a.SlotIndex, b.SlotIndex); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.ValidatorIndex, b.ValidatorIndex)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.Slashing, error) {
+			slashingFilter.MaxSlot = exportLimitMaxKey(filterMaxSlot, maxKey)
+			slashings, _ := services.GlobalBeaconService.GetSlashingsByFilter(slashingFilter, 0, limit)
+			return slashings, nil
+		},
+		write: func(slashing *dbtypes.Slashing) error {
+			var reason string
+			switch slashing.Reason {
+			case dbtypes.ProposerSlashing:
+				reason = "proposer"
+			case dbtypes.AttesterSlashing:
+				reason = "attester"
+			default:
+				reason = "unspecified"
+			}
+
+			return writeRow([]interface{}{
+				int64(slashing.SlotNumber),
+				exportSlotTime(slashing.SlotNumber),
+				exportHex(slashing.SlotRoot),
+				int64(slashing.ValidatorIndex),
+				exportValidatorName(&slashing.ValidatorIndex),
+				int64(slashing.SlasherIndex),
+				exportValidatorName(&slashing.SlasherIndex),
+				reason,
+				slashing.Orphaned,
+			})
+		},
+	}
+	return pager.run()
+}
+
+func exportElWithdrawals(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	withdrawalRequestFilter := &services.CombinedWithdrawalRequestFilter{
+		Filter: &dbtypes.WithdrawalRequestFilter{
+			WithOrphaned: 1,
+		},
+	}
+	if urlArgs.Has("f") {
+		filter := withdrawalRequestFilter.Filter
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		filter.MinSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		filter.MaxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		filter.SourceAddress = common.FromHex(urlArgs.Get("f.address"))
+		filter.MinIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		filter.MaxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		filter.ValidatorName = urlArgs.Get("f.vname")
+		filter.WithOrphaned = uint8(withOrphaned)
+		filter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+
+		switch urlArgs.Get("f.type") {
+		case "1": // withdrawals
+			minAmount := uint64(1)
+			filter.MinAmount = &minAmount
+		case "2": // exits
+			maxAmount := uint64(0)
+			filter.MaxAmount = &maxAmount
+		}
+	}
+
+	writeWithdrawalRequest := func(withdrawalRequest *services.CombinedWithdrawalRequest) error {
+		row := make([]interface{}, 11)
+		if request := withdrawalRequest.Request; request != nil {
+			row[0] = int64(request.SlotNumber)
+			row[1] = exportHex(request.SlotRoot)
+			row[2] = int64(request.BlockNumber)
+			row[3] = exportHex(request.TxHash)
+			row[9] = int64(request.Result)
+			row[10] = withdrawalRequest.RequestOrphaned
+		} else if transaction := withdrawalRequest.Transaction; transaction != nil {
+			row[2] = int64(transaction.BlockNumber)
+			row[3] = exportHex(transaction.TxHash)
+			row[10] = withdrawalRequest.TransactionOrphaned
+		}
+		row[4] = exportHex(withdrawalRequest.SourceAddress())
+		row[5] = exportUint(withdrawalRequest.ValidatorIndex())
+		row[6] = exportValidatorName(withdrawalRequest.ValidatorIndex())
+		row[7] = exportHex(withdrawalRequest.ValidatorPubkey())
+		row[8] = int64(withdrawalRequest.Amount())
+		return writeRow(row)
+	}
+
+	// pending request transactions (not dequeued yet, so the set is small and has no slot to page by)
+	withdrawalRequestFilter.Request = 1
+	for offset := uint64(0); ; offset += exportBatchSize {
+		withdrawalRequests, _, _ := services.GlobalBeaconService.GetWithdrawalRequestsByFilter(withdrawalRequestFilter, offset, exportBatchSize)
+		for _, withdrawalRequest := range withdrawalRequests {
+			if err := writeWithdrawalRequest(withdrawalRequest); err != nil {
+				return err
+			}
+		}
+
+		if len(withdrawalRequests) < exportBatchSize {
+			break
+		}
+	}
+
+	// included requests (always have a request entry)
+	withdrawalRequestFilter.Request = 2
+	filterMaxSlot := withdrawalRequestFilter.Filter.MaxSlot
+	pager := &exportPager[*services.CombinedWithdrawalRequest]{
+		key: func(withdrawalRequest *services.CombinedWithdrawalRequest) uint64 {
+			return withdrawalRequest.Request.SlotNumber
+		},
+		tiebreak: func(a, b *services.CombinedWithdrawalRequest) int {
+			if c := bytes.Compare(a.Request.SlotRoot, b.Request.SlotRoot); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Request.SlotIndex, b.Request.SlotIndex)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*services.CombinedWithdrawalRequest, error) {
+			withdrawalRequestFilter.Filter.MaxSlot = exportLimitMaxKey(filterMaxSlot, maxKey)
+			withdrawalRequests, _, _ := services.GlobalBeaconService.GetWithdrawalRequestsByFilter(withdrawalRequestFilter, 0, limit)
+			return withdrawalRequests, nil
+		},
+		write: func(withdrawalRequest *services.CombinedWithdrawalRequest) error {
+			return writeWithdrawalRequest(withdrawalRequest)
+		},
+	}
+	return pager.run()
+}
+
+func exportElConsolidations(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	consolidationRequestFilter := &services.CombinedConsolidationRequestFilter{
+		Filter: &dbtypes.ConsolidationRequestFilter{},
+	}
+	if urlArgs.Has("f") {
+		filter := consolidationRequestFilter.Filter
+		withOrphaned, _ := strconv.ParseUint(urlArgs.Get("f.orphaned"), 10, 64)
+		filter.MinSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		filter.MaxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		filter.SourceAddress = common.FromHex(urlArgs.Get("f.address"))
+		filter.MinSrcIndex, _ = strconv.ParseUint(urlArgs.Get("f.minsi"), 10, 64)
+		filter.MaxSrcIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxsi"), 10, 64)
+		filter.SrcValidatorName = urlArgs.Get("f.svname")
+		filter.MinTgtIndex, _ = strconv.ParseUint(urlArgs.Get("f.minti"), 10, 64)
+		filter.MaxTgtIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxti"), 10, 64)
+		filter.TgtValidatorName = urlArgs.Get("f.tvname")
+		filter.WithOrphaned = uint8(withOrphaned)
+		filter.PublicKey = common.FromHex(urlArgs.Get("f.pubkey"))
+	}
+
+	writeConsolidationRequest := func(consolidationRequest *services.CombinedConsolidationRequest) error {
+		row := make([]interface{}, 13)
+		if request := consolidationRequest.Request; request != nil {
+			row[0] = int64(request.SlotNumber)
+			row[1] = exportHex(request.SlotRoot)
+			row[2] = int64(request.BlockNumber)
+			row[3] = exportHex(request.TxHash)
+			row[11] = int64(request.Result)
+			row[12] = consolidationRequest.RequestOrphaned
+		} else if transaction := consolidationRequest.Transaction; transaction != nil {
+			row[2] = int64(transaction.BlockNumber)
+			row[3] = exportHex(transaction.TxHash)
+			row[12] = consolidationRequest.TransactionOrphaned
+		}
+		row[4] = exportHex(consolidationRequest.SourceAddress())
+		row[5] = exportUint(consolidationRequest.SourceIndex())
+		row[6] = exportValidatorName(consolidationRequest.SourceIndex())
+		row[7] = exportHex(consolidationRequest.SourcePubkey())
+		row[8] = exportUint(consolidationRequest.TargetIndex())
+		row[9] = exportValidatorName(consolidationRequest.TargetIndex())
+		row[10] = exportHex(consolidationRequest.TargetPubkey())
+		return writeRow(row)
+	}
+
+	// pending request transactions (not dequeued yet, so the set is small and has no slot to page by)
+	consolidationRequestFilter.Request = 1
+	for offset := uint64(0); ; offset += exportBatchSize {
+		consolidationRequests, _, _ := services.GlobalBeaconService.GetConsolidationRequestsByFilter(consolidationRequestFilter, offset, exportBatchSize)
+		for _, consolidationRequest := range consolidationRequests {
+			if err := writeConsolidationRequest(consolidationRequest); err != nil {
+				return err
+			}
+		}
+
+		if len(consolidationRequests) < exportBatchSize {
+			break
+		}
+	}
+
+	// included requests (always have a request entry)
+	consolidationRequestFilter.Request = 2
+	filterMaxSlot := consolidationRequestFilter.Filter.MaxSlot
+	pager := &exportPager[*services.CombinedConsolidationRequest]{
+		key: func(consolidationRequest *services.CombinedConsolidationRequest) uint64 {
+			return consolidationRequest.Request.SlotNumber
+		},
+		tiebreak: func(a, b *services.CombinedConsolidationRequest) int {
+			if c := bytes.Compare(a.Request.SlotRoot, b.Request.SlotRoot); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.Request.SlotIndex, b.Request.SlotIndex)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*services.CombinedConsolidationRequest, error) {
+			consolidationRequestFilter.Filter.MaxSlot = exportLimitMaxKey(filterMaxSlot, maxKey)
+			consolidationRequests, _, _ := services.GlobalBeaconService.GetConsolidationRequestsByFilter(consolidationRequestFilter, 0, limit)
+			return consolidationRequests, nil
+		},
+		write: func(consolidationRequest *services.CombinedConsolidationRequest) error {
+			return writeConsolidationRequest(consolidationRequest)
+		},
+	}
+	return pager.run()
+}
+
+func exportMevBlocks(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	mevBlockFilter := &dbtypes.MevBlockFilter{}
+	if urlArgs.Has("f") {
+		mevBlockFilter.MinSlot, _ = strconv.ParseUint(urlArgs.Get("f.mins"), 10, 64)
+		mevBlockFilter.MaxSlot, _ = strconv.ParseUint(urlArgs.Get("f.maxs"), 10, 64)
+		mevBlockFilter.MinIndex, _ = strconv.ParseUint(urlArgs.Get("f.mini"), 10, 64)
+		mevBlockFilter.MaxIndex, _ = strconv.ParseUint(urlArgs.Get("f.maxi"), 10, 64)
+		mevBlockFilter.ProposerName = urlArgs.Get("f.vname")
+
+		for _, relayIdStr := range strings.Split(urlArgs.Get("f.relays"), " ") {
+			relayId, err := strconv.ParseUint(relayIdStr, 10, 64)
+			if err == nil && relayId < 63 {
+				mevBlockFilter.MevRelay = append(mevBlockFilter.MevRelay, uint8(relayId))
+			}
+		}
+		for _, proposedStr := range strings.Split(urlArgs.Get("f.proposed"), " ") {
+			proposedOpt, err := strconv.ParseUint(proposedStr, 10, 64)
+			if err == nil && proposedOpt <= 2 {
+				mevBlockFilter.Proposed = append(mevBlockFilter.Proposed, uint8(proposedOpt))
+			}
+		}
+	}
+
+	filterMaxSlot := mevBlockFilter.MaxSlot
+	pager := &exportPager[*dbtypes.MevBlock]{
+		key: func(mevBlock *dbtypes.MevBlock) uint64 {
+			return mevBlock.SlotNumber
+		},
+		tiebreak: func(a, b *dbtypes.MevBlock) int {
+			return bytes.Compare(a.BlockHash, b.BlockHash)
+		},
+		load: func(maxKey uint64, limit uint32) ([]*dbtypes.MevBlock, error) {
+			mevBlockFilter.MaxSlot = exportLimitMaxKey(filterMaxSlot, maxKey)
+			mevBlocks, _, err := db.GetMevBlocksFiltered(0, limit, mevBlockFilter)
+			return mevBlocks, err
+		},
+		write: func(mevBlock *dbtypes.MevBlock) error {
+			var proposed string
+			switch mevBlock.Proposed {
+			case 1:
+				proposed = "proposed"
+			case 2:
+				proposed = "orphaned"
+			default:
+				proposed = "missed"
+			}
+
+			relays := []string{}
+			for _, relay := range utils.Config.MevIndexer.Relays {
+				relayFlag := uint64(1) << uint64(relay.Index)
+				if mevBlock.SeenbyRelays&relayFlag > 0 {
+					relays = append(relays, relay.Name)
+				}
+			}
+
+			return writeRow([]interface{}{
+				int64(mevBlock.SlotNumber),
+				exportSlotTime(mevBlock.SlotNumber),
+				int64(mevBlock.BlockNumber),
+				exportHex(mevBlock.BlockHash),
+				int64(mevBlock.ProposerIndex),
+				exportValidatorName(&mevBlock.ProposerIndex),
+				exportHex(mevBlock.BuilderPubkey),
+				proposed,
+				strings.Join(relays, ","),
+				exportHex(mevBlock.FeeRecipient),
+				int64(mevBlock.TxCount),
+				int64(mevBlock.GasUsed),
+				int64(mevBlock.BlockValueGwei),
+			})
+		},
+	}
+	return pager.run()
+}
+
+// validateValidatorsExport rejects as-of epochs that are not covered by the validator history.
+func validateValidatorsExport(urlArgs url.Values) error {
+	if !urlArgs.Has("f") || urlArgs.Get("f.epoch") == "" {
+		return nil
+	}
+
+	filterEpochVal, err := strconv.ParseUint(urlArgs.Get("f.epoch"), 10, 64)
+	if err != nil || filterEpochVal > uint64(services.GlobalBeaconService.GetChainState().CurrentEpoch()) {
+		return nil
+	}
+
+	if !services.GlobalBeaconService.IsValidatorHistoryAvailable(phase0.Epoch(filterEpochVal)) {
+		if startEpoch, found := services.GlobalBeaconService.GetValidatorHistoryStartEpoch(); found {
+			return fmt.Errorf("validator history is only available since epoch %v", startEpoch)
+		}
+		return fmt.Errorf("no validator history recorded yet")
+	}
+
+	return nil
+}
+
+func exportValidators(urlArgs url.Values, writeRow func(row []interface{}) error) error {
+	validatorFilter := &dbtypes.ValidatorFilter{
+		Limit: exportValidatorBatchSize,
+	}
+	if urlArgs.Has("f") {
+		if filterPubKey := urlArgs.Get("f.pubkey"); filterPubKey != "" {
+			validatorFilter.PubKey, _ = hex.DecodeString(strings.Replace(filterPubKey, "0x", "", -1))
+		}
+		if filterIndex := urlArgs.Get("f.index"); filterIndex != "" {
+			filterIndexVal, _ := strconv.ParseUint(filterIndex, 10, 64)
+			validatorFilter.MinIndex = &filterIndexVal
+			validatorFilter.MaxIndex = &filterIndexVal
+		}
+		validatorFilter.ValidatorName = urlArgs.Get("f.name")
+		if urlArgs.Has("f.status") {
+			for _, status := range strings.Split(strings.Join(urlArgs["f.status"], ","), ",") {
+				statusVal := v1.ValidatorState(0)
+				err := statusVal.UnmarshalJSON([]byte(fmt.Sprintf("\"%v\"", status)))
+				if err == nil {
+					validatorFilter.Status = append(validatorFilter.Status, statusVal)
+				}
+			}
+		}
+		if filterEpoch := urlArgs.Get("f.epoch"); filterEpoch != "" {
+			filterEpochVal, err := strconv.ParseUint(filterEpoch, 10, 64)
+			if err == nil && filterEpochVal <= uint64(services.GlobalBeaconService.GetChainState().CurrentEpoch()) {
+				validatorFilter.AsOfEpoch = &filterEpochVal
+			}
+		}
+	}
+
+	switch urlArgs.Get("o") {
+	case "index-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderIndexDesc
+	case "pubkey":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderPubKeyAsc
+	case "pubkey-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderPubKeyDesc
+	case "balance":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderBalanceAsc
+	case "balance-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderBalanceDesc
+	case "activation":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderActivationEpochAsc
+	case "activation-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderActivationEpochDesc
+	case "exit":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderExitEpochAsc
+	case "exit-d":
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderExitEpochDesc
+	default:
+		validatorFilter.OrderBy = dbtypes.ValidatorOrderIndexAsc
+	}
+
+	for validatorFilter.Offset = 0; ; validatorFilter.Offset += exportValidatorBatchSize {
+		validators, _ := services.GlobalBeaconService.GetFilteredValidatorSet(validatorFilter, true)
+		for _, validator := range validators {
+			if validator.Validator == nil {
+				continue
+			}
+
+			validatorIndex := uint64(validator.Index)
+			err := writeRow([]interface{}{
+				int64(validatorIndex),
+				exportValidatorName(&validatorIndex),
+				exportHex(validator.Validator.PublicKey[:]),
+				validator.Status.String(),
+				int64(validator.Balance),
+				int64(validator.Validator.EffectiveBalance),
+				validator.Validator.Slashed,
+				exportHex(validator.Validator.WithdrawalCredentials),
+				exportEpoch(validator.Validator.ActivationEligibilityEpoch),
+				exportEpoch(validator.Validator.ActivationEpoch),
+				exportEpoch(validator.Validator.ExitEpoch),
+				exportEpoch(validator.Validator.WithdrawableEpoch),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(validators) < exportValidatorBatchSize {
+			return nil
+		}
+	}
+}
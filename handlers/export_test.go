@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type exportTestRow struct {
+	key uint64
+	id  uint64
+}
+
+// newExportTestPager returns a pager over the given rows, which are returned in random order for rows sharing a key.
+func newExportTestPager(rows []exportTestRow, written *[]exportTestRow) *exportPager[exportTestRow] {
+	return &exportPager[exportTestRow]{
+		key: func(row exportTestRow) uint64 {
+			return row.key
+		},
+		tiebreak: func(a, b exportTestRow) int {
+			return cmp.Compare(a.id, b.id)
+		},
+		load: func(maxKey uint64, limit uint32) ([]exportTestRow, error) {
+			result := []exportTestRow{}
+			for _, row := range rows {
+				if maxKey == 0 || row.key <= maxKey {
+					result = append(result, row)
+				}
+			}
+
+			rand.Shuffle(len(result), func(i, j int) {
+				result[i], result[j] = result[j], result[i]
+			})
+			slices.SortStableFunc(result, func(a, b exportTestRow) int {
+				return cmp.Compare(b.key, a.key)
+			})
+
+			if len(result) > int(limit) {
+				result = result[:limit]
+			}
+			return result, nil
+		},
+		write: func(row exportTestRow) error {
+			*written = append(*written, row)
+			return nil
+		},
+	}
+}
+
+func TestExportPager(t *testing.T) {
+	rows := []exportTestRow{}
+	addRows := func(key uint64, count int) {
+		for i := 0; i < count; i++ {
+			rows = append(rows, exportTestRow{key: key, id: uint64(len(rows))})
+		}
+	}
+
+	addRows(0, 1500) // key 0 can't be used as upper bound
+	for key := uint64(1); key <= 700; key++ {
+		addRows(key, 3) // ties crossing the batch boundaries
+	}
+	addRows(701, 2500) // single key filling multiple batches
+	addRows(702, 1)
+
+	expected := slices.Clone(rows)
+	slices.SortFunc(expected, func(a, b exportTestRow) int {
+		if a.key != b.key {
+			return cmp.Compare(b.key, a.key)
+		}
+		return cmp.Compare(a.id, b.id)
+	})
+
+	for run := 0; run < 3; run++ {
+		written := []exportTestRow{}
+		if err := newExportTestPager(rows, &written).run(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !slices.Equal(written, expected) {
+			t.Fatalf("run %v: expected %v rows in deterministic order, got %v rows", run, len(expected), len(written))
+		}
+	}
+}
+
+func TestExportLimitMaxKey(t *testing.T) {
+	tests := []struct {
+		filterMaxKey uint64
+		pagerMaxKey  uint64
+		expected     uint64
+	}{
+		{0, 0, 0},
+		{100, 0, 100},
+		{0, 50, 50},
+		{100, 50, 50},
+		{30, 50, 30},
+	}
+
+	for _, test := range tests {
+		if result := exportLimitMaxKey(test.filterMaxKey, test.pagerMaxKey); result != test.expected {
+			t.Errorf("exportLimitMaxKey(%v, %v): expected %v, got %v", test.filterMaxKey, test.pagerMaxKey, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+)
+
+// FeeRecipient will return the "fee_recipient" page using a go template
+func FeeRecipient(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"fee_recipient/fee_recipient.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/feerecipient", "Fee Recipient", templateFiles)
+
+	vars := mux.Vars(r)
+	address, err := hex.DecodeString(strings.TrimPrefix(vars["address"], "0x"))
+	if err != nil || len(address) != 20 {
+		NotFound(w, r)
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	var pageSize uint64 = 50
+	if urlArgs.Has("c") {
+		pageSize, _ = strconv.ParseUint(urlArgs.Get("c"), 10, 64)
+	}
+	var pageIdx uint64 = 1
+	if urlArgs.Has("p") {
+		pageIdx, _ = strconv.ParseUint(urlArgs.Get("p"), 10, 64)
+		if pageIdx < 1 {
+			pageIdx = 1
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getFeeRecipientPageData(address, pageIdx, pageSize)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "fee_recipient.go", "FeeRecipient", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getFeeRecipientPageData(address []byte, pageIdx uint64, pageSize uint64) (*models.FeeRecipientPageData, error) {
+	pageData := &models.FeeRecipientPageData{}
+	pageCacheKey := fmt.Sprintf("fee_recipient:%x:%v:%v", address, pageIdx, pageSize)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(_ *services.FrontendCacheProcessingPage) interface{} {
+		return buildFeeRecipientPageData(address, pageIdx, pageSize)
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.FeeRecipientPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildFeeRecipientPageData(address []byte, pageIdx uint64, pageSize uint64) *models.FeeRecipientPageData {
+	logrus.Debugf("fee_recipient page called: 0x%x %v:%v", address, pageIdx, pageSize)
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	pageData := &models.FeeRecipientPageData{
+		Address: address,
+		Blocks:  []*models.FeeRecipientPageDataBlock{},
+	}
+
+	if pageIdx == 1 {
+		pageData.IsDefaultPage = true
+	}
+
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	if pageSize == 0 {
+		pageSize = 50
+	}
+	pageData.PageSize = pageSize
+	pageData.TotalPages = pageIdx
+	pageData.CurrentPageIndex = pageIdx
+	if pageIdx > 1 {
+		pageData.PrevPageIndex = pageIdx - 1
+	}
+
+	slotRevenues, totalRows, err := db.GetSlotRevenuesByFeeRecipient(address, (pageIdx-1)*pageSize, pageSize)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, slotRevenue := range slotRevenues {
+		blockData := &models.FeeRecipientPageDataBlock{
+			Slot:         slotRevenue.Slot,
+			Ts:           chainState.SlotToTime(phase0.Slot(slotRevenue.Slot)),
+			Proposer:     slotRevenue.Proposer,
+			ProposerName: services.GlobalBeaconService.GetValidatorName(slotRevenue.Proposer),
+			IsPayment:    bytes.Equal(slotRevenue.EthPaymentRecipient, address),
+		}
+		if slotRevenue.EthBlockNumber != nil {
+			blockData.BlockNumber = *slotRevenue.EthBlockNumber
+		}
+		if slotRevenue.EthPriorityFees != nil {
+			blockData.HasPriorityFees = true
+			blockData.PriorityFees = *slotRevenue.EthPriorityFees
+		}
+		if slotRevenue.EthPaymentValue != nil {
+			blockData.HasPayment = true
+			blockData.PaymentValue = *slotRevenue.EthPaymentValue
+		}
+		if slotRevenue.EthBurntFees != nil {
+			blockData.BurntFees = *slotRevenue.EthBurntFees
+		}
+
+		if blockData.IsPayment || !blockData.HasPayment {
+			// address is the proposers fee recipient
+			received, receivedSource := services.GetBlockProposerRevenue(slotRevenue)
+			blockData.ReceivedKnown = receivedSource != services.BlockRevenueUnknown
+			blockData.Received = received
+			blockData.ReceivedSource = receivedSource.String()
+		} else {
+			// address is the builders fee recipient, which received the priority fees and paid the proposer
+			blockData.IsBuilder = true
+			blockData.ReceivedKnown = blockData.HasPriorityFees
+			blockData.Received = blockData.PriorityFees
+			blockData.ReceivedSource = services.BlockRevenueReceipts.String()
+		}
+
+		pageData.Blocks = append(pageData.Blocks, blockData)
+	}
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+	pageData.TotalCount = totalRows
+
+	if pageData.BlockCount > 0 {
+		pageData.FirstIndex = pageData.Blocks[0].Slot
+		pageData.LastIndex = pageData.Blocks[pageData.BlockCount-1].Slot
+	}
+
+	pageData.TotalPages = totalRows / pageSize
+	if totalRows%pageSize > 0 {
+		pageData.TotalPages++
+	}
+	pageData.LastPageIndex = pageData.TotalPages
+	if pageIdx < pageData.TotalPages {
+		pageData.NextPageIndex = pageIdx + 1
+	}
+
+	pageData.FirstPageLink = fmt.Sprintf("/feerecipient/0x%x?c=%v", address, pageData.PageSize)
+	pageData.PrevPageLink = fmt.Sprintf("/feerecipient/0x%x?p=%v&c=%v", address, pageData.PrevPageIndex, pageData.PageSize)
+	pageData.NextPageLink = fmt.Sprintf("/feerecipient/0x%x?p=%v&c=%v", address, pageData.NextPageIndex, pageData.PageSize)
+	pageData.LastPageLink = fmt.Sprintf("/feerecipient/0x%x?p=%v&c=%v", address, pageData.LastPageIndex, pageData.PageSize)
+
+	return pageData
+}
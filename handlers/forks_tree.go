@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// ForksTree will return the "forks_tree" page using a go template
+func ForksTree(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"forks/forks_tree.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "forks", "/forks/tree", "Fork Choice Tree", templateFiles)
+
+	var replaySlot *uint64
+	if slotStr := r.URL.Query().Get("slot"); slotStr != "" {
+		slot, err := strconv.ParseUint(slotStr, 10, 64)
+		if err == nil {
+			replaySlot = &slot
+		}
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getForksTreePageData(replaySlot)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(data.Data)
+		if err != nil {
+			logrus.WithError(err).Error("error encoding fork choice tree data")
+			http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "forks_tree.go", "ForksTree", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getForksTreePageData(replaySlot *uint64) (*models.ForksTreePageData, error) {
+	pageData := &models.ForksTreePageData{}
+	pageCacheKey := "forks_tree"
+	if replaySlot != nil {
+		pageCacheKey = fmt.Sprintf("forks_tree:%v", *replaySlot)
+	}
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildForksTreePageData(replaySlot)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ForksTreePageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildForksTreePageData(replaySlot *uint64) (*models.ForksTreePageData, time.Duration) {
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	beaconIndexer := services.GlobalBeaconService.GetBeaconIndexer()
+	currentSlot := chainState.CurrentSlot()
+
+	pageData := &models.ForksTreePageData{
+		CurrentSlot: uint64(currentSlot),
+		Nodes:       []*models.ForksTreePageDataNode{},
+	}
+	cacheTime := specs.SecondsPerSlot
+
+	treeSlot := currentSlot
+	if replaySlot != nil && *replaySlot < uint64(currentSlot) {
+		treeSlot = phase0.Slot(*replaySlot)
+		pageData.IsReplay = true
+		cacheTime = 10 * time.Minute
+	}
+	pageData.ReplaySlot = uint64(treeSlot)
+	logrus.Debugf("forks tree page called: %v (replay: %v)", treeSlot, pageData.IsReplay)
+
+	tree := beaconIndexer.GetForkChoiceTree(treeSlot)
+	pageData.HeadRoot = tree.HeadRoot[:]
+	pageData.JustifiedEpoch = uint64(tree.JustifiedEpoch)
+	pageData.JustifiedRoot = tree.JustifiedRoot[:]
+	pageData.FinalizedEpoch = uint64(tree.FinalizedEpoch)
+	pageData.FinalizedRoot = tree.FinalizedRoot[:]
+	pageData.TotalWeight = uint64(tree.TotalWeight)
+	pageData.AmountIsCount = tree.AmountIsCount
+
+	// client heads are only meaningful for the live tree
+	clientHeads := map[phase0.Root][]string{}
+	if !pageData.IsReplay {
+		for _, fork := range services.GlobalBeaconService.GetConsensusClientForks() {
+			for _, client := range fork.AllClients {
+				clientHeads[fork.Root] = append(clientHeads[fork.Root], client.GetClient().GetName())
+			}
+		}
+	}
+
+	canonicalHead := beaconIndexer.GetCanonicalHead(nil)
+	nodeMap := map[phase0.Root]*models.ForksTreePageDataNode{}
+	childrenMap := map[phase0.Root][]*beacon.ForkChoiceNode{}
+
+	for idx, node := range tree.Nodes {
+		nodeData := &models.ForksTreePageDataNode{
+			Slot:         uint64(node.Block.Slot),
+			Root:         node.Block.Root[:],
+			ParentRoot:   node.ParentRoot[:],
+			HasParent:    node.HasParent,
+			ForkId:       uint64(node.ForkId),
+			Weight:       uint64(node.Weight),
+			BranchWeight: uint64(node.BranchWeight),
+			VoteCount:    node.VoteCount,
+			IsHeadChain:  node.IsHeadChain,
+			IsHead:       node.IsHead,
+			IsCanonical:  beaconIndexer.IsCanonicalBlockByHead(node.Block, canonicalHead),
+			IsJustified:  node.IsJustified,
+			IsFinalized:  node.IsFinalized,
+			ClientHeads:  clientHeads[node.Block.Root],
+		}
+
+		if header := node.Block.GetHeader(); header != nil {
+			nodeData.Proposer = uint64(header.Message.ProposerIndex)
+			nodeData.ProposerName = services.GlobalBeaconService.GetValidatorName(nodeData.Proposer)
+		}
+		if tree.TotalWeight > 0 {
+			nodeData.BranchShare = float64(node.BranchWeight) * 100 / float64(tree.TotalWeight)
+		}
+
+		if idx == 0 || nodeData.Slot < pageData.MinSlot {
+			pageData.MinSlot = nodeData.Slot
+		}
+		if nodeData.Slot > pageData.MaxSlot {
+			pageData.MaxSlot = nodeData.Slot
+		}
+		if node.IsHead {
+			pageData.HeadSlot = nodeData.Slot
+		}
+
+		nodeMap[node.Block.Root] = nodeData
+		if node.HasParent {
+			childrenMap[node.ParentRoot] = append(childrenMap[node.ParentRoot], node)
+		}
+		pageData.Nodes = append(pageData.Nodes, nodeData)
+	}
+
+	// assign a lane to each node, the heaviest child continues the lane of its parent
+	nextLane := uint64(0)
+	var assignLanes func(node *beacon.ForkChoiceNode, lane uint64)
+	assignLanes = func(node *beacon.ForkChoiceNode, lane uint64) {
+		nodeMap[node.Block.Root].Lane = lane
+
+		children := childrenMap[node.Block.Root]
+		for childIdx, child := range sortForksTreeChildren(children) {
+			childLane := lane
+			if childIdx > 0 {
+				childLane = nextLane
+				nextLane++
+			}
+			assignLanes(child, childLane)
+		}
+	}
+	for _, node := range sortForksTreeChildren(tree.Nodes) {
+		if node.HasParent {
+			continue
+		}
+		lane := nextLane
+		nextLane++
+		assignLanes(node, lane)
+	}
+	pageData.LaneCount = nextLane
+	pageData.NodeCount = uint64(len(pageData.Nodes))
+
+	return pageData, cacheTime
+}
+
+// sortForksTreeChildren returns the nodes ordered by descending branch weight
+func sortForksTreeChildren(nodes []*beacon.ForkChoiceNode) []*beacon.ForkChoiceNode {
+	sorted := make([]*beacon.ForkChoiceNode, len(nodes))
+	copy(sorted, nodes)
+	sort.SliceStable(sorted, func(a, b int) bool {
+		return sorted[a].BranchWeight > sorted[b].BranchWeight
+	})
+	return sorted
+}
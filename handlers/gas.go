@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// number of time buckets shown in the gas charts
+const gasBucketCount = 50
+
+// max number of gas limit voters shown in the vote table
+const gasVoterLimit = 100
+
+// Gas will return the "gas" page using a go template
+func Gas(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"gas/gas.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/gas", "Gas", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseGasArgs(r.URL.Query())
+		data.Data, pageError = getGasPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "gas.go", "Gas", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// GasData will return the gas analytics as json
+func GasData(w http.ResponseWriter, r *http.Request) {
+	var pageData *models.GasPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseGasArgs(r.URL.Query())
+		pageData, pageError = getGasPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding gas analytics data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseGasArgs(urlArgs url.Values) (startEpoch uint64, endEpoch uint64) {
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	if finalizedEpoch > 0 {
+		endEpoch = uint64(finalizedEpoch) - 1
+	}
+	if endEpoch > 224 {
+		startEpoch = endEpoch - 224
+	}
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.start") {
+			startEpoch, _ = strconv.ParseUint(urlArgs.Get("f.start"), 10, 64)
+		}
+		if urlArgs.Has("f.end") {
+			endEpoch, _ = strconv.ParseUint(urlArgs.Get("f.end"), 10, 64)
+		}
+	}
+
+	if endEpoch < startEpoch {
+		startEpoch, endEpoch = endEpoch, startEpoch
+	}
+
+	maxEpochRange := utils.Config.GasAnalytics.MaxEpochRange
+	if maxEpochRange > 0 && endEpoch-startEpoch >= maxEpochRange {
+		startEpoch = endEpoch - maxEpochRange + 1
+	}
+
+	return
+}
+
+func getGasPageData(startEpoch uint64, endEpoch uint64) (*models.GasPageData, error) {
+	pageData := &models.GasPageData{}
+	pageCacheKey := fmt.Sprintf("gas:%v:%v", startEpoch, endEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildGasPageData(startEpoch, endEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.GasPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildGasPageData(startEpoch uint64, endEpoch uint64) (*models.GasPageData, time.Duration) {
+	logrus.Debugf("gas page called: %v-%v", startEpoch, endEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+
+	pageData := &models.GasPageData{
+		FilterStartEpoch: startEpoch,
+		FilterEndEpoch:   endEpoch,
+		MaxEpochRange:    utils.Config.GasAnalytics.MaxEpochRange,
+		FinalizedEpoch:   uint64(finalizedEpoch),
+		Buckets:          []*models.GasPageDataBucket{},
+		Voters:           []*models.GasPageDataVoter{},
+	}
+
+	cacheTime := 5 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	}
+
+	analytics, err := services.GlobalBeaconService.GetGasAnalytics(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch), gasBucketCount)
+	if err != nil {
+		panic(err)
+	}
+
+	pageData.BlockCount = analytics.BlockCount
+	pageData.GasUsed = analytics.GasUsed
+	pageData.FirstGasLimit = analytics.FirstGasLimit
+	pageData.LastGasLimit = analytics.LastGasLimit
+	pageData.UpCount = analytics.UpCount
+	pageData.DownCount = analytics.DownCount
+	pageData.SameCount = analytics.SameCount
+	pageData.ChangingVoters = analytics.ChangingVoters
+	pageData.UnchangedVoters = analytics.UnchangedVoters
+	pageData.BucketSize = analytics.BucketSize
+	if analytics.GasLimit > 0 {
+		pageData.Utilization = float64(analytics.GasUsed) * 100 / float64(analytics.GasLimit)
+	}
+
+	for _, bucket := range analytics.Buckets {
+		bucketData := &models.GasPageDataBucket{
+			StartEpoch:   uint64(bucket.StartEpoch),
+			EndEpoch:     uint64(bucket.EndEpoch),
+			BlockCount:   bucket.BlockCount,
+			LastGasLimit: bucket.LastGasLimit,
+		}
+		if bucket.BlockCount > 0 {
+			bucketData.AvgGasLimit = bucket.GasLimit / bucket.BlockCount
+		}
+		if bucket.GasLimit > 0 {
+			bucketData.Utilization = float64(bucket.GasUsed) * 100 / float64(bucket.GasLimit)
+		}
+		if bucket.BaseFeeCount > 0 {
+			bucketData.AvgBaseFee = bucket.BaseFeeSum / float64(bucket.BaseFeeCount) / 1e9
+		}
+
+		pageData.Buckets = append(pageData.Buckets, bucketData)
+	}
+
+	pageData.VoterCount = uint64(len(analytics.Voters))
+	for idx, voter := range analytics.Voters {
+		if idx >= gasVoterLimit {
+			break
+		}
+
+		voterData := &models.GasPageDataVoter{
+			Name:           voter.Name,
+			ValidatorIndex: voter.ValidatorIndex,
+			BlockCount:     voter.BlockCount,
+			UpCount:        voter.UpCount,
+			DownCount:      voter.DownCount,
+			SameCount:      voter.SameCount,
+			LastSlot:       uint64(voter.LastSlot),
+			LastGasLimit:   voter.LastGasLimit,
+		}
+		if voter.UpCount > voter.DownCount {
+			voterData.Direction = 1
+		} else if voter.DownCount > voter.UpCount {
+			voterData.Direction = -1
+		}
+
+		pageData.Voters = append(pageData.Voters, voterData)
+	}
+
+	return pageData, cacheTime
+}
@@ -21,6 +21,7 @@ import (
 func IncludedDeposits(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"included_deposits/included_deposits.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -23,6 +23,7 @@ import (
 func InitiatedDeposits(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"initiated_deposits/initiated_deposits.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
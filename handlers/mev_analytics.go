@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// number of time buckets shown in the mev share charts
+const mevAnalyticsBucketCount = 50
+
+// max number of epochs that can be aggregated in a single request
+const mevAnalyticsMaxEpochRange = 2250
+
+// MevBuilders will return the "mev_builders" page using a go template
+func MevBuilders(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"mev_analytics/mev_builders.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/mev/builders", "MEV Builders", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseMevAnalyticsArgs(r.URL.Query())
+		data.Data, pageError = getMevAnalyticsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "mev_analytics.go", "MevBuilders", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// MevRelays will return the "mev_relays" page using a go template
+func MevRelays(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"mev_analytics/mev_relays.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/mev/relays", "MEV Relays", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseMevAnalyticsArgs(r.URL.Query())
+		data.Data, pageError = getMevAnalyticsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "mev_analytics.go", "MevRelays", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// MevAnalyticsData will return the builder & relay analytics as json
+func MevAnalyticsData(w http.ResponseWriter, r *http.Request) {
+	var pageData *models.MevAnalyticsPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseMevAnalyticsArgs(r.URL.Query())
+		pageData, pageError = getMevAnalyticsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(pageData)
+	if err != nil {
+		logrus.WithError(err).Error("error encoding mev analytics data")
+		http.Error(w, "Internal server error", http.StatusServiceUnavailable)
+	}
+}
+
+func parseMevAnalyticsArgs(urlArgs url.Values) (startEpoch uint64, endEpoch uint64) {
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+	if finalizedEpoch > 0 {
+		endEpoch = uint64(finalizedEpoch) - 1
+	}
+	if endEpoch > 224 {
+		startEpoch = endEpoch - 224
+	}
+
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.start") {
+			startEpoch, _ = strconv.ParseUint(urlArgs.Get("f.start"), 10, 64)
+		}
+		if urlArgs.Has("f.end") {
+			endEpoch, _ = strconv.ParseUint(urlArgs.Get("f.end"), 10, 64)
+		}
+	}
+
+	if endEpoch < startEpoch {
+		startEpoch, endEpoch = endEpoch, startEpoch
+	}
+
+	if endEpoch-startEpoch >= mevAnalyticsMaxEpochRange {
+		startEpoch = endEpoch - mevAnalyticsMaxEpochRange + 1
+	}
+
+	return
+}
+
+func getMevAnalyticsPageData(startEpoch uint64, endEpoch uint64) (*models.MevAnalyticsPageData, error) {
+	pageData := &models.MevAnalyticsPageData{}
+	pageCacheKey := fmt.Sprintf("mev_analytics:%v:%v", startEpoch, endEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildMevAnalyticsPageData(startEpoch, endEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.MevAnalyticsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildMevAnalyticsPageData(startEpoch uint64, endEpoch uint64) (*models.MevAnalyticsPageData, time.Duration) {
+	logrus.Debugf("mev analytics page called: %v-%v", startEpoch, endEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+	finalizedEpoch, _ := services.GlobalBeaconService.GetFinalizedEpoch()
+
+	pageData := &models.MevAnalyticsPageData{
+		FilterStartEpoch: startEpoch,
+		FilterEndEpoch:   endEpoch,
+		MaxEpochRange:    mevAnalyticsMaxEpochRange,
+		FinalizedEpoch:   uint64(finalizedEpoch),
+		Builders:         []*models.MevAnalyticsPageDataBuilder{},
+		Relays:           []*models.MevAnalyticsPageDataRelay{},
+		Buckets:          []*models.MevAnalyticsPageDataBucket{},
+	}
+
+	cacheTime := 5 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	}
+
+	analytics, err := services.GlobalBeaconService.GetMevAnalytics(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch), mevAnalyticsBucketCount)
+	if err != nil {
+		panic(err)
+	}
+
+	pageData.PayloadCount = analytics.PayloadCount
+	pageData.MevBlockCount = analytics.MevBlockCount
+	pageData.LocalBlockCount = analytics.LocalBlockCount
+	pageData.MevValue = analytics.MevValue
+	pageData.BucketSize = analytics.BucketSize
+	if analytics.PayloadCount > 0 {
+		pageData.MevShare = float64(analytics.MevBlockCount) * 100 / float64(analytics.PayloadCount)
+		pageData.LocalShare = float64(analytics.LocalBlockCount) * 100 / float64(analytics.PayloadCount)
+	}
+	if analytics.MevBlockCount > 0 {
+		pageData.AvgMevValue = analytics.MevValue / analytics.MevBlockCount
+	}
+
+	for _, builder := range analytics.Builders {
+		builderData := &models.MevAnalyticsPageDataBuilder{
+			Pubkey:     builder.Pubkey,
+			BlockCount: builder.BlockCount,
+			TotalValue: builder.TotalValue,
+			AvgValue:   builder.TotalValue / builder.BlockCount,
+			MaxValue:   builder.MaxValue,
+			LastSlot:   uint64(builder.LastSlot),
+			Relays:     []*models.MevBlocksPageDataRelay{},
+		}
+		if analytics.MevBlockCount > 0 {
+			builderData.Share = float64(builder.BlockCount) * 100 / float64(analytics.MevBlockCount)
+		}
+
+		for _, relay := range utils.Config.MevIndexer.Relays {
+			if builder.RelayCount[relay.Index] > 0 {
+				builderData.Relays = append(builderData.Relays, &models.MevBlocksPageDataRelay{
+					Index: uint64(relay.Index),
+					Name:  relay.Name,
+				})
+			}
+		}
+
+		pageData.Builders = append(pageData.Builders, builderData)
+	}
+
+	for _, relay := range analytics.Relays {
+		relayData := &models.MevAnalyticsPageDataRelay{
+			Index:          uint64(relay.Index),
+			Name:           relay.Name,
+			BlockCount:     relay.BlockCount,
+			ExclusiveCount: relay.ExclusiveCount,
+			TotalValue:     relay.TotalValue,
+			BuilderCount:   relay.BuilderCount,
+		}
+		if analytics.MevBlockCount > 0 {
+			relayData.Share = float64(relay.BlockCount) * 100 / float64(analytics.MevBlockCount)
+		}
+		if relay.BlockCount > 0 {
+			relayData.ExclusiveShare = float64(relay.ExclusiveCount) * 100 / float64(relay.BlockCount)
+			relayData.AvgValue = relay.TotalValue / relay.BlockCount
+		}
+
+		pageData.Relays = append(pageData.Relays, relayData)
+	}
+
+	for _, bucket := range analytics.Buckets {
+		bucketData := &models.MevAnalyticsPageDataBucket{
+			StartEpoch:    uint64(bucket.StartEpoch),
+			EndEpoch:      uint64(bucket.EndEpoch),
+			PayloadCount:  bucket.PayloadCount,
+			MevBlockCount: bucket.MevBlockCount,
+			MevValue:      bucket.MevValue,
+		}
+		if bucket.PayloadCount > 0 {
+			bucketData.MevShare = float64(bucket.MevBlockCount) * 100 / float64(bucket.PayloadCount)
+			bucketData.LocalShare = 100 - bucketData.MevShare
+		}
+
+		pageData.Buckets = append(pageData.Buckets, bucketData)
+	}
+
+	return pageData, cacheTime
+}
@@ -21,6 +21,7 @@ import (
 func MevBlocks(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"mev_blocks/mev_blocks.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// max number of individual faults shown on the relay faults page
+const mevFaultsMaxListed = 100
+
+// MevFaults will return the "mev_faults" page using a go template
+func MevFaults(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"mev_analytics/mev_faults.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/mev/faults", "MEV Relay Faults", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		startEpoch, endEpoch := parseMevAnalyticsArgs(r.URL.Query())
+		data.Data, pageError = getMevFaultsPageData(startEpoch, endEpoch)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "mev_faults.go", "MevFaults", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getMevFaultsPageData(startEpoch uint64, endEpoch uint64) (*models.MevFaultsPageData, error) {
+	pageData := &models.MevFaultsPageData{}
+	pageCacheKey := fmt.Sprintf("mev_faults:%v:%v", startEpoch, endEpoch)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildMevFaultsPageData(startEpoch, endEpoch)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.MevFaultsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildMevFaultsPageData(startEpoch uint64, endEpoch uint64) (*models.MevFaultsPageData, time.Duration) {
+	logrus.Debugf("mev faults page called: %v-%v", startEpoch, endEpoch)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	pageData := &models.MevFaultsPageData{
+		FilterStartEpoch: startEpoch,
+		FilterEndEpoch:   endEpoch,
+		MaxEpochRange:    mevAnalyticsMaxEpochRange,
+		Relays:           []*models.MevFaultsPageDataRelay{},
+		Faults:           []*models.MevFaultsPageDataFault{},
+	}
+
+	cacheTime := 5 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch)
+	}
+
+	report, err := services.GlobalBeaconService.GetMevRelayFaults(phase0.Epoch(startEpoch), phase0.Epoch(endEpoch))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, relay := range report.Relays {
+		relayData := &models.MevFaultsPageDataRelay{
+			Index:             uint64(relay.Index),
+			Name:              relay.Name,
+			DeliveredCount:    relay.DeliveredCount,
+			MissedSlotCount:   relay.MissedSlotCount,
+			ReplacedCount:     relay.ReplacedCount,
+			OrphanedCount:     relay.OrphanedCount,
+			FeeRecipientCount: relay.FeeRecipientCount,
+		}
+		relayData.FaultCount = relay.MissedSlotCount + relay.ReplacedCount + relay.OrphanedCount + relay.FeeRecipientCount
+		if relay.DeliveredCount > 0 {
+			relayData.FaultRate = float64(relayData.FaultCount) * 100 / float64(relay.DeliveredCount)
+		}
+
+		pageData.Relays = append(pageData.Relays, relayData)
+	}
+
+	pageData.FaultCount = uint64(len(report.Faults))
+	for idx, fault := range report.Faults {
+		if idx >= mevFaultsMaxListed {
+			pageData.MoreFaults = true
+			break
+		}
+
+		faultData := &models.MevFaultsPageDataFault{
+			SlotNumber:          uint64(fault.Slot),
+			BlockHash:           fault.BlockHash,
+			Proposer:            uint64(fault.Proposer),
+			ProposerName:        services.GlobalBeaconService.GetValidatorName(uint64(fault.Proposer)),
+			Relays:              []*models.MevBlocksPageDataRelay{},
+			FaultType:           uint8(fault.Type),
+			FaultName:           fault.Type.String(),
+			BlockValue:          fault.Value,
+			FeeRecipient:        fault.FeeRecipient,
+			PayloadFeeRecipient: fault.PayloadFeeRecipient,
+		}
+
+		for _, relay := range utils.Config.MevIndexer.Relays {
+			if fault.SeenbyRelays&(uint64(1)<<uint64(relay.Index)) > 0 {
+				faultData.Relays = append(faultData.Relays, &models.MevBlocksPageDataRelay{
+					Index: uint64(relay.Index),
+					Name:  relay.Name,
+				})
+			}
+		}
+
+		pageData.Faults = append(pageData.Faults, faultData)
+	}
+
+	return pageData, cacheTime
+}
@@ -98,6 +98,9 @@ func createMenuItems(active string) []types.MainMenuItem {
 	blockchainMenu := []types.NavigationGroup{}
 	validatorMenu := []types.NavigationGroup{}
 
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
 	blockchainMenu = append(blockchainMenu, types.NavigationGroup{
 		Links: []types.NavigationLink{
 			{
@@ -121,6 +124,24 @@ func createMenuItems(active string) []types.MainMenuItem {
 			},
 		},
 	})
+
+	analyticsLinks := []types.NavigationLink{
+		{
+			Label: "Gas",
+			Path:  "/gas",
+			Icon:  "fa-gas-pump",
+		},
+	}
+	if specs != nil && specs.DenebForkEpoch != nil && uint64(chainState.CurrentEpoch()) >= *specs.DenebForkEpoch {
+		analyticsLinks = append(analyticsLinks, types.NavigationLink{
+			Label: "Blobs",
+			Path:  "/blobs",
+			Icon:  "fa-database",
+		})
+	}
+	blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+		Links: analyticsLinks,
+	})
 	if len(utils.Config.MevIndexer.Relays) > 0 {
 		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
 			Links: []types.NavigationLink{
@@ -129,6 +150,32 @@ func createMenuItems(active string) []types.MainMenuItem {
 					Path:  "/mev/blocks",
 					Icon:  "fa-money-bill",
 				},
+				{
+					Label: "MEV Builders",
+					Path:  "/mev/builders",
+					Icon:  "fa-hammer",
+				},
+				{
+					Label: "MEV Relays",
+					Path:  "/mev/relays",
+					Icon:  "fa-tower-broadcast",
+				},
+				{
+					Label: "Relay Faults",
+					Path:  "/mev/faults",
+					Icon:  "fa-triangle-exclamation",
+				},
+			},
+		})
+	}
+	if utils.Config.Alerting.Enabled {
+		blockchainMenu = append(blockchainMenu, types.NavigationGroup{
+			Links: []types.NavigationLink{
+				{
+					Label: "Alerts",
+					Path:  "/alerts",
+					Icon:  "fa-bell",
+				},
 			},
 		})
 	}
@@ -149,6 +196,12 @@ func createMenuItems(active string) []types.MainMenuItem {
 		})
 	}
 
+	clientLinks = append(clientLinks, types.NavigationLink{
+		Label: "Diversity",
+		Path:  "/clients/diversity",
+		Icon:  "fa-chart-pie",
+	})
+
 	clientLinks = append(clientLinks, types.NavigationLink{
 		Label: "Forks",
 		Path:  "/forks",
@@ -171,6 +224,21 @@ func createMenuItems(active string) []types.MainMenuItem {
 				Path:  "/validators/activity",
 				Icon:  "fa-tachometer",
 			},
+			{
+				Label: "Validator Dashboard",
+				Path:  "/validators/dashboard",
+				Icon:  "fa-th-large",
+			},
+			{
+				Label: "Upcoming Duties",
+				Path:  "/validators/duties",
+				Icon:  "fa-calendar-alt",
+			},
+			{
+				Label: "Operator Leaderboard",
+				Path:  "/validators/operators",
+				Icon:  "fa-trophy",
+			},
 		},
 	})
 	validatorMenu = append(validatorMenu, types.NavigationGroup{
@@ -193,8 +261,6 @@ func createMenuItems(active string) []types.MainMenuItem {
 		},
 	})
 
-	chainState := services.GlobalBeaconService.GetChainState()
-	specs := chainState.GetSpecs()
 	if specs != nil && specs.ElectraForkEpoch != nil && uint64(chainState.CurrentEpoch()) >= *specs.ElectraForkEpoch {
 		validatorMenu = append(validatorMenu, types.NavigationGroup{
 			Links: []types.NavigationLink{
@@ -20,6 +20,7 @@ import (
 func Slashings(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"slashings/slashings.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -47,6 +47,7 @@ func Slot(w http.ResponseWriter, r *http.Request) {
 		"slot/deposit_requests.html",
 		"slot/withdrawal_requests.html",
 		"slot/consolidation_requests.html",
+		"slot/bids.html",
 	)
 	var notfoundTemplateFiles = append(layoutTemplateFiles,
 		"slot/notfound.html",
@@ -291,12 +292,88 @@ func buildSlotPageData(ctx context.Context, blockSlot int64, blockRoot []byte) (
 					ClassName:   "text-bg-warning",
 				})
 			}
+
+			if utils.Config.MevIndexer.BidTracesEnabled {
+				pageData.Block.Bids = getSlotPageBids(uint64(slot), pageData.Block.ExecutionData.BlockHash)
+				if pageData.Block.Bids != nil {
+					pageData.Block.BidsCount = uint64(len(pageData.Block.Bids.Bids))
+				}
+			}
 		}
 	}
 
 	return pageData, cacheTimeout
 }
 
+func getSlotPageBids(slot uint64, winningHash []byte) *models.SlotPageBids {
+	mevBids, err := db.GetMevBidsBySlot(slot)
+	if err != nil || len(mevBids) == 0 {
+		return nil
+	}
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	slotStartMs := chainState.SlotToTime(phase0.Slot(slot)).UnixMilli()
+
+	bidsData := &models.SlotPageBids{
+		Builders:   []string{},
+		Bids:       make([]*models.SlotPageBid, 0, len(mevBids)),
+		RelayNames: []string{},
+	}
+
+	builderIndexes := map[string]int{}
+	relayIndexes := map[uint8]int{}
+	relayNames := map[uint8]string{}
+	for _, relay := range utils.Config.MevIndexer.Relays {
+		relayNames[relay.Index] = relay.Name
+	}
+
+	for idx, mevBid := range mevBids {
+		builderKey := fmt.Sprintf("0x%x", mevBid.BuilderPubkey)
+		builderIdx, found := builderIndexes[builderKey]
+		if !found {
+			builderIdx = len(bidsData.Builders)
+			builderIndexes[builderKey] = builderIdx
+			bidsData.Builders = append(bidsData.Builders, builderKey)
+		}
+
+		relayIdx, found := relayIndexes[mevBid.RelayId]
+		if !found {
+			relayIdx = len(bidsData.RelayNames)
+			relayIndexes[mevBid.RelayId] = relayIdx
+			relayName := relayNames[mevBid.RelayId]
+			if relayName == "" {
+				relayName = fmt.Sprintf("Relay %v", mevBid.RelayId)
+			}
+			bidsData.RelayNames = append(bidsData.RelayNames, relayName)
+		}
+
+		bidData := &models.SlotPageBid{
+			Builder:   builderIdx,
+			Relay:     relayIdx,
+			Value:     mevBid.ValueGwei,
+			Delay:     mevBid.TimestampMs - slotStartMs,
+			IsWinning: bytes.Equal(mevBid.BlockHash, winningHash),
+		}
+		bidsData.Bids = append(bidsData.Bids, bidData)
+
+		if idx == 0 {
+			bidsData.FirstBidDelay = bidData.Delay
+		}
+		bidsData.LastBidDelay = bidData.Delay
+		bidsData.HighestValue = max(bidsData.HighestValue, bidData.Value)
+
+		if bidData.IsWinning && (!bidsData.HasWinningBid || bidData.Delay < bidsData.WinningDelay) {
+			bidsData.HasWinningBid = true
+			bidsData.WinningValue = bidData.Value
+			bidsData.WinningDelay = bidData.Delay
+			bidsData.WinningBuilder = mevBid.BuilderPubkey
+		}
+	}
+	bidsData.BuilderCount = uint64(len(bidsData.Builders))
+
+	return bidsData
+}
+
 func getSlotPageBlockData(blockData *services.CombinedBlockResponse, epochStatsValues *beacon.EpochStatsValues) *models.SlotPageBlockData {
 	chainState := services.GlobalBeaconService.GetChainState()
 	specs := chainState.GetSpecs()
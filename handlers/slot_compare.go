@@ -0,0 +1,423 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/mux"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// SlotCompare will return the "slot_compare" page using a go template
+func SlotCompare(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"slot/compare.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "blockchain", "/slots", "Compare Blocks", templateFiles)
+
+	vars := mux.Vars(r)
+	slot, err := strconv.ParseUint(vars["slot"], 10, 64)
+	if err != nil {
+		handlePageError(w, r, errors.New("invalid slot number"))
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	leftRoot := parseSlotCompareRoot(urlArgs.Get("a"))
+	rightRoot := parseSlotCompareRoot(urlArgs.Get("b"))
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getSlotComparePageData(r.Context(), slot, leftRoot, rightRoot)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "slot_compare.go", "SlotCompare", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func parseSlotCompareRoot(rootStr string) []byte {
+	root, err := hex.DecodeString(strings.Replace(rootStr, "0x", "", -1))
+	if err != nil || len(root) != 32 {
+		return nil
+	}
+	return root
+}
+
+func getSlotComparePageData(ctx context.Context, slot uint64, leftRoot []byte, rightRoot []byte) (*models.SlotComparePageData, error) {
+	pageData := &models.SlotComparePageData{}
+	pageCacheKey := fmt.Sprintf("slot_compare:%v:%x:%x", slot, leftRoot, rightRoot)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildSlotComparePageData(ctx, slot, leftRoot, rightRoot)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.SlotComparePageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildSlotComparePageData(ctx context.Context, slot uint64, leftRoot []byte, rightRoot []byte) (*models.SlotComparePageData, time.Duration) {
+	logrus.Debugf("slot compare page called: %v", slot)
+	chainState := services.GlobalBeaconService.GetChainState()
+
+	pageData := &models.SlotComparePageData{
+		Slot:   slot,
+		Epoch:  uint64(chainState.EpochOfSlot(phase0.Slot(slot))),
+		Ts:     chainState.SlotToTime(phase0.Slot(slot)),
+		Blocks: []*models.SlotCompareBlockInfo{},
+	}
+
+	blockVariants, err := services.GlobalBeaconService.GetSlotBlockVariants(ctx, phase0.Slot(slot))
+	if err != nil {
+		panic(err)
+	}
+
+	for _, blockData := range blockVariants {
+		pageData.Blocks = append(pageData.Blocks, buildSlotCompareBlockInfo(blockData, pageData.Ts))
+	}
+	pageData.BlockCount = uint64(len(pageData.Blocks))
+
+	cacheTimeout := 5 * time.Minute
+	if len(blockVariants) < 2 {
+		cacheTimeout = 30 * time.Second
+		return pageData, cacheTimeout
+	}
+
+	// select the blocks to compare, defaults to the canonical block vs. the first orphaned block
+	leftIdx, rightIdx := 0, 1
+	for idx, block := range pageData.Blocks {
+		if leftRoot != nil && bytes.Equal(block.Root, leftRoot) {
+			leftIdx = idx
+		}
+		if rightRoot != nil && bytes.Equal(block.Root, rightRoot) {
+			rightIdx = idx
+		}
+	}
+	if leftIdx == rightIdx {
+		rightIdx = (leftIdx + 1) % len(pageData.Blocks)
+	}
+
+	left := blockVariants[leftIdx]
+	right := blockVariants[rightIdx]
+	pageData.Left = pageData.Blocks[leftIdx]
+	pageData.Right = pageData.Blocks[rightIdx]
+
+	pageData.Fields = buildSlotCompareFields(left, right, pageData.Left, pageData.Right)
+	pageData.Operations = buildSlotCompareOperations(left.Block, right.Block)
+	buildSlotCompareTransactions(pageData, left.Block, right.Block)
+
+	return pageData, cacheTimeout
+}
+
+func buildSlotCompareBlockInfo(blockData *services.CombinedBlockResponse, slotTime time.Time) *models.SlotCompareBlockInfo {
+	blockInfo := &models.SlotCompareBlockInfo{
+		Root:     blockData.Root[:],
+		Orphaned: blockData.Orphaned,
+		Proposer: uint64(blockData.Header.Message.ProposerIndex),
+	}
+	blockInfo.ProposerName = services.GlobalBeaconService.GetValidatorName(blockInfo.Proposer)
+
+	if !blockData.RecvTime.IsZero() {
+		blockInfo.HasRecvTime = true
+		blockInfo.RecvTime = blockData.RecvTime
+		blockInfo.RecvDelay = blockData.RecvTime.Sub(slotTime).Milliseconds()
+	}
+
+	return blockInfo
+}
+
+func buildSlotCompareFields(left, right *services.CombinedBlockResponse, leftInfo, rightInfo *models.SlotCompareBlockInfo) []*models.SlotCompareField {
+	fields := []*models.SlotCompareField{}
+	addField := func(name string, leftValue string, rightValue string) {
+		fields = append(fields, &models.SlotCompareField{
+			Name:    name,
+			Left:    leftValue,
+			Right:   rightValue,
+			Differs: leftValue != rightValue,
+		})
+	}
+
+	formatRecvDelay := func(blockInfo *models.SlotCompareBlockInfo) string {
+		if !blockInfo.HasRecvTime {
+			return "unknown"
+		}
+		return fmt.Sprintf("%v ms", blockInfo.RecvDelay)
+	}
+
+	addField("Status", slotCompareStatus(left), slotCompareStatus(right))
+	addField("Arrival (after slot start)", formatRecvDelay(leftInfo), formatRecvDelay(rightInfo))
+	addField("Parent Root", fmt.Sprintf("0x%x", left.Header.Message.ParentRoot[:]), fmt.Sprintf("0x%x", right.Header.Message.ParentRoot[:]))
+	addField("State Root", fmt.Sprintf("0x%x", left.Header.Message.StateRoot[:]), fmt.Sprintf("0x%x", right.Header.Message.StateRoot[:]))
+	addField("Proposer", fmt.Sprintf("%v", left.Header.Message.ProposerIndex), fmt.Sprintf("%v", right.Header.Message.ProposerIndex))
+
+	leftGraffiti, _ := left.Block.Graffiti()
+	rightGraffiti, _ := right.Block.Graffiti()
+	addField("Graffiti", utils.GraffitiToString(leftGraffiti[:]), utils.GraffitiToString(rightGraffiti[:]))
+
+	formatEth1Data := func(block *spec.VersionedSignedBeaconBlock) string {
+		eth1Data, err := block.ETH1Data()
+		if err != nil || eth1Data == nil {
+			return "-"
+		}
+		return fmt.Sprintf("0x%x (%v deposits)", eth1Data.BlockHash, eth1Data.DepositCount)
+	}
+	addField("Eth1 Data", formatEth1Data(left.Block), formatEth1Data(right.Block))
+
+	formatSyncAggregate := func(block *spec.VersionedSignedBeaconBlock) string {
+		syncAggregate, err := block.SyncAggregate()
+		if err != nil || syncAggregate == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%v / %v", syncAggregate.SyncCommitteeBits.Count(), syncAggregate.SyncCommitteeBits.Len())
+	}
+	addField("Sync Participation", formatSyncAggregate(left.Block), formatSyncAggregate(right.Block))
+
+	formatExecHash := func(block *spec.VersionedSignedBeaconBlock) string {
+		blockHash, err := block.ExecutionBlockHash()
+		if err != nil {
+			return "-"
+		}
+		return fmt.Sprintf("0x%x", blockHash[:])
+	}
+	addField("Execution Block Hash", formatExecHash(left.Block), formatExecHash(right.Block))
+
+	formatExecNumber := func(block *spec.VersionedSignedBeaconBlock) string {
+		blockNumber, err := block.ExecutionBlockNumber()
+		if err != nil {
+			return "-"
+		}
+		return fmt.Sprintf("%v", blockNumber)
+	}
+	addField("Execution Block Number", formatExecNumber(left.Block), formatExecNumber(right.Block))
+
+	formatBlobCount := func(block *spec.VersionedSignedBeaconBlock) string {
+		commitments, err := block.BlobKZGCommitments()
+		if err != nil {
+			return "-"
+		}
+		return fmt.Sprintf("%v", len(commitments))
+	}
+	addField("Blobs", formatBlobCount(left.Block), formatBlobCount(right.Block))
+
+	return fields
+}
+
+func slotCompareStatus(blockData *services.CombinedBlockResponse) string {
+	if blockData.Orphaned {
+		return "Orphaned"
+	}
+	return "Canonical"
+}
+
+// slotCompareRoots collects the identifying roots of a list of block operations
+func slotCompareRoots[T any](items []T, getRoot func(item T) ([32]byte, error)) [][32]byte {
+	roots := make([][32]byte, 0, len(items))
+	for _, item := range items {
+		root, err := getRoot(item)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, root)
+	}
+	return roots
+}
+
+func buildSlotCompareOperations(left, right *spec.VersionedSignedBeaconBlock) []*models.SlotCompareOperations {
+	operations := []*models.SlotCompareOperations{}
+	addOperations := func(name string, getRoots func(block *spec.VersionedSignedBeaconBlock) [][32]byte) {
+		leftRoots := getRoots(left)
+		rightRoots := getRoots(right)
+		if len(leftRoots) == 0 && len(rightRoots) == 0 {
+			return
+		}
+
+		rightSet := make(map[[32]byte]bool, len(rightRoots))
+		for _, root := range rightRoots {
+			rightSet[root] = true
+		}
+
+		opsData := &models.SlotCompareOperations{
+			Name:       name,
+			LeftCount:  uint64(len(leftRoots)),
+			RightCount: uint64(len(rightRoots)),
+		}
+		for _, root := range leftRoots {
+			if rightSet[root] {
+				opsData.Common++
+			} else {
+				opsData.OnlyLeft++
+			}
+		}
+		opsData.OnlyRight = opsData.RightCount - opsData.Common
+		operations = append(operations, opsData)
+	}
+
+	addOperations("Attestations", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		attestations, _ := block.Attestations()
+		return slotCompareRoots(attestations, func(att *spec.VersionedAttestation) ([32]byte, error) {
+			return att.HashTreeRoot()
+		})
+	})
+	addOperations("Deposits", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		deposits, _ := block.Deposits()
+		return slotCompareRoots(deposits, func(deposit *phase0.Deposit) ([32]byte, error) {
+			return deposit.HashTreeRoot()
+		})
+	})
+	addOperations("Voluntary Exits", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		exits, _ := block.VoluntaryExits()
+		return slotCompareRoots(exits, func(exit *phase0.SignedVoluntaryExit) ([32]byte, error) {
+			return exit.HashTreeRoot()
+		})
+	})
+	addOperations("Proposer Slashings", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		slashings, _ := block.ProposerSlashings()
+		return slotCompareRoots(slashings, func(slashing *phase0.ProposerSlashing) ([32]byte, error) {
+			return slashing.HashTreeRoot()
+		})
+	})
+	addOperations("Attester Slashings", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		slashings, _ := block.AttesterSlashings()
+		return slotCompareRoots(slashings, func(slashing spec.VersionedAttesterSlashing) ([32]byte, error) {
+			return sha256.Sum256([]byte(slashing.String())), nil
+		})
+	})
+	addOperations("BLS Changes", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		blsChanges, _ := block.BLSToExecutionChanges()
+		return slotCompareRoots(blsChanges, func(blsChange *capella.SignedBLSToExecutionChange) ([32]byte, error) {
+			return blsChange.HashTreeRoot()
+		})
+	})
+	addOperations("Withdrawals", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		withdrawals, _ := block.Withdrawals()
+		return slotCompareRoots(withdrawals, func(withdrawal *capella.Withdrawal) ([32]byte, error) {
+			return withdrawal.HashTreeRoot()
+		})
+	})
+	addOperations("Deposit Requests", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		requests, err := block.ExecutionRequests()
+		if err != nil || requests == nil {
+			return nil
+		}
+		return slotCompareRoots(requests.Deposits, func(request *electra.DepositRequest) ([32]byte, error) {
+			return request.HashTreeRoot()
+		})
+	})
+	addOperations("Withdrawal Requests", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		requests, err := block.ExecutionRequests()
+		if err != nil || requests == nil {
+			return nil
+		}
+		return slotCompareRoots(requests.Withdrawals, func(request *electra.WithdrawalRequest) ([32]byte, error) {
+			return request.HashTreeRoot()
+		})
+	})
+	addOperations("Consolidation Requests", func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		requests, err := block.ExecutionRequests()
+		if err != nil || requests == nil {
+			return nil
+		}
+		return slotCompareRoots(requests.Consolidations, func(request *electra.ConsolidationRequest) ([32]byte, error) {
+			return request.HashTreeRoot()
+		})
+	})
+
+	return operations
+}
+
+func buildSlotCompareTransactions(pageData *models.SlotComparePageData, left, right *spec.VersionedSignedBeaconBlock) {
+	getTxHashes := func(block *spec.VersionedSignedBeaconBlock) [][32]byte {
+		transactions, err := block.ExecutionTransactions()
+		if err != nil {
+			return nil
+		}
+
+		hashes := make([][32]byte, 0, len(transactions))
+		for _, txBytes := range transactions {
+			var tx ethtypes.Transaction
+			if err := tx.UnmarshalBinary(txBytes); err != nil {
+				continue
+			}
+			hashes = append(hashes, tx.Hash())
+		}
+		return hashes
+	}
+
+	leftHashes := getTxHashes(left)
+	rightHashes := getTxHashes(right)
+
+	leftIndex := make(map[[32]byte]int, len(leftHashes))
+	for idx, hash := range leftHashes {
+		leftIndex[hash] = idx
+	}
+	rightIndex := make(map[[32]byte]int, len(rightHashes))
+	for idx, hash := range rightHashes {
+		rightIndex[hash] = idx
+	}
+
+	pageData.TransactionsOnlyLeft = []*models.SlotCompareTransaction{}
+	pageData.TransactionsOnlyRight = []*models.SlotCompareTransaction{}
+
+	commonLeft := []int{}
+	for idx, hash := range leftHashes {
+		if rightIdx, found := rightIndex[hash]; found {
+			pageData.TransactionsCommon++
+			commonLeft = append(commonLeft, rightIdx)
+			continue
+		}
+		pageData.TransactionsOnlyLeft = append(pageData.TransactionsOnlyLeft, &models.SlotCompareTransaction{
+			Index: uint64(idx),
+			Hash:  hash[:],
+		})
+	}
+	for idx, hash := range rightHashes {
+		if _, found := leftIndex[hash]; found {
+			continue
+		}
+		pageData.TransactionsOnlyRight = append(pageData.TransactionsOnlyRight, &models.SlotCompareTransaction{
+			Index: uint64(idx),
+			Hash:  hash[:],
+		})
+	}
+
+	// check if the common transactions are included in the same relative order
+	for i := 1; i < len(commonLeft); i++ {
+		if commonLeft[i] < commonLeft[i-1] {
+			pageData.TransactionsOrderDiff = true
+			break
+		}
+	}
+}
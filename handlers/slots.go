@@ -175,6 +175,21 @@ func buildSlotsPageData(firstSlot uint64, pageSize uint64) (*models.SlotsPageDat
 		}
 	}
 	pageData.SlotCount = uint64(blockCount)
+
+	// classify missed slots
+	missedSlots := []services.MissedSlotRef{}
+	for _, slotData := range pageData.Slots {
+		if slotData.Status == uint8(dbtypes.Missing) && !slotData.Scheduled && slotData.Synchronized && slotData.Slot > 0 {
+			missedSlots = append(missedSlots, services.MissedSlotRef{Slot: phase0.Slot(slotData.Slot), Proposer: phase0.ValidatorIndex(slotData.Proposer)})
+		}
+	}
+	missedCauses := services.GlobalBeaconService.ClassifyMissedSlots(missedSlots)
+	for _, slotData := range pageData.Slots {
+		if cause, ok := missedCauses[phase0.Slot(slotData.Slot)]; ok && slotData.Status == uint8(dbtypes.Missing) {
+			slotData.MissedCause = cause.String()
+			slotData.MissedCauseInfo = cause.Description()
+		}
+	}
 	pageData.FirstSlot = firstSlot
 	pageData.LastSlot = lastSlot
 	pageData.ForkTreeWidth = (maxOpenFork * 20) + 20
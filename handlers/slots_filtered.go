@@ -20,6 +20,7 @@ import (
 func SlotsFiltered(w http.ResponseWriter, r *http.Request) {
 	var slotsTemplateFiles = append(layoutTemplateFiles,
 		"slots_filtered/slots_filtered.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -269,6 +270,21 @@ func buildFilteredSlotsPageData(pageIdx uint64, pageSize uint64, graffiti string
 		pageData.Slots = append(pageData.Slots, slotData)
 	}
 	pageData.SlotCount = uint64(len(pageData.Slots))
+
+	// classify missed slots
+	missedSlots := []services.MissedSlotRef{}
+	for _, slotData := range pageData.Slots {
+		if slotData.Status == uint8(dbtypes.Missing) && !slotData.Scheduled && slotData.Slot > 0 {
+			missedSlots = append(missedSlots, services.MissedSlotRef{Slot: phase0.Slot(slotData.Slot), Proposer: phase0.ValidatorIndex(slotData.Proposer)})
+		}
+	}
+	missedCauses := services.GlobalBeaconService.ClassifyMissedSlots(missedSlots)
+	for _, slotData := range pageData.Slots {
+		if cause, ok := missedCauses[phase0.Slot(slotData.Slot)]; ok && slotData.Status == uint8(dbtypes.Missing) {
+			slotData.MissedCause = cause.String()
+			slotData.MissedCauseInfo = cause.Description()
+		}
+	}
 	if pageData.SlotCount > 0 {
 		pageData.FirstSlot = pageData.Slots[0].Slot
 		pageData.LastSlot = pageData.Slots[pageData.SlotCount-1].Slot
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"bytes"
 	"encoding/hex"
 	"fmt"
 	"math"
@@ -21,6 +22,7 @@ import (
 	"github.com/ethpandaops/dora/services"
 	"github.com/ethpandaops/dora/templates"
 	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
 )
 
 // Validator will return the main "validator" page using a go template
@@ -32,6 +34,8 @@ func Validator(w http.ResponseWriter, r *http.Request) {
 		"validator/recentDeposits.html",
 		"validator/withdrawalRequests.html",
 		"validator/consolidationRequests.html",
+		"validator/mevRegistrations.html",
+		"validator/revenue.html",
 		"validator/txDetails.html",
 		"_svg/timeline.html",
 	)
@@ -174,10 +178,19 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		pageData.ShowWithdrawAddress = true
 		pageData.WithdrawAddress = validator.Validator.WithdrawalCredentials[12:]
 	}
+	if utils.Config.MevIndexer.RegistrationsEnabled && len(utils.Config.MevIndexer.Relays) > 0 {
+		pageData.ShowMevRegistrations = true
+	}
 
 	// load latest blocks
 	if pageData.TabView == "blocks" {
 		pageData.RecentBlocks = make([]*models.ValidatorPageDataBlock, 0)
+
+		var mevRegistrations []*dbtypes.MevValidatorRegistration
+		if pageData.ShowMevRegistrations {
+			mevRegistrations, _ = db.GetMevValidatorRegistrations(validatorIndex, 100)
+		}
+
 		blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
 			ProposerIndex: &validatorIndex,
 			WithOrphaned:  1,
@@ -203,6 +216,16 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 					blockEntry.WithEthBlock = true
 					blockEntry.EthBlock = *blockData.Block.EthBlockNumber
 				}
+
+				// flag proposals that did not pay the fee recipient registered with the relays
+				if blockData.Block.EthFeeRecipient != nil && len(mevRegistrations) > 0 {
+					blockEntry.FeeRecipient = blockData.Block.EthFeeRecipient
+					registeredRecipient := getValidatorRegisteredFeeRecipient(mevRegistrations, blockEntry.Ts)
+					if registeredRecipient != nil {
+						blockEntry.RegisteredRecipient = registeredRecipient
+						blockEntry.FeeRecipientMismatch = !bytes.Equal(registeredRecipient, blockData.Block.EthFeeRecipient) && !bytes.Equal(registeredRecipient, blockData.Block.EthPaymentRecipient)
+					}
+				}
 			}
 			pageData.RecentBlocks = append(pageData.RecentBlocks, &blockEntry)
 		}
@@ -597,6 +620,80 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 		pageData.ConsolidationRequestCount = uint64(len(pageData.ConsolidationRequests))
 	}
 
+	// load block revenues
+	if pageData.TabView == "revenue" {
+		pageData.RevenueBlocks = make([]*models.ValidatorPageDataRevenue, 0)
+
+		slotRevenues, _ := db.GetSlotRevenuesByProposer(validatorIndex, 0, 50)
+		for _, slotRevenue := range slotRevenues {
+			revenueData := &models.ValidatorPageDataRevenue{
+				Slot:             slotRevenue.Slot,
+				Ts:               chainState.SlotToTime(phase0.Slot(slotRevenue.Slot)),
+				FeeRecipient:     slotRevenue.EthFeeRecipient,
+				PaymentRecipient: slotRevenue.EthPaymentRecipient,
+			}
+			if slotRevenue.EthBlockNumber != nil {
+				revenueData.BlockNumber = *slotRevenue.EthBlockNumber
+			}
+			if slotRevenue.EthPriorityFees != nil {
+				revenueData.HasPriorityFees = true
+				revenueData.PriorityFees = *slotRevenue.EthPriorityFees
+			}
+			if slotRevenue.EthPaymentValue != nil {
+				revenueData.HasPayment = true
+				revenueData.PaymentValue = *slotRevenue.EthPaymentValue
+			}
+			if slotRevenue.EthBurntFees != nil {
+				revenueData.BurntFees = *slotRevenue.EthBurntFees
+				pageData.RevenueBurntTotal += revenueData.BurntFees
+			}
+
+			revenue, revenueSource := services.GetBlockProposerRevenue(slotRevenue)
+			revenueData.RevenueKnown = revenueSource != services.BlockRevenueUnknown
+			revenueData.Revenue = revenue
+			revenueData.RevenueSource = revenueSource.String()
+			pageData.RevenueTotal += revenue
+
+			pageData.RevenueBlocks = append(pageData.RevenueBlocks, revenueData)
+		}
+		pageData.RevenueBlockCount = uint64(len(pageData.RevenueBlocks))
+	}
+
+	// load relay registrations
+	if pageData.TabView == "registrations" && pageData.ShowMevRegistrations {
+		pageData.MevRegistrations = make([]*models.ValidatorPageDataMevRegistration, 0)
+		pageData.MevRegistrationHistory = make([]*models.ValidatorPageDataMevRegistration, 0)
+
+		relayNames := map[uint8]string{}
+		for _, relay := range utils.Config.MevIndexer.Relays {
+			relayNames[relay.Index] = relay.Name
+		}
+
+		mevRegistrations, _ := db.GetMevValidatorRegistrations(validatorIndex, 50)
+		latestRegistrations := map[uint8]bool{}
+		for _, registration := range mevRegistrations {
+			registrationData := &models.ValidatorPageDataMevRegistration{
+				RelayIndex:   uint64(registration.RelayId),
+				RelayName:    relayNames[registration.RelayId],
+				FeeRecipient: registration.FeeRecipient,
+				GasLimit:     registration.GasLimit,
+				Time:         time.Unix(int64(registration.Timestamp), 0),
+				FirstSeen:    time.Unix(int64(registration.FirstSeen), 0),
+			}
+
+			// registrations are sorted by time desc, so the first entry per relay is the current one
+			if !latestRegistrations[registration.RelayId] {
+				latestRegistrations[registration.RelayId] = true
+				pageData.MevRegistrations = append(pageData.MevRegistrations, registrationData)
+			}
+
+			pageData.MevRegistrationHistory = append(pageData.MevRegistrationHistory, registrationData)
+		}
+
+		pageData.MevRegistrationCount = uint64(len(pageData.MevRegistrations))
+		pageData.MevRegistrationHistoryCount = uint64(len(pageData.MevRegistrationHistory))
+	}
+
 	// Check for exit reason if validator is exiting or has exited
 	if pageData.ShowExit {
 		zeroAmount := uint64(0)
@@ -680,3 +777,14 @@ func buildValidatorPageData(validatorIndex uint64, tabView string) (*models.Vali
 
 	return pageData, 10 * time.Minute
 }
+
+// getValidatorRegisteredFeeRecipient returns the fee recipient of the newest relay registration that was valid at the given time.
+func getValidatorRegisteredFeeRecipient(registrations []*dbtypes.MevValidatorRegistration, blockTime time.Time) []byte {
+	for _, registration := range registrations {
+		if registration.Timestamp <= uint64(blockTime.Unix()) {
+			return registration.FeeRecipient
+		}
+	}
+
+	return nil
+}
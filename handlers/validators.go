@@ -12,6 +12,7 @@ import (
 	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
 
 	"github.com/ethpandaops/dora/dbtypes"
 	"github.com/ethpandaops/dora/services"
@@ -24,6 +25,7 @@ import (
 func Validators(w http.ResponseWriter, r *http.Request) {
 	var validatorsTemplateFiles = append(layoutTemplateFiles,
 		"validators/validators.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
@@ -49,6 +51,7 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	var filterIndex string
 	var filterName string
 	var filterStatus string
+	var filterEpoch string
 	if urlArgs.Has("f") {
 		if urlArgs.Has("f.pubkey") {
 			filterPubKey = urlArgs.Get("f.pubkey")
@@ -62,6 +65,9 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 		if urlArgs.Has("f.status") {
 			filterStatus = strings.Join(urlArgs["f.status"], ",")
 		}
+		if urlArgs.Has("f.epoch") {
+			filterEpoch = urlArgs.Get("f.epoch")
+		}
 	}
 	var sortOrder string
 	if urlArgs.Has("o") {
@@ -71,7 +77,7 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	var pageError error
 	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 1)
 	if pageError == nil {
-		data.Data, pageError = getValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		data.Data, pageError = getValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterEpoch)
 	}
 	if pageError != nil {
 		handlePageError(w, r, pageError)
@@ -93,11 +99,11 @@ func Validators(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, error) {
+func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string, filterEpoch string) (*models.ValidatorsPageData, error) {
 	pageData := &models.ValidatorsPageData{}
-	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+	pageCacheKey := fmt.Sprintf("validators:%v:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterEpoch)
 	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
-		pageData, cacheTimeout := buildValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+		pageData, cacheTimeout := buildValidatorsPageData(pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterEpoch)
 		pageCall.CacheTimeout = cacheTimeout
 		return pageData
 	})
@@ -111,8 +117,8 @@ func getValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string,
 	return pageData, pageErr
 }
 
-func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string) (*models.ValidatorsPageData, time.Duration) {
-	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus)
+func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder string, filterPubKey string, filterIndex string, filterName string, filterStatus string, filterEpoch string) (*models.ValidatorsPageData, time.Duration) {
+	logrus.Debugf("validators page called: %v:%v:%v:%v:%v:%v:%v:%v", pageNumber, pageSize, sortOrder, filterPubKey, filterIndex, filterName, filterStatus, filterEpoch)
 	pageData := &models.ValidatorsPageData{}
 	cacheTime := 10 * time.Minute
 
@@ -124,7 +130,7 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 	}
 
 	filterArgs := url.Values{}
-	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" {
+	if filterPubKey != "" || filterIndex != "" || filterName != "" || filterStatus != "" || filterEpoch != "" {
 		if filterPubKey != "" {
 			pageData.FilterPubKey = filterPubKey
 			filterArgs.Add("f.pubkey", filterPubKey)
@@ -156,6 +162,24 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 				}
 			}
 		}
+		if filterEpoch != "" {
+			filterEpochVal, err := strconv.ParseUint(filterEpoch, 10, 64)
+			if err == nil && filterEpochVal <= uint64(chainState.CurrentEpoch()) {
+				pageData.FilterEpoch = filterEpoch
+				filterArgs.Add("f.epoch", filterEpoch)
+
+				// the validator history does not cover epochs before the history start, fall back to the latest validator set
+				if services.GlobalBeaconService.IsValidatorHistoryAvailable(phase0.Epoch(filterEpochVal)) {
+					validatorFilter.AsOfEpoch = &filterEpochVal
+				} else {
+					pageData.FilterEpochUnavailable = true
+					if startEpoch, found := services.GlobalBeaconService.GetValidatorHistoryStartEpoch(); found {
+						pageData.HistoryStartEpoch = uint64(startEpoch)
+						pageData.HasHistoryStart = true
+					}
+				}
+			}
+		}
 	}
 
 	// apply sort order
@@ -196,7 +220,12 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 	}
 
 	// get status options
-	statusMap := services.GlobalBeaconService.GetValidatorStatusMap()
+	var statusMap map[v1.ValidatorState]uint64
+	if validatorFilter.AsOfEpoch != nil {
+		statusMap = services.GlobalBeaconService.GetValidatorStatusMapAtEpoch(phase0.Epoch(*validatorFilter.AsOfEpoch))
+	} else {
+		statusMap = services.GlobalBeaconService.GetValidatorStatusMap()
+	}
 	pageData.FilterStatusOpts = make([]models.ValidatorsPageDataStatusOption, 0)
 	for status, count := range statusMap {
 		pageData.FilterStatusOpts = append(pageData.FilterStatusOpts, models.ValidatorsPageDataStatusOption{
@@ -269,6 +298,10 @@ func buildValidatorsPageData(pageNumber uint64, pageSize uint64, sortOrder strin
 			validatorData.State = validator.Status.String()
 		}
 
+		if validatorData.ShowUpcheck && validatorFilter.AsOfEpoch != nil {
+			// liveness is only tracked for recent epochs
+			validatorData.ShowUpcheck = false
+		}
 		if validatorData.ShowUpcheck {
 			validatorData.UpcheckActivity = uint8(services.GlobalBeaconService.GetValidatorLiveness(validator.Index, 3))
 			validatorData.UpcheckMaximum = uint8(3)
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// maximum number of validators that can be included in a dashboard
+const validatorsDashboardMaxValidators = 10000
+
+// maximum number of validators shown in the dashboard validator table
+const validatorsDashboardMaxShownValidators = 100
+
+// maximum length (in characters) of a saved dashboard name
+const validatorsDashboardMaxNameLength = 100
+
+// maximum size (in bytes) of the encoded filter arguments of a saved dashboard (fits validatorsDashboardMaxValidators pubkeys)
+const validatorsDashboardMaxFilterArgsSize = 2 * 1024 * 1024
+
+type validatorsDashboardArgs struct {
+	validators string
+	waddr      string
+	vname      string
+}
+
+// ValidatorsDashboard will return the "validators_dashboard" page for a user-defined validator set using a go template
+func ValidatorsDashboard(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validators_dashboard/validators_dashboard.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/dashboard", "Validator Dashboard", templateFiles)
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	urlArgs := r.URL.Query()
+	var dashboardKey, dashboardName string
+	if urlArgs.Has("d") {
+		dashboard := db.GetValidatorDashboard(urlArgs.Get("d"))
+		if dashboard == nil {
+			handlePageError(w, r, errors.New("dashboard not found"))
+			return
+		}
+
+		savedArgs, err := url.ParseQuery(dashboard.FilterArgs)
+		if err != nil {
+			handlePageError(w, r, fmt.Errorf("invalid dashboard filter: %v", err))
+			return
+		}
+
+		dashboardKey = dashboard.DashboardKey
+		dashboardName = dashboard.Name
+		urlArgs = savedArgs
+		updateValidatorDashboardAccess(dashboard)
+	}
+
+	dashboardArgs := parseValidatorsDashboardArgs(urlArgs)
+
+	data.Data, pageError = getValidatorsDashboardPageData(dashboardArgs, dashboardKey, dashboardName)
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		dashboardDataBytes, err := json.Marshal(data.Data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(dashboardDataBytes)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error writing response: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_dashboard.go", "ValidatorsDashboard", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsDashboardSave stores a validator dashboard server-side and redirects to the saved dashboard
+func ValidatorsDashboardSave(w http.ResponseWriter, r *http.Request) {
+	if !utils.Config.Frontend.AllowSavedDashboards {
+		handlePageError(w, r, errors.New("saving dashboards is not enabled"))
+		return
+	}
+
+	err := services.GlobalCallRateLimiter.CheckCallLimit(r, 10)
+	if err != nil {
+		handlePageError(w, r, err)
+		return
+	}
+
+	err = r.ParseForm()
+	if err != nil {
+		handlePageError(w, r, fmt.Errorf("invalid form data: %v", err))
+		return
+	}
+
+	dashboardArgs := parseValidatorsDashboardArgs(r.PostForm)
+	if dashboardArgs.validators == "" && dashboardArgs.waddr == "" && dashboardArgs.vname == "" {
+		handlePageError(w, r, errors.New("empty validator set"))
+		return
+	}
+
+	dashboardName := []rune(strings.TrimSpace(r.PostForm.Get("name")))
+	if len(dashboardName) > validatorsDashboardMaxNameLength {
+		dashboardName = dashboardName[:validatorsDashboardMaxNameLength]
+	}
+
+	filterArgs := dashboardArgs.filterArgs().Encode()
+	if len(filterArgs) > validatorsDashboardMaxFilterArgsSize {
+		handlePageError(w, r, fmt.Errorf("validator set too large (max %v bytes)", validatorsDashboardMaxFilterArgsSize))
+		return
+	}
+
+	keyBytes := make([]byte, 8)
+	if _, err := rand.Read(keyBytes); err != nil {
+		handlePageError(w, r, fmt.Errorf("error generating dashboard key: %v", err))
+		return
+	}
+
+	now := time.Now().Unix()
+	dashboard := &dbtypes.ValidatorDashboard{
+		DashboardKey: hex.EncodeToString(keyBytes),
+		Name:         string(dashboardName),
+		FilterArgs:   filterArgs,
+		CreatedAt:    now,
+		LastAccess:   now,
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertValidatorDashboard(dashboard, tx)
+	})
+	if err != nil {
+		logrus.Warnf("error saving validator dashboard: %v", err)
+		handlePageError(w, r, errors.New("error saving dashboard"))
+		return
+	}
+
+	http.Redirect(w, r, "/validators/dashboard?d="+dashboard.DashboardKey, http.StatusSeeOther)
+}
+
+func parseValidatorsDashboardArgs(urlArgs url.Values) *validatorsDashboardArgs {
+	args := &validatorsDashboardArgs{}
+	if urlArgs.Has("f") {
+		args.validators = urlArgs.Get("f.validators")
+		args.waddr = strings.TrimSpace(urlArgs.Get("f.waddr"))
+		args.vname = urlArgs.Get("f.vname")
+	}
+	return args
+}
+
+func (args *validatorsDashboardArgs) filterArgs() url.Values {
+	filterArgs := url.Values{}
+	filterArgs.Add("f", "")
+	if args.validators != "" {
+		filterArgs.Add("f.validators", args.validators)
+	}
+	if args.waddr != "" {
+		filterArgs.Add("f.waddr", args.waddr)
+	}
+	if args.vname != "" {
+		filterArgs.Add("f.vname", args.vname)
+	}
+	return filterArgs
+}
+
+// validatorsDashboardAccessUpdates tracks the last access time update per dashboard key,
+// so the access time is written at most once per hour and dashboard.
+var validatorsDashboardAccessUpdates = struct {
+	mutex   sync.Mutex
+	updates map[string]int64
+}{
+	updates: map[string]int64{},
+}
+
+func updateValidatorDashboardAccess(dashboard *dbtypes.ValidatorDashboard) {
+	now := time.Now().Unix()
+	if now-dashboard.LastAccess < 3600 {
+		return
+	}
+
+	validatorsDashboardAccessUpdates.mutex.Lock()
+	if now-validatorsDashboardAccessUpdates.updates[dashboard.DashboardKey] < 3600 {
+		validatorsDashboardAccessUpdates.mutex.Unlock()
+		return
+	}
+	for key, lastUpdate := range validatorsDashboardAccessUpdates.updates {
+		if now-lastUpdate >= 3600 {
+			delete(validatorsDashboardAccessUpdates.updates, key)
+		}
+	}
+	validatorsDashboardAccessUpdates.updates[dashboard.DashboardKey] = now
+	validatorsDashboardAccessUpdates.mutex.Unlock()
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.UpdateValidatorDashboardAccess(dashboard.DashboardKey, now, tx)
+	})
+	if err != nil {
+		logrus.Warnf("error updating validator dashboard access time: %v", err)
+	}
+}
+
+func getValidatorsDashboardPageData(args *validatorsDashboardArgs, dashboardKey string, dashboardName string) (*models.ValidatorsDashboardPageData, error) {
+	pageData := &models.ValidatorsDashboardPageData{}
+	pageCacheKey := fmt.Sprintf("validators_dashboard:%x:%v", sha256.Sum256([]byte(args.filterArgs().Encode())), dashboardKey)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsDashboardPageData(args, dashboardKey, dashboardName)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsDashboardPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsDashboardPageData(args *validatorsDashboardArgs, dashboardKey string, dashboardName string) (*models.ValidatorsDashboardPageData, time.Duration) {
+	logrus.Debugf("validators dashboard page called: %v", dashboardKey)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	pageData := &models.ValidatorsDashboardPageData{
+		FilterValidators:        args.validators,
+		FilterWithdrawalAddress: args.waddr,
+		FilterValidatorName:     args.vname,
+		DashboardKey:            dashboardKey,
+		DashboardName:           dashboardName,
+		CanSave:                 utils.Config.Frontend.AllowSavedDashboards,
+		ValidatorLimit:          validatorsDashboardMaxValidators,
+	}
+
+	cacheTime := 12 * time.Second
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot
+	}
+
+	indices, pubkeys := services.ParseValidatorSetList(args.validators)
+	var withdrawalAddress []byte
+	if args.waddr != "" {
+		addr, err := hex.DecodeString(strings.TrimPrefix(args.waddr, "0x"))
+		if err == nil && len(addr) == 20 {
+			withdrawalAddress = addr
+		}
+	}
+	if len(indices) == 0 && len(pubkeys) == 0 && withdrawalAddress == nil && args.vname == "" {
+		return pageData, cacheTime
+	}
+
+	filterArgs := args.filterArgs().Encode()
+	pageData.HasSetSelected = true
+	pageData.ShareLink = "/validators/dashboard?" + filterArgs
+	pageData.DutiesLink = "/validators/duties?" + filterArgs
+	if dashboardKey != "" {
+		pageData.ShareLink = "/validators/dashboard?d=" + dashboardKey
+	}
+
+	validatorSet := services.GlobalBeaconService.ResolveValidatorSet(&services.ValidatorSetFilter{
+		Indices:           indices,
+		Pubkeys:           pubkeys,
+		WithdrawalAddress: withdrawalAddress,
+		NamePattern:       args.vname,
+	}, validatorsDashboardMaxValidators+1)
+	if len(validatorSet) > validatorsDashboardMaxValidators {
+		validatorSet = validatorSet[:validatorsDashboardMaxValidators]
+		pageData.ValidatorsCapped = true
+	}
+	pageData.ValidatorCount = uint64(len(validatorSet))
+
+	// aggregate balances, status & liveness
+	statusMap := map[v1.ValidatorState]uint64{}
+	livenessSum := uint64(0)
+	pageData.Validators = make([]*models.ValidatorsDashboardPageDataValidator, 0)
+
+	for _, validatorIndex := range validatorSet {
+		validator := services.GlobalBeaconService.GetValidatorByIndex(validatorIndex, true)
+		if validator == nil || validator.Validator == nil {
+			continue
+		}
+
+		pageData.TotalBalance += uint64(validator.Balance)
+		pageData.TotalEffectiveBalance += uint64(validator.Validator.EffectiveBalance)
+		statusMap[validator.Status]++
+
+		validatorData := &models.ValidatorsDashboardPageDataValidator{
+			Index:            uint64(validatorIndex),
+			Name:             services.GlobalBeaconService.GetValidatorName(uint64(validatorIndex)),
+			Balance:          uint64(validator.Balance),
+			EffectiveBalance: uint64(validator.Validator.EffectiveBalance),
+		}
+
+		if strings.HasPrefix(validator.Status.String(), "pending") {
+			validatorData.State = "Pending"
+		} else if validator.Status == v1.ValidatorStateActiveOngoing {
+			validatorData.State = "Active"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateActiveExiting {
+			validatorData.State = "Exiting"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateActiveSlashed {
+			validatorData.State = "Slashed"
+			validatorData.ShowUpcheck = true
+		} else if validator.Status == v1.ValidatorStateExitedUnslashed {
+			validatorData.State = "Exited"
+		} else if validator.Status == v1.ValidatorStateExitedSlashed {
+			validatorData.State = "Slashed"
+		} else {
+			validatorData.State = validator.Status.String()
+		}
+
+		if validatorData.ShowUpcheck {
+			validatorData.UpcheckActivity = uint8(services.GlobalBeaconService.GetValidatorLiveness(validatorIndex, 3))
+			validatorData.UpcheckMaximum = uint8(3)
+
+			pageData.ActiveCount++
+			livenessSum += uint64(validatorData.UpcheckActivity)
+			if validatorData.UpcheckActivity > 0 {
+				pageData.OnlineCount++
+			} else {
+				pageData.OfflineCount++
+			}
+		}
+
+		if len(pageData.Validators) < validatorsDashboardMaxShownValidators {
+			pageData.Validators = append(pageData.Validators, validatorData)
+		}
+	}
+	pageData.ShownValidatorCount = uint64(len(pageData.Validators))
+
+	if pageData.ActiveCount > 0 {
+		pageData.LivenessPercent = float64(livenessSum) * 100 / float64(pageData.ActiveCount*3)
+	}
+
+	pageData.StatusCounts = make([]*models.ValidatorsDashboardPageDataStatus, 0, len(statusMap))
+	for status, count := range statusMap {
+		pageData.StatusCounts = append(pageData.StatusCounts, &models.ValidatorsDashboardPageDataStatus{
+			Status: status.String(),
+			Count:  count,
+		})
+	}
+	sort.Slice(pageData.StatusCounts, func(a, b int) bool {
+		return strings.Compare(pageData.StatusCounts[a].Status, pageData.StatusCounts[b].Status) < 0
+	})
+
+	// load recent proposals
+	pageData.RecentBlocks = make([]*models.ValidatorsDashboardPageDataBlock, 0)
+	if len(validatorSet) > 0 {
+		proposerIndexes := make([]uint64, len(validatorSet))
+		for i, validatorIndex := range validatorSet {
+			proposerIndexes[i] = uint64(validatorIndex)
+		}
+
+		blocksData := services.GlobalBeaconService.GetDbBlocksByFilter(&dbtypes.BlockFilter{
+			ProposerIndexes: proposerIndexes,
+			WithOrphaned:    1,
+			WithMissing:     1,
+		}, 0, 10, 0)
+		for _, blockData := range blocksData {
+			var blockStatus dbtypes.SlotStatus
+			if blockData.Block == nil {
+				blockStatus = dbtypes.Missing
+			} else {
+				blockStatus = blockData.Block.Status
+			}
+			blockEntry := &models.ValidatorsDashboardPageDataBlock{
+				Epoch:          uint64(chainState.EpochOfSlot(phase0.Slot(blockData.Slot))),
+				Slot:           blockData.Slot,
+				Ts:             chainState.SlotToTime(phase0.Slot(blockData.Slot)),
+				Status:         uint64(blockStatus),
+				ValidatorIndex: blockData.Proposer,
+				ValidatorName:  services.GlobalBeaconService.GetValidatorName(blockData.Proposer),
+			}
+			if blockData.Block != nil {
+				blockEntry.BlockRoot = fmt.Sprintf("0x%x", blockData.Block.Root)
+				if blockData.Block.EthBlockNumber != nil {
+					blockEntry.WithEthBlock = true
+					blockEntry.EthBlock = *blockData.Block.EthBlockNumber
+				}
+			}
+			pageData.RecentBlocks = append(pageData.RecentBlocks, blockEntry)
+		}
+	}
+	pageData.RecentBlockCount = uint64(len(pageData.RecentBlocks))
+
+	// load next duties
+	pageData.NextDuties = make([]*models.ValidatorsDutiesPageDataDuty, 0)
+	for _, duty := range services.GlobalBeaconService.GetUpcomingValidatorDuties(validatorSet) {
+		if len(pageData.NextDuties) >= 10 {
+			break
+		}
+
+		pageData.NextDuties = append(pageData.NextDuties, buildValidatorsDutiesPageDataDuty(duty))
+	}
+	pageData.NextDutyCount = uint64(len(pageData.NextDuties))
+
+	return pageData, cacheTime
+}
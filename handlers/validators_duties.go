@@ -0,0 +1,291 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// maximum number of validators that can be included in a duties lookahead
+const validatorsDutiesMaxValidators = 10000
+
+// ValidatorsDuties will return the "validators_duties" page using a go template
+func ValidatorsDuties(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validators_duties/validators_duties.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/duties", "Upcoming Duties", templateFiles)
+
+	validators, vname := parseValidatorsDutiesArgs(r.URL.Query())
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		data.Data, pageError = getValidatorsDutiesPageData(validators, vname)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		dutiesDataBytes, err := json.Marshal(data.Data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(dutiesDataBytes)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error writing response: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_duties.go", "ValidatorsDuties", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+// ValidatorsDutiesICal will return the upcoming duties of a validator set as iCalendar feed
+func ValidatorsDutiesICal(w http.ResponseWriter, r *http.Request) {
+	validators, vname := parseValidatorsDutiesArgs(r.URL.Query())
+
+	var pageData *models.ValidatorsDutiesPageData
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 2)
+	if pageError == nil {
+		pageData, pageError = getValidatorsDutiesPageData(validators, vname)
+	}
+	if pageError != nil {
+		http.Error(w, pageError.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	siteDomain := utils.Config.Frontend.SiteDomain
+	if siteDomain == "" {
+		siteDomain = r.Host
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "attachment; filename=\"validator-duties.ics\"")
+	_, err := w.Write([]byte(buildValidatorsDutiesICal(pageData, siteDomain)))
+	if err != nil {
+		logrus.Warnf("error writing duties ical response: %v", err)
+	}
+}
+
+func parseValidatorsDutiesArgs(urlArgs url.Values) (validators string, vname string) {
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.validators") {
+			validators = urlArgs.Get("f.validators")
+		}
+		if urlArgs.Has("f.vname") {
+			vname = urlArgs.Get("f.vname")
+		}
+	}
+	return
+}
+
+func getValidatorsDutiesPageData(validators string, vname string) (*models.ValidatorsDutiesPageData, error) {
+	pageData := &models.ValidatorsDutiesPageData{}
+	pageCacheKey := fmt.Sprintf("validators_duties:%x:%v", sha256.Sum256([]byte(validators)), vname)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsDutiesPageData(validators, vname)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsDutiesPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsDutiesPageData(validators string, vname string) (*models.ValidatorsDutiesPageData, time.Duration) {
+	logrus.Debugf("validators duties page called: %v", vname)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	pageData := &models.ValidatorsDutiesPageData{
+		FilterValidators:    validators,
+		FilterValidatorName: vname,
+		ValidatorLimit:      validatorsDutiesMaxValidators,
+		CurrentSlot:         uint64(chainState.CurrentSlot()),
+		CurrentEpoch:        uint64(chainState.CurrentEpoch()),
+		Duties:              []*models.ValidatorsDutiesPageDataDuty{},
+	}
+
+	cacheTime := 12 * time.Second
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot
+	}
+
+	filterArgs := url.Values{}
+	filterArgs.Add("f", "")
+	if validators != "" {
+		filterArgs.Add("f.validators", validators)
+	}
+	if vname != "" {
+		filterArgs.Add("f.vname", vname)
+	}
+
+	indices, pubkeys := services.ParseValidatorSetList(validators)
+	if len(indices) == 0 && len(pubkeys) == 0 && vname == "" {
+		return pageData, cacheTime
+	}
+
+	pageData.HasSetSelected = true
+	pageData.ShareLink = "/validators/duties?" + filterArgs.Encode()
+	pageData.ICalLink = "/validators/duties/ical?" + filterArgs.Encode()
+
+	validatorSet := services.GlobalBeaconService.ResolveValidatorSet(&services.ValidatorSetFilter{
+		Indices:     indices,
+		Pubkeys:     pubkeys,
+		NamePattern: vname,
+	}, validatorsDutiesMaxValidators+1)
+	if len(validatorSet) > validatorsDutiesMaxValidators {
+		validatorSet = validatorSet[:validatorsDutiesMaxValidators]
+		pageData.ValidatorsCapped = true
+	}
+	pageData.ValidatorCount = uint64(len(validatorSet))
+
+	for _, duty := range services.GlobalBeaconService.GetUpcomingValidatorDuties(validatorSet) {
+		dutyData := buildValidatorsDutiesPageDataDuty(duty)
+		switch duty.Type {
+		case services.ValidatorDutyProposal:
+			pageData.ProposalCount++
+		case services.ValidatorDutySyncCommittee:
+			pageData.SyncDutyCount++
+		}
+
+		pageData.Duties = append(pageData.Duties, dutyData)
+	}
+	pageData.DutyCount = uint64(len(pageData.Duties))
+
+	return pageData, cacheTime
+}
+
+func buildValidatorsDutiesPageDataDuty(duty *services.ValidatorDuty) *models.ValidatorsDutiesPageDataDuty {
+	chainState := services.GlobalBeaconService.GetChainState()
+	dutyData := &models.ValidatorsDutiesPageDataDuty{
+		ValidatorIndex: uint64(duty.ValidatorIndex),
+		ValidatorName:  services.GlobalBeaconService.GetValidatorName(uint64(duty.ValidatorIndex)),
+		Slot:           uint64(duty.Slot),
+		EndSlot:        uint64(duty.EndSlot),
+		Epoch:          uint64(chainState.EpochOfSlot(duty.Slot)),
+		SyncPeriod:     duty.SyncPeriod,
+		Time:           chainState.SlotToTime(duty.Slot),
+		EndTime:        chainState.SlotToTime(duty.EndSlot + 1),
+		Predicted:      duty.Predicted,
+	}
+
+	switch duty.Type {
+	case services.ValidatorDutyProposal:
+		dutyData.Type = "proposal"
+	case services.ValidatorDutySyncCommittee:
+		dutyData.Type = "sync"
+	}
+
+	return dutyData
+}
+
+func buildValidatorsDutiesICal(pageData *models.ValidatorsDutiesPageData, siteDomain string) string {
+	icalTimeFormat := "20060102T150405Z"
+	now := time.Now().UTC().Format(icalTimeFormat)
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		fmt.Sprintf("PRODID:-//%v//Validator Duties//EN", utils.Config.Frontend.SiteName),
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		fmt.Sprintf("X-WR-CALNAME:%v Validator Duties", utils.Config.Frontend.SiteName),
+	}
+
+	for _, duty := range pageData.Duties {
+		validatorLabel := fmt.Sprintf("%v", duty.ValidatorIndex)
+		if duty.ValidatorName != "" {
+			validatorLabel = fmt.Sprintf("%v (%v)", duty.ValidatorName, duty.ValidatorIndex)
+		}
+
+		var uid, summary, description string
+		switch duty.Type {
+		case "proposal":
+			uid = fmt.Sprintf("proposal-%v-%v@%v", duty.Slot, duty.ValidatorIndex, siteDomain)
+			summary = fmt.Sprintf("Block proposal: validator %v", validatorLabel)
+			description = fmt.Sprintf("Validator %v is scheduled to propose slot %v (epoch %v).", validatorLabel, duty.Slot, duty.Epoch)
+		case "sync":
+			uid = fmt.Sprintf("sync-%v-%v@%v", duty.SyncPeriod, duty.ValidatorIndex, siteDomain)
+			summary = fmt.Sprintf("Sync committee: validator %v", validatorLabel)
+			description = fmt.Sprintf("Validator %v is part of the sync committee for period %v (slot %v - %v).", validatorLabel, duty.SyncPeriod, duty.Slot, duty.EndSlot)
+		default:
+			continue
+		}
+		if duty.Predicted {
+			description += " This duty is predicted and might still change."
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+uid,
+			"DTSTAMP:"+now,
+			"DTSTART:"+duty.Time.UTC().Format(icalTimeFormat),
+			"DTEND:"+duty.EndTime.UTC().Format(icalTimeFormat),
+			"SUMMARY:"+escapeICalText(summary),
+			"DESCRIPTION:"+escapeICalText(description),
+			fmt.Sprintf("URL:https://%v/slot/%v", siteDomain, duty.Slot),
+			"END:VEVENT",
+		)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	icalBuilder := strings.Builder{}
+	for _, line := range lines {
+		icalBuilder.WriteString(foldICalLine(line))
+		icalBuilder.WriteString("\r\n")
+	}
+	return icalBuilder.String()
+}
+
+func escapeICalText(text string) string {
+	return strings.NewReplacer("\\", "\\\\", ";", "\\;", ",", "\\,", "\n", "\\n").Replace(text)
+}
+
+// foldICalLine splits lines longer than 75 octets as required by RFC 5545
+func foldICalLine(line string) string {
+	if len(line) <= 75 {
+		return line
+	}
+
+	folded := strings.Builder{}
+	lineLen := 0
+	for _, char := range line {
+		charLen := len(string(char))
+		if lineLen+charLen > 75 {
+			folded.WriteString("\r\n ")
+			lineLen = 1
+		}
+		folded.WriteRune(char)
+		lineLen += charLen
+	}
+	return folded.String()
+}
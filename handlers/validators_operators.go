@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/services"
+	"github.com/ethpandaops/dora/templates"
+	"github.com/ethpandaops/dora/types/models"
+	"github.com/sirupsen/logrus"
+)
+
+// maximum number of epochs that can be aggregated in the operator leaderboard
+const validatorsOperatorsMaxEpochRange = 10000
+
+// ValidatorsOperators will return the "validators_operators" leaderboard page using a go template
+func ValidatorsOperators(w http.ResponseWriter, r *http.Request) {
+	var templateFiles = append(layoutTemplateFiles,
+		"validators_operators/validators_operators.html",
+		"_svg/professor.html",
+	)
+
+	var pageTemplate = templates.GetTemplate(templateFiles...)
+	data := InitPageData(w, r, "validators", "/validators/operators", "Operator Leaderboard", templateFiles)
+
+	chainState := services.GlobalBeaconService.GetChainState()
+	endEpoch := uint64(chainState.CurrentEpoch())
+	if endEpoch > 0 {
+		endEpoch--
+	}
+	startEpoch := uint64(0)
+	if endEpoch > 99 {
+		startEpoch = endEpoch - 99
+	}
+	groupByPrefix := false
+	nameFilter := ""
+	order := "liveness"
+
+	urlArgs := r.URL.Query()
+	if urlArgs.Has("f") {
+		if urlArgs.Has("f.start") {
+			startEpoch, _ = strconv.ParseUint(urlArgs.Get("f.start"), 10, 64)
+		}
+		if urlArgs.Has("f.end") {
+			endEpoch, _ = strconv.ParseUint(urlArgs.Get("f.end"), 10, 64)
+		}
+		if urlArgs.Has("f.prefix") {
+			groupByPrefix = urlArgs.Get("f.prefix") == "1"
+		}
+		if urlArgs.Has("f.name") {
+			nameFilter = urlArgs.Get("f.name")
+		}
+	}
+	if urlArgs.Has("o") {
+		order = urlArgs.Get("o")
+	}
+
+	if endEpoch < startEpoch {
+		startEpoch, endEpoch = endEpoch, startEpoch
+	}
+	if endEpoch-startEpoch >= validatorsOperatorsMaxEpochRange {
+		startEpoch = endEpoch - validatorsOperatorsMaxEpochRange + 1
+	}
+
+	var pageError error
+	pageError = services.GlobalCallRateLimiter.CheckCallLimit(r, 3)
+	if pageError == nil {
+		data.Data, pageError = getValidatorsOperatorsPageData(startEpoch, endEpoch, groupByPrefix, nameFilter, order)
+	}
+	if pageError != nil {
+		handlePageError(w, r, pageError)
+		return
+	}
+
+	if r.Header.Get("Accept") == "application/json" {
+		w.Header().Set("Content-Type", "application/json")
+		operatorsDataBytes, err := json.Marshal(data.Data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, err = w.Write(operatorsDataBytes)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Error writing response: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if handleTemplateError(w, r, "validators_operators.go", "ValidatorsOperators", "", pageTemplate.ExecuteTemplate(w, "layout", data)) != nil {
+		return // an error has occurred and was processed
+	}
+}
+
+func getValidatorsOperatorsPageData(startEpoch uint64, endEpoch uint64, groupByPrefix bool, nameFilter string, order string) (*models.ValidatorsOperatorsPageData, error) {
+	pageData := &models.ValidatorsOperatorsPageData{}
+	pageCacheKey := fmt.Sprintf("validators_operators:%v:%v:%v:%v:%v", startEpoch, endEpoch, groupByPrefix, nameFilter, order)
+	pageRes, pageErr := services.GlobalFrontendCache.ProcessCachedPage(pageCacheKey, true, pageData, func(pageCall *services.FrontendCacheProcessingPage) interface{} {
+		pageData, cacheTimeout := buildValidatorsOperatorsPageData(startEpoch, endEpoch, groupByPrefix, nameFilter, order)
+		pageCall.CacheTimeout = cacheTimeout
+		return pageData
+	})
+	if pageErr == nil && pageRes != nil {
+		resData, resOk := pageRes.(*models.ValidatorsOperatorsPageData)
+		if !resOk {
+			return nil, ErrInvalidPageModel
+		}
+		pageData = resData
+	}
+	return pageData, pageErr
+}
+
+func buildValidatorsOperatorsPageData(startEpoch uint64, endEpoch uint64, groupByPrefix bool, nameFilter string, order string) (*models.ValidatorsOperatorsPageData, time.Duration) {
+	logrus.Debugf("validators operators page called: %v-%v (prefix: %v, name: %v)", startEpoch, endEpoch, groupByPrefix, nameFilter)
+	chainState := services.GlobalBeaconService.GetChainState()
+	specs := chainState.GetSpecs()
+
+	pageData := &models.ValidatorsOperatorsPageData{
+		FilterStartEpoch:    startEpoch,
+		FilterEndEpoch:      endEpoch,
+		FilterGroupByPrefix: groupByPrefix,
+		FilterName:          nameFilter,
+		FilterOrder:         order,
+		MaxEpochRange:       validatorsOperatorsMaxEpochRange,
+		Operators:           []*models.ValidatorsOperatorsPageDataOperator{},
+	}
+
+	cacheTime := 1 * time.Minute
+	if specs != nil {
+		cacheTime = specs.SecondsPerSlot * time.Duration(specs.SlotsPerEpoch) / 4
+	}
+
+	performance := services.GlobalBeaconService.GetOperatorPerformance(&services.OperatorPerformanceFilter{
+		StartEpoch:    phase0.Epoch(startEpoch),
+		EndEpoch:      phase0.Epoch(endEpoch),
+		GroupByPrefix: groupByPrefix,
+		NameFilter:    nameFilter,
+	})
+
+	pageData.HasActivity = performance.HasActivity
+	pageData.ActivityStartEpoch = uint64(performance.ActivityStartEpoch)
+	pageData.ActivityEndEpoch = uint64(performance.ActivityEndEpoch)
+
+	for _, operator := range performance.Operators {
+		operatorData := &models.ValidatorsOperatorsPageDataOperator{
+			Name:              operator.Name,
+			ValidatorCount:    operator.ValidatorCount,
+			ProposalsHit:      operator.ProposalsHit,
+			ProposalsMissed:   operator.ProposalsMissed,
+			ProposalsOrphaned: operator.ProposalsOrphaned,
+			MissedOffline:     operator.MissedOffline,
+			MissedRelay:       operator.MissedRelay,
+			MissedLate:        operator.MissedLate,
+			MissedOrphaned:    operator.MissedOrphaned,
+			MissedUnknown:     operator.MissedUnknown,
+			AttestationsDue:   operator.AttestationsDue,
+			AttestationsHit:   operator.AttestationsHit,
+			SyncDue:           operator.SyncDue,
+			SyncHit:           operator.SyncHit,
+			Slashings:         operator.Slashings,
+		}
+
+		proposalCount := operator.ProposalsHit + operator.ProposalsMissed + operator.ProposalsOrphaned
+		if proposalCount > 0 {
+			operatorData.ProposalRate = float64(operator.ProposalsHit) * 100 / float64(proposalCount)
+		}
+		if operator.AttestationsDue > 0 {
+			operatorData.AttestationLiveness = float64(operator.AttestationsHit) * 100 / float64(operator.AttestationsDue)
+		}
+		if operator.SyncDue > 0 {
+			operatorData.SyncParticipation = float64(operator.SyncHit) * 100 / float64(operator.SyncDue)
+		}
+
+		pageData.Operators = append(pageData.Operators, operatorData)
+	}
+	pageData.OperatorCount = uint64(len(pageData.Operators))
+
+	sortValidatorsOperators(pageData.Operators, order)
+
+	return pageData, cacheTime
+}
+
+func sortValidatorsOperators(operators []*models.ValidatorsOperatorsPageDataOperator, order string) {
+	ascending := strings.HasSuffix(order, "-asc")
+	sortField := strings.TrimSuffix(order, "-asc")
+
+	getSortValue := func(operator *models.ValidatorsOperatorsPageDataOperator) (float64, bool) {
+		switch sortField {
+		case "validators":
+			return float64(operator.ValidatorCount), true
+		case "proposals":
+			return operator.ProposalRate, operator.ProposalsHit+operator.ProposalsMissed+operator.ProposalsOrphaned > 0
+		case "sync":
+			return operator.SyncParticipation, operator.SyncDue > 0
+		case "slashings":
+			return float64(operator.Slashings), true
+		default:
+			return operator.AttestationLiveness, operator.AttestationsDue > 0
+		}
+	}
+
+	sort.SliceStable(operators, func(a, b int) bool {
+		if sortField == "name" {
+			return operators[a].Name < operators[b].Name
+		}
+
+		valA, hasA := getSortValue(operators[a])
+		valB, hasB := getSortValue(operators[b])
+		if hasA != hasB {
+			// operators without data are always shown last
+			return hasA
+		}
+		if valA == valB {
+			return operators[a].Name < operators[b].Name
+		}
+		if ascending {
+			return valA < valB
+		}
+		return valA > valB
+	})
+}
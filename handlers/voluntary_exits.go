@@ -20,6 +20,7 @@ import (
 func VoluntaryExits(w http.ResponseWriter, r *http.Request) {
 	var templateFiles = append(layoutTemplateFiles,
 		"voluntary_exits/voluntary_exits.html",
+		"_layout/export.html",
 		"_svg/professor.html",
 	)
 
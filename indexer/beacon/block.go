@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
@@ -33,7 +34,9 @@ type Block struct {
 	isInFinalizedDb   bool // block is in finalized table (slots)
 	isInUnfinalizedDb bool // block is in unfinalized table (unfinalized_blocks)
 	isDisposed        bool // block is disposed
+	isSpilled         bool // block has been moved to the spill cache
 	processingStatus  dbtypes.UnfinalizedBlockStatus
+	recvTs            atomic.Int64 // time the block was first received via the event stream of any client (unix ms, 0 if unknown)
 	seenMutex         sync.RWMutex
 	seenMap           map[uint16]*Client
 	processedActivity uint8
@@ -333,6 +336,7 @@ func (block *Block) buildUnfinalizedBlock(compress bool) (*dbtypes.UnfinalizedBl
 		BlockSSZ:  blockSSZ,
 		Status:    0,
 		ForkId:    uint64(block.forkId),
+		RecvTs:    block.getRecvTs(),
 	}, nil
 }
 
@@ -358,9 +362,32 @@ func (block *Block) buildOrphanedBlock(compress bool) (*dbtypes.OrphanedBlock, e
 		HeaderSSZ: headerSSZ,
 		BlockVer:  blockVer,
 		BlockSSZ:  blockSSZ,
+		RecvTs:    block.getRecvTs(),
 	}, nil
 }
 
+// GetRecvTime returns the time the block was first received from any client (zero if unknown).
+func (block *Block) GetRecvTime() time.Time {
+	recvTs := block.recvTs.Load()
+	if recvTs == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(recvTs)
+}
+
+// setRecvTime sets the receive time of the block if not already set.
+func (block *Block) setRecvTime(recvTime time.Time) {
+	if recvTime.IsZero() {
+		return
+	}
+	block.recvTs.CompareAndSwap(0, recvTime.UnixMilli())
+}
+
+// getRecvTs returns the receive time as unix milliseconds for the database (0 if unknown).
+func (block *Block) getRecvTs() int64 {
+	return block.recvTs.Load()
+}
+
 // unpruneBlockBody retrieves the block body from the database if it is not already present.
 func (block *Block) unpruneBlockBody() {
 	if block.isDisposed || block.block != nil || !block.isInUnfinalizedDb {
@@ -3,6 +3,7 @@ package beacon
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/altair"
@@ -11,6 +12,9 @@ import (
 	"github.com/attestantio/go-eth2-client/spec/deneb"
 	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethpandaops/dora/utils"
 	dynssz "github.com/pk910/dynamic-ssz"
 )
@@ -191,6 +195,138 @@ func unmarshalVersionedSignedBeaconBlockJson(version uint64, ssz []byte) (*spec.
 	return block, nil
 }
 
+// getBlockExecutionFeeRecipient returns the fee recipient from the execution payload of a versioned signed beacon block.
+func getBlockExecutionFeeRecipient(v *spec.VersionedSignedBeaconBlock) (bellatrix.ExecutionAddress, error) {
+	switch v.Version {
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.Message == nil || v.Bellatrix.Message.Body == nil || v.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no bellatrix block")
+		}
+
+		return v.Bellatrix.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.Message == nil || v.Capella.Message.Body == nil || v.Capella.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no capella block")
+		}
+
+		return v.Capella.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil || v.Deneb.Message.Body == nil || v.Deneb.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no deneb block")
+		}
+
+		return v.Deneb.Message.Body.ExecutionPayload.FeeRecipient, nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil || v.Electra.Message.Body == nil || v.Electra.Message.Body.ExecutionPayload == nil {
+			return bellatrix.ExecutionAddress{}, errors.New("no electra block")
+		}
+
+		return v.Electra.Message.Body.ExecutionPayload.FeeRecipient, nil
+	default:
+		return bellatrix.ExecutionAddress{}, errors.New("unknown version")
+	}
+}
+
+// getBlockPaymentTransaction returns the builder payment transaction of a payload.
+// By convention, builders pay the proposer with the last transaction of the payload, sent from the payloads fee recipient.
+func getBlockPaymentTransaction(feeRecipient bellatrix.ExecutionAddress, transactions []bellatrix.Transaction) *ethtypes.Transaction {
+	if len(transactions) == 0 {
+		return nil
+	}
+
+	var tx ethtypes.Transaction
+	if err := tx.UnmarshalBinary(transactions[len(transactions)-1]); err != nil {
+		return nil
+	}
+
+	if tx.To() == nil || *tx.To() == common.Address(feeRecipient) {
+		return nil
+	}
+
+	txFrom, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), &tx)
+	if err != nil || txFrom != common.Address(feeRecipient) {
+		return nil
+	}
+
+	return &tx
+}
+
+// getBlockExecutionGasInfo returns the gas limit, gas used and base fee per gas (in wei) from the execution payload of a versioned signed beacon block.
+func getBlockExecutionGasInfo(v *spec.VersionedSignedBeaconBlock) (uint64, uint64, *big.Int, error) {
+	switch v.Version {
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.Message == nil || v.Bellatrix.Message.Body == nil || v.Bellatrix.Message.Body.ExecutionPayload == nil {
+			return 0, 0, nil, errors.New("no bellatrix block")
+		}
+
+		payload := v.Bellatrix.Message.Body.ExecutionPayload
+		return payload.GasLimit, payload.GasUsed, baseFeeFromLittleEndian(payload.BaseFeePerGas), nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.Message == nil || v.Capella.Message.Body == nil || v.Capella.Message.Body.ExecutionPayload == nil {
+			return 0, 0, nil, errors.New("no capella block")
+		}
+
+		payload := v.Capella.Message.Body.ExecutionPayload
+		return payload.GasLimit, payload.GasUsed, baseFeeFromLittleEndian(payload.BaseFeePerGas), nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil || v.Deneb.Message.Body == nil || v.Deneb.Message.Body.ExecutionPayload == nil {
+			return 0, 0, nil, errors.New("no deneb block")
+		}
+
+		payload := v.Deneb.Message.Body.ExecutionPayload
+		return payload.GasLimit, payload.GasUsed, payload.BaseFeePerGas.ToBig(), nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil || v.Electra.Message.Body == nil || v.Electra.Message.Body.ExecutionPayload == nil {
+			return 0, 0, nil, errors.New("no electra block")
+		}
+
+		payload := v.Electra.Message.Body.ExecutionPayload
+		return payload.GasLimit, payload.GasUsed, payload.BaseFeePerGas.ToBig(), nil
+	default:
+		return 0, 0, nil, errors.New("unknown version")
+	}
+}
+
+// getBlockExecutionBlobGas returns the blob gas used and excess blob gas from the execution payload of a versioned signed beacon block.
+func getBlockExecutionBlobGas(v *spec.VersionedSignedBeaconBlock) (uint64, uint64, error) {
+	switch v.Version {
+	case spec.DataVersionPhase0, spec.DataVersionAltair, spec.DataVersionBellatrix, spec.DataVersionCapella:
+		return 0, 0, errors.New("no blob gas before deneb")
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.Message == nil || v.Deneb.Message.Body == nil || v.Deneb.Message.Body.ExecutionPayload == nil {
+			return 0, 0, errors.New("no deneb block")
+		}
+
+		payload := v.Deneb.Message.Body.ExecutionPayload
+		return payload.BlobGasUsed, payload.ExcessBlobGas, nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.Message == nil || v.Electra.Message.Body == nil || v.Electra.Message.Body.ExecutionPayload == nil {
+			return 0, 0, errors.New("no electra block")
+		}
+
+		payload := v.Electra.Message.Body.ExecutionPayload
+		return payload.BlobGasUsed, payload.ExcessBlobGas, nil
+	default:
+		return 0, 0, errors.New("unknown version")
+	}
+}
+
+// getBlobBaseFee derives the blob base fee (in wei) from the excess blob gas of a payload.
+// The base fee update fraction got raised with EIP-7691, which activated together with electra.
+func getBlobBaseFee(version spec.DataVersion, excessBlobGas uint64) *big.Int {
+	return eip4844.CalcBlobFee(excessBlobGas, version >= spec.DataVersionElectra)
+}
+
+// baseFeeFromLittleEndian converts the little endian encoded base fee of pre-deneb payloads.
+func baseFeeFromLittleEndian(baseFee [32]byte) *big.Int {
+	bigEndian := make([]byte, 32)
+	for i := 0; i < 32; i++ {
+		bigEndian[i] = baseFee[31-i]
+	}
+
+	return new(big.Int).SetBytes(bigEndian)
+}
+
 // getBlockExecutionExtraData returns the extra data from the execution payload of a versioned signed beacon block.
 func getBlockExecutionExtraData(v *spec.VersionedSignedBeaconBlock) ([]byte, error) {
 	switch v.Version {
@@ -286,6 +422,25 @@ func getStateDepositIndex(state *spec.VersionedBeaconState) uint64 {
 	return 0
 }
 
+// getStateFinalizedCheckpoint returns the finalized checkpoint from a versioned beacon state.
+func getStateFinalizedCheckpoint(state *spec.VersionedBeaconState) *phase0.Checkpoint {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.FinalizedCheckpoint
+	case spec.DataVersionAltair:
+		return state.Altair.FinalizedCheckpoint
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.FinalizedCheckpoint
+	case spec.DataVersionCapella:
+		return state.Capella.FinalizedCheckpoint
+	case spec.DataVersionDeneb:
+		return state.Deneb.FinalizedCheckpoint
+	case spec.DataVersionElectra:
+		return state.Electra.FinalizedCheckpoint
+	}
+	return nil
+}
+
 // getStateCurrentSyncCommittee returns the current sync committee from a versioned beacon state.
 func getStateCurrentSyncCommittee(v *spec.VersionedBeaconState) ([]phase0.BLSPubKey, error) {
 	switch v.Version {
@@ -326,6 +481,46 @@ func getStateCurrentSyncCommittee(v *spec.VersionedBeaconState) ([]phase0.BLSPub
 	}
 }
 
+// getStateNextSyncCommittee returns the next sync committee from a versioned beacon state.
+func getStateNextSyncCommittee(v *spec.VersionedBeaconState) ([]phase0.BLSPubKey, error) {
+	switch v.Version {
+	case spec.DataVersionPhase0:
+		return nil, errors.New("no sync committee in phase0")
+	case spec.DataVersionAltair:
+		if v.Altair == nil || v.Altair.NextSyncCommittee == nil {
+			return nil, errors.New("no altair block")
+		}
+
+		return v.Altair.NextSyncCommittee.Pubkeys, nil
+	case spec.DataVersionBellatrix:
+		if v.Bellatrix == nil || v.Bellatrix.NextSyncCommittee == nil {
+			return nil, errors.New("no bellatrix block")
+		}
+
+		return v.Bellatrix.NextSyncCommittee.Pubkeys, nil
+	case spec.DataVersionCapella:
+		if v.Capella == nil || v.Capella.NextSyncCommittee == nil {
+			return nil, errors.New("no capella block")
+		}
+
+		return v.Capella.NextSyncCommittee.Pubkeys, nil
+	case spec.DataVersionDeneb:
+		if v.Deneb == nil || v.Deneb.NextSyncCommittee == nil {
+			return nil, errors.New("no deneb block")
+		}
+
+		return v.Deneb.NextSyncCommittee.Pubkeys, nil
+	case spec.DataVersionElectra:
+		if v.Electra == nil || v.Electra.NextSyncCommittee == nil {
+			return nil, errors.New("no electra block")
+		}
+
+		return v.Electra.NextSyncCommittee.Pubkeys, nil
+	default:
+		return nil, errors.New("unknown version")
+	}
+}
+
 // getStatePendingWithdrawals returns the pending withdrawals from a versioned beacon state.
 func getStatePendingWithdrawals(v *spec.VersionedBeaconState) ([]*electra.PendingPartialWithdrawal, error) {
 	switch v.Version {
@@ -46,6 +46,10 @@ func (cache *blockCache) createOrGetBlock(root phase0.Root, slot phase0.Slot) (*
 		return cache.rootMap[root], false
 	}
 
+	if spilledBlock := cache.indexer.spillCache.getBlock(root); spilledBlock != nil {
+		return spilledBlock, false
+	}
+
 	cacheBlock := newBlock(cache.indexer.dynSsz, root, slot)
 	cache.rootMap[root] = cacheBlock
 
@@ -72,7 +76,7 @@ func (cache *blockCache) addBlockToParentMap(block *Block) {
 	defer cache.cacheMutex.Unlock()
 
 	parentRoot := block.GetParentRoot()
-	if parentRoot == nil {
+	if parentRoot == nil || block.isSpilled {
 		return
 	}
 
@@ -91,7 +95,7 @@ func (cache *blockCache) addBlockToExecBlockMap(block *Block) {
 	defer cache.cacheMutex.Unlock()
 
 	blockIndex := block.GetBlockIndex()
-	if blockIndex == nil {
+	if blockIndex == nil || block.isSpilled {
 		return
 	}
 
@@ -111,20 +115,43 @@ func (cache *blockCache) addBlockToExecBlockMap(block *Block) {
 // getBlockByRoot returns the cached block with the given root.
 func (cache *blockCache) getBlockByRoot(root phase0.Root) *Block {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
+	block := cache.rootMap[root]
+	cache.cacheMutex.RUnlock()
+
+	if block == nil {
+		block = cache.indexer.spillCache.getBlock(root)
+	}
 
-	return cache.rootMap[root]
+	return block
 }
 
 // getBlocksBySlot returns the cached blocks with the given slot.
 func (cache *blockCache) getBlocksBySlot(slot phase0.Slot) []*Block {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	blocks := make([]*Block, len(cache.slotMap[slot]))
 	if len(blocks) > 0 {
 		copy(blocks, cache.slotMap[slot])
 	}
+	cache.cacheMutex.RUnlock()
+
+	return cache.appendSpilledBlocks(blocks, cache.indexer.spillCache.getBlocksBySlotRange(slot, slot))
+}
+
+// appendSpilledBlocks appends the spilled blocks to the given list of cached blocks, skipping duplicates.
+func (cache *blockCache) appendSpilledBlocks(blocks []*Block, spilledBlocks []*Block) []*Block {
+	for _, spilledBlock := range spilledBlocks {
+		isDuplicate := false
+		for _, block := range blocks {
+			if block.Root == spilledBlock.Root {
+				isDuplicate = true
+				break
+			}
+		}
+
+		if !isDuplicate {
+			blocks = append(blocks, spilledBlock)
+		}
+	}
 
 	return blocks
 }
@@ -132,22 +159,19 @@ func (cache *blockCache) getBlocksBySlot(slot phase0.Slot) []*Block {
 // getBlocksByParentRoot returns a slice of blocks that have the given parent root.
 func (cache *blockCache) getBlocksByParentRoot(parentRoot phase0.Root) []*Block {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	cachedBlocks := cache.parentMap[parentRoot]
 	blocks := make([]*Block, len(cachedBlocks))
 	if len(blocks) > 0 {
 		copy(blocks, cachedBlocks)
 	}
+	cache.cacheMutex.RUnlock()
 
-	return blocks
+	return cache.appendSpilledBlocks(blocks, cache.indexer.spillCache.getBlocksByParentRoot(parentRoot))
 }
 
 // getBlockByStateRoot returns the block with the given state root.
 func (cache *blockCache) getBlockByStateRoot(stateRoot phase0.Root) *Block {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	for _, block := range cache.rootMap {
 		blockHeader := block.GetHeader()
 		if blockHeader == nil {
@@ -155,27 +179,33 @@ func (cache *blockCache) getBlockByStateRoot(stateRoot phase0.Root) *Block {
 		}
 
 		if bytes.Equal(blockHeader.Message.StateRoot[:], stateRoot[:]) {
+			cache.cacheMutex.RUnlock()
 			return block
 		}
 	}
+	cache.cacheMutex.RUnlock()
 
-	return nil
+	return cache.indexer.spillCache.getBlockByStateRoot(stateRoot)
 }
 
 func (cache *blockCache) getBlocksByExecutionBlockHash(blockHash phase0.Hash32) []*Block {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	cachedBlocks := cache.execBlockMap[blockHash]
 	blocks := make([]*Block, len(cachedBlocks))
 	if len(blocks) > 0 {
 		copy(blocks, cachedBlocks)
 	}
+	cache.cacheMutex.RUnlock()
 
-	return blocks
+	return cache.appendSpilledBlocks(blocks, cache.indexer.spillCache.getBlocksByExecutionBlockHash(blockHash))
 }
 
 func (cache *blockCache) getBlocksByExecutionBlockNumber(blockNumber uint64) []*Block {
+	resBlocks := cache.getCachedBlocksByExecutionBlockNumber(blockNumber)
+	return cache.appendSpilledBlocks(resBlocks, cache.indexer.spillCache.getBlocksByExecutionBlockNumber(blockNumber))
+}
+
+func (cache *blockCache) getCachedBlocksByExecutionBlockNumber(blockNumber uint64) []*Block {
 	cache.cacheMutex.RLock()
 	defer cache.cacheMutex.RUnlock()
 
@@ -300,8 +330,19 @@ func (cache *blockCache) getLatestBlocks(limit uint64, forkId *ForkKey) []*Block
 // removeBlock removes the given block from the block cache.
 func (cache *blockCache) removeBlock(block *Block) {
 	cache.cacheMutex.Lock()
-	defer cache.cacheMutex.Unlock()
+	cache.removeBlockFromMaps(block)
+	cache.cacheMutex.Unlock()
 
+	if block.isSpilled {
+		cache.indexer.spillCache.removeBlock(block)
+	}
+
+	block.Dispose()
+}
+
+// removeBlockFromMaps removes the given block from all cache maps.
+// the caller must hold the cache mutex.
+func (cache *blockCache) removeBlockFromMaps(block *Block) {
 	// remove the block from the root map.
 	delete(cache.rootMap, block.Root)
 
@@ -347,26 +388,63 @@ func (cache *blockCache) removeBlock(block *Block) {
 			}
 		}
 	}
+}
 
-	block.Dispose()
+// spillBlocks moves all pruned blocks before the given slot to the spill cache.
+// the blocks are removed from the cache maps and paged back in from the spill cache on demand.
+func (cache *blockCache) spillBlocks(spillSlot phase0.Slot) (uint64, error) {
+	cache.cacheMutex.RLock()
+	blocks := []*Block{}
+	for slot, slotBlocks := range cache.slotMap {
+		if slot >= spillSlot {
+			continue
+		}
+
+		for _, block := range slotBlocks {
+			if block.block != nil || block.header == nil || !block.isInFinalizedDb {
+				continue
+			}
+
+			blocks = append(blocks, block)
+		}
+	}
+	cache.cacheMutex.RUnlock()
+
+	if len(blocks) == 0 {
+		return 0, nil
+	}
+
+	// write blocks to disk before removing them from the maps, so lookups never miss them
+	if err := cache.indexer.spillCache.addBlocks(blocks); err != nil {
+		return 0, err
+	}
+
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	for _, block := range blocks {
+		cache.removeBlockFromMaps(block)
+	}
+
+	return uint64(len(blocks)), nil
 }
 
 // getEpochBlocks returns the blocks that belong to the specified epoch.
 func (cache *blockCache) getEpochBlocks(epoch phase0.Epoch) []*Block {
-	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
+	chainState := cache.indexer.consensusPool.GetChainState()
 	blocks := []*Block{}
 
+	cache.cacheMutex.RLock()
 	for slot, slotBlocks := range cache.slotMap {
-		if cache.indexer.consensusPool.GetChainState().EpochOfSlot(slot) != epoch {
+		if chainState.EpochOfSlot(slot) != epoch {
 			continue
 		}
 
 		blocks = append(blocks, slotBlocks...)
 	}
+	cache.cacheMutex.RUnlock()
 
-	return blocks
+	return cache.appendSpilledBlocks(blocks, cache.indexer.spillCache.getBlocksBySlotRange(chainState.EpochToSlot(epoch), chainState.EpochToSlot(epoch+1)-1))
 }
 
 // isCanonicalBlock checks if the block with the given blockRoot is a canonical block with respect to the block with the given head.
@@ -286,11 +286,16 @@ func (c *Client) processHeadEvent(headEvent *v1.HeadEvent) error {
 
 // processStreamBlock processes a block received from the stream (either via block or head events).
 func (c *Client) processStreamBlock(slot phase0.Slot, root phase0.Root) (*Block, error) {
+	recvTime := time.Now()
+
 	block, isNew, processingTimes, err := c.processBlock(slot, root, nil)
 	if err != nil {
 		return nil, err
 	}
 
+	// only blocks from the event stream carry a meaningful receive time, polled & backfilled blocks are left unset
+	block.setRecvTime(recvTime)
+
 	c.emitBlockLogEntry(slot, root, "stream", isNew, block.forkId, processingTimes)
 
 	return block, nil
@@ -429,6 +434,8 @@ func (c *Client) processBlock(slot phase0.Slot, root phase0.Root, header *phase0
 
 		block.isInUnfinalizedDb = true
 		c.indexer.blockCache.latestBlock = block
+
+		c.indexer.blockDispatcher.Fire(block)
 	}
 
 	if slot < finalizedSlot && !block.isInFinalizedDb {
@@ -2,6 +2,7 @@ package beacon
 
 import (
 	"reflect"
+	"unsafe"
 
 	mapsize "github.com/520MianXiangDuiXiang520/MapSize"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -38,8 +39,23 @@ type CacheDebugStats struct {
 		ParentIdsCacheHit  uint64
 		ParentIdsCacheMiss uint64
 	}
+	SpillCache struct {
+		Enabled       bool
+		SpilledSlot   uint64
+		SpilledEpoch  uint64
+		SpilledBlocks uint64
+		SpilledStats  uint64
+		SpilledVotes  uint64
+		BlockPageLen  uint64
+		StatsPageLen  uint64
+		PageInHit     uint64
+		PageInMiss    uint64
+	}
 	ValidatorCache struct {
 		Validators        uint64
+		Segments          uint64
+		SegmentSize       uint64
+		PendingPersist    uint64
 		ValidatorDiffs    uint64
 		ValidatorData     uint64
 		ValidatorActivity uint64
@@ -57,6 +73,7 @@ func (indexer *Indexer) GetCacheDebugStats() *CacheDebugStats {
 	indexer.getBlockCacheDebugStats(cacheStats)
 	indexer.getEpochCacheDebugStats(cacheStats)
 	indexer.getForkCacheDebugStats(cacheStats)
+	indexer.getSpillCacheDebugStats(cacheStats)
 	indexer.getValidatorCacheDebugStats(cacheStats)
 	return cacheStats
 }
@@ -151,24 +168,51 @@ func (indexer *Indexer) getForkCacheDebugStats(cacheStats *CacheDebugStats) {
 	cacheStats.ForkCache.ParentIdsCacheMiss = indexer.forkCache.parentIdsCacheMiss
 }
 
+func (indexer *Indexer) getSpillCacheDebugStats(cacheStats *CacheDebugStats) {
+	if !indexer.spillCache.isEnabled() {
+		return
+	}
+
+	cacheStats.SpillCache.Enabled = true
+	cacheStats.SpillCache.SpilledSlot = uint64(indexer.spillCache.getSpilledSlot())
+	cacheStats.SpillCache.SpilledEpoch = uint64(indexer.spillCache.getSpilledEpoch())
+	cacheStats.SpillCache.SpilledBlocks = indexer.spillCache.spilledBlocks
+	cacheStats.SpillCache.SpilledStats = indexer.spillCache.spilledStats
+	cacheStats.SpillCache.SpilledVotes = indexer.spillCache.spilledVotes
+	cacheStats.SpillCache.BlockPageLen = uint64(indexer.spillCache.blockPage.Len())
+	cacheStats.SpillCache.StatsPageLen = uint64(indexer.spillCache.statsPage.Len())
+	cacheStats.SpillCache.PageInHit = indexer.spillCache.pageInHit
+	cacheStats.SpillCache.PageInMiss = indexer.spillCache.pageInMiss
+}
+
 func (indexer *Indexer) getValidatorCacheDebugStats(cacheStats *CacheDebugStats) {
 	indexer.validatorCache.cacheMutex.RLock()
 	defer indexer.validatorCache.cacheMutex.RUnlock()
 
-	cacheStats.ValidatorCache.Validators = uint64(len(indexer.validatorCache.valsetCache))
+	cacheStats.ValidatorCache.Validators = indexer.validatorCache.validatorCount
+	cacheStats.ValidatorCache.Segments = uint64(len(indexer.validatorCache.segments))
+	cacheStats.ValidatorCache.SegmentSize = uint64(len(indexer.validatorCache.segments)) * uint64(unsafe.Sizeof(validatorSegment{}))
 
 	validatorsMap := map[*phase0.Validator]bool{}
-	for _, validator := range indexer.validatorCache.valsetCache {
-		refs := len(validator.validatorDiffs)
-		for _, diff := range validator.validatorDiffs {
-			validatorsMap[diff.validator] = true
+	for _, segment := range indexer.validatorCache.segments {
+		if segment.pending == nil {
+			continue
 		}
 
-		if validator.finalValidator != nil {
-			validatorsMap[validator.finalValidator] = true
-			refs++
+		cacheStats.ValidatorCache.PendingPersist += uint64(segment.pending.count)
+		cacheStats.ValidatorCache.SegmentSize += uint64(unsafe.Sizeof(validatorSegmentPending{}))
+		for _, states := range segment.pending.states {
+			for _, state := range states {
+				validatorsMap[state.validator] = true
+			}
+		}
+	}
+
+	for _, validatorDiffs := range indexer.validatorCache.validatorDiffs {
+		cacheStats.ValidatorCache.ValidatorDiffs += uint64(len(validatorDiffs))
+		for _, diff := range validatorDiffs {
+			validatorsMap[diff.validator] = true
 		}
-		cacheStats.ValidatorCache.ValidatorDiffs += uint64(refs)
 	}
 
 	cacheStats.ValidatorCache.ValidatorData = uint64(len(validatorsMap))
@@ -73,7 +73,11 @@ func (cache *epochCache) createOrGetEpochStats(epoch phase0.Epoch, dependentRoot
 
 	epochStats := cache.statsMap[statsKey]
 	if epochStats == nil {
-		epochStats = newEpochStats(epoch, dependentRoot)
+		// bring spilled epoch stats back to the cache if available
+		epochStats = cache.indexer.spillCache.unspillEpochStats(epoch, dependentRoot)
+		if epochStats == nil {
+			epochStats = newEpochStats(epoch, dependentRoot)
+		}
 		cache.statsMap[statsKey] = epochStats
 	}
 
@@ -118,11 +122,15 @@ func (cache *epochCache) addEpochStateRequest(epochStats *EpochStats) {
 
 func (cache *epochCache) getEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root) *EpochStats {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	statsKey := getEpochStatsKey(epoch, dependentRoot)
+	epochStats := cache.statsMap[statsKey]
+	cache.cacheMutex.RUnlock()
+
+	if epochStats == nil {
+		epochStats = cache.indexer.spillCache.getEpochStats(epoch, dependentRoot)
+	}
 
-	return cache.statsMap[statsKey]
+	return epochStats
 }
 
 // getPendingEpochStats gets all EpochStats with unloaded epochStates.
@@ -142,24 +150,48 @@ func (cache *epochCache) getPendingEpochStats() []*EpochStats {
 
 func (cache *epochCache) getEpochStatsByEpoch(epoch phase0.Epoch) []*EpochStats {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	statsList := []*EpochStats{}
 	for _, stats := range cache.statsMap {
 		if stats.epoch == epoch {
 			statsList = append(statsList, stats)
 		}
 	}
+	cache.cacheMutex.RUnlock()
+
+	return cache.appendSpilledEpochStats(statsList, cache.indexer.spillCache.getEpochStatsByEpoch(epoch))
+}
+
+// appendSpilledEpochStats appends the spilled epoch stats to the given list of cached epoch stats, skipping duplicates.
+func (cache *epochCache) appendSpilledEpochStats(statsList []*EpochStats, spilledStats []*EpochStats) []*EpochStats {
+	for _, spilledEpochStats := range spilledStats {
+		isDuplicate := false
+		for _, stats := range statsList {
+			if stats.epoch == spilledEpochStats.epoch && stats.dependentRoot == spilledEpochStats.dependentRoot {
+				isDuplicate = true
+				break
+			}
+		}
+
+		if !isDuplicate {
+			statsList = append(statsList, spilledEpochStats)
+		}
+	}
 
 	return statsList
 }
 
 func (cache *epochCache) getEpochStatsByEpochAndRoot(epoch phase0.Epoch, blockRoot phase0.Root) *EpochStats {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
-
 	for _, stats := range cache.statsMap {
 		if stats.epoch == epoch && cache.indexer.blockCache.isCanonicalBlock(stats.dependentRoot, blockRoot) {
+			cache.cacheMutex.RUnlock()
+			return stats
+		}
+	}
+	cache.cacheMutex.RUnlock()
+
+	for _, stats := range cache.indexer.spillCache.getEpochStatsByEpoch(epoch) {
+		if cache.indexer.blockCache.isCanonicalBlock(stats.dependentRoot, blockRoot) {
 			return stats
 		}
 	}
@@ -190,6 +222,7 @@ func (cache *epochCache) removeEpochStats(epochStats *EpochStats) {
 	statsKey := getEpochStatsKey(epochStats.epoch, epochStats.dependentRoot)
 
 	if cache.statsMap[statsKey] == nil {
+		cache.indexer.spillCache.removeEpochStats(epochStats.epoch, epochStats.dependentRoot)
 		return
 	}
 
@@ -212,6 +245,42 @@ func (cache *epochCache) removeEpochStats(epochStats *EpochStats) {
 	}
 }
 
+// spillEpochStats moves all pruned epoch stats before the given epoch to the spill cache.
+// the epoch stats are removed from the stats map and paged back in from the spill cache on demand.
+func (cache *epochCache) spillEpochStats(spillEpoch phase0.Epoch) (uint64, error) {
+	cache.cacheMutex.RLock()
+	statsList := []*EpochStats{}
+	for _, stats := range cache.statsMap {
+		if stats.epoch >= spillEpoch || stats.dependentState != nil || stats.values != nil || stats.processing {
+			continue
+		}
+
+		statsList = append(statsList, stats)
+	}
+	cache.cacheMutex.RUnlock()
+
+	if len(statsList) == 0 {
+		return 0, nil
+	}
+
+	// write epoch stats to disk before removing them from the map, so lookups never miss them
+	if err := cache.indexer.spillCache.addEpochStats(statsList); err != nil {
+		return 0, err
+	}
+
+	cache.cacheMutex.Lock()
+	defer cache.cacheMutex.Unlock()
+
+	for _, stats := range statsList {
+		statsKey := getEpochStatsKey(stats.epoch, stats.dependentRoot)
+		if cache.statsMap[statsKey] == stats {
+			delete(cache.statsMap, statsKey)
+		}
+	}
+
+	return uint64(len(statsList)), nil
+}
+
 func (cache *epochCache) removeEpochStatsByEpoch(epoch phase0.Epoch) {
 	for _, stats := range cache.getEpochStatsByEpoch(epoch) {
 		cache.removeEpochStats(stats)
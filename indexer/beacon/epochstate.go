@@ -26,7 +26,9 @@ type epochState struct {
 	validatorBalances         []phase0.Gwei
 	randaoMixes               []phase0.Root
 	depositIndex              uint64
+	finalizedCheckpoint       *phase0.Checkpoint
 	syncCommittee             []phase0.ValidatorIndex
+	nextSyncCommittee         []phase0.ValidatorIndex
 	pendingPartialWithdrawals []*electra.PendingPartialWithdrawal
 	pendingConsolidations     []*electra.PendingConsolidation
 }
@@ -174,6 +176,7 @@ func (s *epochState) processState(state *spec.VersionedBeaconState, cache *epoch
 
 	s.randaoMixes = randaoMixes
 	s.depositIndex = getStateDepositIndex(state)
+	s.finalizedCheckpoint = getStateFinalizedCheckpoint(state)
 
 	if state.Version >= spec.DataVersionAltair {
 		currentSyncCommittee, err := getStateCurrentSyncCommittee(state)
@@ -189,8 +192,19 @@ func (s *epochState) processState(state *spec.VersionedBeaconState, cache *epoch
 			syncCommittee = cache.getOrUpdateSyncCommittee(syncCommittee)
 		}
 		s.syncCommittee = syncCommittee
+
+		nextSyncCommittee, err := getStateNextSyncCommittee(state)
+		if err != nil {
+			return fmt.Errorf("error getting next sync committee from state %v: %v", s.slotRoot.String(), err)
+		}
+
+		s.nextSyncCommittee = make([]phase0.ValidatorIndex, len(nextSyncCommittee))
+		for i, v := range nextSyncCommittee {
+			s.nextSyncCommittee[i] = validatorPubkeyMap[v]
+		}
 	} else {
 		s.syncCommittee = []phase0.ValidatorIndex{}
+		s.nextSyncCommittee = []phase0.ValidatorIndex{}
 	}
 
 	if state.Version >= spec.DataVersionElectra {
@@ -51,6 +51,8 @@ type EpochStatsValues struct {
 	ProposerDuties        []phase0.ValidatorIndex
 	AttesterDuties        [][][]duties.ActiveIndiceIndex
 	SyncCommitteeDuties   []phase0.ValidatorIndex
+	NextSyncCommittee     []phase0.ValidatorIndex // not persisted, only available for epochs processed from a loaded state
+	FinalizedCheckpoint   *phase0.Checkpoint      // not persisted, only available for epochs processed from a loaded state
 	ActiveValidators      uint64
 	TotalBalance          phase0.Gwei
 	ActiveBalance         phase0.Gwei
@@ -289,6 +291,7 @@ func (es *EpochStats) pruneValues() {
 		ProposerDuties:        es.values.ProposerDuties,
 		AttesterDuties:        nil, // prune
 		SyncCommitteeDuties:   es.values.SyncCommitteeDuties,
+		NextSyncCommittee:     es.values.NextSyncCommittee,
 		ActiveValidators:      es.values.ActiveValidators,
 		TotalBalance:          es.values.TotalBalance,
 		ActiveBalance:         es.values.ActiveBalance,
@@ -296,6 +299,7 @@ func (es *EpochStats) pruneValues() {
 		FirstDepositIndex:     es.values.FirstDepositIndex,
 		PendingWithdrawals:    nil, // prune
 		PendingConsolidations: nil, // prune
+		FinalizedCheckpoint:   es.values.FinalizedCheckpoint,
 	}
 
 	es.values = nil
@@ -349,6 +353,8 @@ func (es *EpochStats) processState(indexer *Indexer, validatorSet []*phase0.Vali
 		ActiveIndices:         make([]phase0.ValidatorIndex, 0),
 		EffectiveBalances:     make([]uint16, 0),
 		SyncCommitteeDuties:   dependentState.syncCommittee,
+		NextSyncCommittee:     dependentState.nextSyncCommittee,
+		FinalizedCheckpoint:   dependentState.finalizedCheckpoint,
 		TotalBalance:          0,
 		ActiveBalance:         0,
 		EffectiveBalance:      0,
@@ -472,6 +478,26 @@ func (es *EpochStats) processState(indexer *Indexer, validatorSet []*phase0.Vali
 	es.setStatsReady()
 }
 
+// getPrecomputedSyncCommittees returns the sync committee & next sync committee of an epoch precomputed from its parent epoch.
+// When the epoch starts a new sync committee period, the next committee of the parent becomes the current committee
+// and the next committee is unknown until a state of the new period has been loaded.
+func getPrecomputedSyncCommittees(specs *consensus.ChainSpec, epoch phase0.Epoch, parentEpoch phase0.Epoch, parentValues *EpochStatsValues) ([]phase0.ValidatorIndex, []phase0.ValidatorIndex) {
+	if specs.EpochsPerSyncCommitteePeriod == 0 {
+		return parentValues.SyncCommitteeDuties, parentValues.NextSyncCommittee
+	}
+
+	period := uint64(epoch) / specs.EpochsPerSyncCommitteePeriod
+	parentPeriod := uint64(parentEpoch) / specs.EpochsPerSyncCommitteePeriod
+	switch {
+	case period == parentPeriod:
+		return parentValues.SyncCommitteeDuties, parentValues.NextSyncCommittee
+	case period == parentPeriod+1:
+		return parentValues.NextSyncCommittee, nil
+	default:
+		return nil, nil
+	}
+}
+
 // precomputeFromParentState precomputes the EpochStats values based on the parent state.
 func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *EpochStats) error {
 	es.precalcBaseRoot = parentState.dependentRoot
@@ -499,14 +525,19 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 			return fmt.Errorf("parent stats values not available")
 		}
 
+		chainState := indexer.consensusPool.GetChainState()
+		syncCommittee, nextSyncCommittee := getPrecomputedSyncCommittees(chainState.GetSpecs(), es.epoch, parentState.epoch, parentStatsValues)
+
 		values := &EpochStatsValues{
 			ActiveIndices:       parentStatsValues.ActiveIndices,
 			RandaoMix:           parentStatsValues.NextRandaoMix,
 			EffectiveBalances:   parentStatsValues.EffectiveBalances,
-			SyncCommitteeDuties: parentStatsValues.SyncCommitteeDuties,
+			SyncCommitteeDuties: syncCommittee,
+			NextSyncCommittee:   nextSyncCommittee,
 			TotalBalance:        parentStatsValues.TotalBalance,
 			ActiveBalance:       parentStatsValues.ActiveBalance,
 			EffectiveBalance:    parentStatsValues.EffectiveBalance,
+			FinalizedCheckpoint: parentStatsValues.FinalizedCheckpoint,
 		}
 
 		// update active validators from validator cache
@@ -535,7 +566,6 @@ func (es *EpochStats) precomputeFromParentState(indexer *Indexer, parentState *E
 				return phase0.Gwei(values.EffectiveBalances[index]) * EtherGweiFactor
 			},
 		}
-		chainState := indexer.consensusPool.GetChainState()
 
 		// compute proposers
 		proposerDuties := []phase0.ValidatorIndex{}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 )
@@ -131,3 +132,64 @@ func contains(s, substr string) bool {
 	}
 	return false
 }
+
+func TestGetPrecomputedSyncCommittees(t *testing.T) {
+	specs := &consensus.ChainSpec{
+		EpochsPerSyncCommitteePeriod: 256,
+	}
+	parentValues := &EpochStatsValues{
+		SyncCommitteeDuties: []phase0.ValidatorIndex{1, 2},
+		NextSyncCommittee:   []phase0.ValidatorIndex{3, 4},
+	}
+
+	t.Run("SamePeriod", func(t *testing.T) {
+		current, next := getPrecomputedSyncCommittees(specs, 300, 299, parentValues)
+		if len(current) != 2 || current[0] != 1 || len(next) != 2 || next[0] != 3 {
+			t.Errorf("expected parent committees, got %v / %v", current, next)
+		}
+	})
+
+	t.Run("PeriodBoundary", func(t *testing.T) {
+		current, next := getPrecomputedSyncCommittees(specs, 512, 511, parentValues)
+		if len(current) != 2 || current[0] != 3 || current[1] != 4 {
+			t.Errorf("expected next committee of the parent as current committee, got %v", current)
+		}
+		if next != nil {
+			t.Errorf("expected unknown next committee, got %v", next)
+		}
+	})
+
+	t.Run("PeriodBoundaryUnknownNext", func(t *testing.T) {
+		current, next := getPrecomputedSyncCommittees(specs, 512, 511, &EpochStatsValues{
+			SyncCommitteeDuties: []phase0.ValidatorIndex{1, 2},
+		})
+		if current != nil || next != nil {
+			t.Errorf("expected unknown committees, got %v / %v", current, next)
+		}
+	})
+
+	t.Run("SkippedPeriod", func(t *testing.T) {
+		current, next := getPrecomputedSyncCommittees(specs, 768, 511, parentValues)
+		if current != nil || next != nil {
+			t.Errorf("expected unknown committees, got %v / %v", current, next)
+		}
+	})
+}
+
+func TestEpochStatsPruneValuesKeepsFinalizedCheckpoint(t *testing.T) {
+	checkpoint := &phase0.Checkpoint{Epoch: 41, Root: phase0.Root{0x41}}
+	es := &EpochStats{
+		epoch: 42,
+		values: &EpochStatsValues{
+			ActiveValidators:    100,
+			FinalizedCheckpoint: checkpoint,
+		},
+	}
+
+	es.pruneValues()
+
+	values := es.GetValues(false)
+	if values == nil || values.FinalizedCheckpoint != checkpoint {
+		t.Errorf("expected finalized checkpoint to be kept in pruned values, got %v", values)
+	}
+}
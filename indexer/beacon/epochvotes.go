@@ -73,9 +73,23 @@ func (indexer *Indexer) aggregateEpochVotes(epoch phase0.Epoch, chainState *cons
 		return cachedVotes
 	}
 
+	// votes of pruned epochs do not change anymore, so they're kept in the spill cache
+	isPrunedEpoch := epoch < indexer.lastPrunedEpoch
+	if isPrunedEpoch {
+		if spilledVotes := indexer.spillCache.getEpochVotes(epoch, votesKey); spilledVotes != nil {
+			indexer.epochCache.votesCache.Add(votesKey, spilledVotes)
+			indexer.epochCache.votesCacheHit++
+			return spilledVotes
+		}
+	}
+
 	votes := indexer.aggregateEpochVotesAndActivity(epoch, chainState, blocks, epochStats)
 	indexer.epochCache.votesCacheMiss++
 
+	if isPrunedEpoch {
+		indexer.spillCache.addEpochVotes(epoch, votesKey, votes)
+	}
+
 	return votes
 }
 
@@ -0,0 +1,281 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/altair"
+	"github.com/attestantio/go-eth2-client/spec/bellatrix"
+	"github.com/attestantio/go-eth2-client/spec/capella"
+	"github.com/attestantio/go-eth2-client/spec/deneb"
+	"github.com/attestantio/go-eth2-client/spec/electra"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/golang/snappy"
+	dynssz "github.com/pk910/dynamic-ssz"
+)
+
+// e2store entry types used in .era files
+// https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md
+var (
+	e2storeTypeCompressedBlock = [2]byte{0x01, 0x00}
+	e2storeTypeCompressedState = [2]byte{0x02, 0x00}
+	e2storeTypeSlotIndex       = [2]byte{0x69, 0x32}
+)
+
+const e2storeHeaderSize = 8
+
+// sanity limits for values read from (possibly corrupt) era files
+const (
+	eraMaxSlotIndexCount = 1 << 20 // way above SLOTS_PER_HISTORICAL_ROOT of all known networks
+	eraMaxEntrySize      = 1 << 30 // compressed mainnet states are well below 1GB
+)
+
+// eraFile provides access to the blocks & state stored in a .era file.
+// An era file contains the blocks of up to SLOTS_PER_HISTORICAL_ROOT slots and the state at the end of that range.
+type eraFile struct {
+	path           string
+	file           *os.File
+	fileSize       int64
+	blockStartSlot phase0.Slot
+	blockOffsets   []int64
+	stateSlot      phase0.Slot
+	stateOffset    int64
+}
+
+// openEraFile opens an era file and reads its slot indexes.
+func openEraFile(path string) (*eraFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	era := &eraFile{
+		path: path,
+		file: file,
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	era.fileSize = fileInfo.Size()
+
+	// the state index is the last entry of the file, the block index (if any) precedes it
+	stateIndexPos, stateIndex, err := era.readSlotIndex(fileInfo.Size())
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("error reading state index: %v", err)
+	}
+	if len(stateIndex.offsets) != 1 {
+		file.Close()
+		return nil, fmt.Errorf("invalid state index with %v entries", len(stateIndex.offsets))
+	}
+
+	era.stateSlot = phase0.Slot(stateIndex.startSlot)
+	era.stateOffset = stateIndexPos + stateIndex.offsets[0]
+
+	if era.stateSlot > 0 {
+		blockIndexPos, blockIndex, err := era.readSlotIndex(stateIndexPos)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("error reading block index: %v", err)
+		}
+
+		era.blockStartSlot = phase0.Slot(blockIndex.startSlot)
+		era.blockOffsets = make([]int64, len(blockIndex.offsets))
+		for i, offset := range blockIndex.offsets {
+			if offset != 0 {
+				era.blockOffsets[i] = blockIndexPos + offset
+			}
+		}
+	}
+
+	return era, nil
+}
+
+type eraSlotIndex struct {
+	startSlot uint64
+	offsets   []int64
+}
+
+// readSlotIndex reads the slot index entry that ends at the given file position.
+func (era *eraFile) readSlotIndex(endPos int64) (int64, *eraSlotIndex, error) {
+	if endPos < e2storeHeaderSize+16 {
+		return 0, nil, fmt.Errorf("no slot index before position %v", endPos)
+	}
+
+	countBuf := make([]byte, 8)
+	if _, err := era.file.ReadAt(countBuf, endPos-8); err != nil {
+		return 0, nil, err
+	}
+
+	rawCount := binary.LittleEndian.Uint64(countBuf)
+	if rawCount > eraMaxSlotIndexCount {
+		return 0, nil, fmt.Errorf("invalid slot index count: %v", rawCount)
+	}
+
+	count := int64(rawCount)
+	entryPos := endPos - e2storeHeaderSize - 16 - count*8
+	if entryPos < 0 {
+		return 0, nil, fmt.Errorf("invalid slot index count: %v", count)
+	}
+
+	entryType, data, err := era.readEntry(entryPos)
+	if err != nil {
+		return 0, nil, err
+	}
+	if entryType != e2storeTypeSlotIndex {
+		return 0, nil, fmt.Errorf("unexpected entry type 0x%x", entryType)
+	}
+	if int64(len(data)) != 16+count*8 {
+		return 0, nil, fmt.Errorf("invalid slot index size %v for %v entries", len(data), count)
+	}
+
+	index := &eraSlotIndex{
+		startSlot: binary.LittleEndian.Uint64(data[0:8]),
+		offsets:   make([]int64, count),
+	}
+	for i := int64(0); i < count; i++ {
+		index.offsets[i] = int64(binary.LittleEndian.Uint64(data[8+i*8 : 16+i*8]))
+	}
+
+	return entryPos, index, nil
+}
+
+// readEntry reads the e2store entry at the given file position.
+func (era *eraFile) readEntry(pos int64) ([2]byte, []byte, error) {
+	var entryType [2]byte
+
+	header := make([]byte, e2storeHeaderSize)
+	if _, err := era.file.ReadAt(header, pos); err != nil {
+		return entryType, nil, err
+	}
+
+	copy(entryType[:], header[0:2])
+	length := binary.LittleEndian.Uint32(header[2:6])
+	if length > eraMaxEntrySize || pos+e2storeHeaderSize+int64(length) > era.fileSize {
+		return entryType, nil, fmt.Errorf("invalid entry length %v at position %v", length, pos)
+	}
+
+	data := make([]byte, length)
+	if _, err := era.file.ReadAt(data, pos+e2storeHeaderSize); err != nil {
+		return entryType, nil, err
+	}
+
+	return entryType, data, nil
+}
+
+// readCompressedEntry reads and decompresses a snappy framed e2store entry of the given type.
+func (era *eraFile) readCompressedEntry(pos int64, expectedType [2]byte) ([]byte, error) {
+	entryType, data, err := era.readEntry(pos)
+	if err != nil {
+		return nil, err
+	}
+	if entryType != expectedType {
+		return nil, fmt.Errorf("unexpected entry type 0x%x", entryType)
+	}
+
+	return io.ReadAll(snappy.NewReader(bytes.NewReader(data)))
+}
+
+// readBlockSSZ returns the ssz encoded block for the given slot, or nil if the slot is empty.
+func (era *eraFile) readBlockSSZ(slot phase0.Slot) ([]byte, error) {
+	if slot < era.blockStartSlot || slot >= era.blockStartSlot+phase0.Slot(len(era.blockOffsets)) {
+		return nil, nil
+	}
+
+	offset := era.blockOffsets[slot-era.blockStartSlot]
+	if offset == 0 {
+		return nil, nil
+	}
+
+	return era.readCompressedEntry(offset, e2storeTypeCompressedBlock)
+}
+
+// readStateSSZ returns the ssz encoded state at the end of the era.
+func (era *eraFile) readStateSSZ() ([]byte, error) {
+	return era.readCompressedEntry(era.stateOffset, e2storeTypeCompressedState)
+}
+
+func (era *eraFile) close() error {
+	return era.file.Close()
+}
+
+// getForkVersionAtEpoch returns the data version of the fork that is active at the given epoch.
+func getForkVersionAtEpoch(specs *consensus.ChainSpec, epoch phase0.Epoch) spec.DataVersion {
+	switch {
+	case specs.ElectraForkEpoch != nil && epoch >= phase0.Epoch(*specs.ElectraForkEpoch):
+		return spec.DataVersionElectra
+	case specs.DenebForkEpoch != nil && epoch >= phase0.Epoch(*specs.DenebForkEpoch):
+		return spec.DataVersionDeneb
+	case specs.CapellaForkEpoch != nil && epoch >= phase0.Epoch(*specs.CapellaForkEpoch):
+		return spec.DataVersionCapella
+	case specs.BellatrixForkEpoch != nil && epoch >= phase0.Epoch(*specs.BellatrixForkEpoch):
+		return spec.DataVersionBellatrix
+	case specs.AltairForkEpoch != nil && epoch >= phase0.Epoch(*specs.AltairForkEpoch):
+		return spec.DataVersionAltair
+	default:
+		return spec.DataVersionPhase0
+	}
+}
+
+// unmarshalVersionedBeaconStateSSZ unmarshals a ssz encoded beacon state of the given version.
+func unmarshalVersionedBeaconStateSSZ(dynSsz *dynssz.DynSsz, version spec.DataVersion, ssz []byte) (*spec.VersionedBeaconState, error) {
+	state := &spec.VersionedBeaconState{
+		Version: version,
+	}
+
+	var err error
+	switch version {
+	case spec.DataVersionPhase0:
+		state.Phase0 = &phase0.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Phase0, ssz)
+	case spec.DataVersionAltair:
+		state.Altair = &altair.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Altair, ssz)
+	case spec.DataVersionBellatrix:
+		state.Bellatrix = &bellatrix.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Bellatrix, ssz)
+	case spec.DataVersionCapella:
+		state.Capella = &capella.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Capella, ssz)
+	case spec.DataVersionDeneb:
+		state.Deneb = &deneb.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Deneb, ssz)
+	case spec.DataVersionElectra:
+		state.Electra = &electra.BeaconState{}
+		err = dynSsz.UnmarshalSSZ(state.Electra, ssz)
+	default:
+		return nil, fmt.Errorf("unknown state version")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %v beacon state: %v", version.String(), err)
+	}
+
+	return state, nil
+}
+
+// getStateGenesis returns the genesis time & validators root from a versioned beacon state.
+func getStateGenesis(state *spec.VersionedBeaconState) (uint64, phase0.Root) {
+	switch state.Version {
+	case spec.DataVersionPhase0:
+		return state.Phase0.GenesisTime, state.Phase0.GenesisValidatorsRoot
+	case spec.DataVersionAltair:
+		return state.Altair.GenesisTime, state.Altair.GenesisValidatorsRoot
+	case spec.DataVersionBellatrix:
+		return state.Bellatrix.GenesisTime, state.Bellatrix.GenesisValidatorsRoot
+	case spec.DataVersionCapella:
+		return state.Capella.GenesisTime, state.Capella.GenesisValidatorsRoot
+	case spec.DataVersionDeneb:
+		return state.Deneb.GenesisTime, state.Deneb.GenesisValidatorsRoot
+	case spec.DataVersionElectra:
+		return state.Electra.GenesisTime, state.Electra.GenesisValidatorsRoot
+	}
+	return 0, phase0.Root{}
+}
@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// buildEraEntry encodes a single e2store entry with the given type and data.
+func buildEraEntry(entryType [2]byte, length uint32, data []byte) []byte {
+	entry := make([]byte, e2storeHeaderSize, e2storeHeaderSize+len(data))
+	copy(entry[0:2], entryType[:])
+	binary.LittleEndian.PutUint32(entry[2:6], length)
+	return append(entry, data...)
+}
+
+// buildEraSlotIndex encodes the data of a slot index entry.
+func buildEraSlotIndex(startSlot uint64, offsets []int64) []byte {
+	data := make([]byte, 16+len(offsets)*8)
+	binary.LittleEndian.PutUint64(data[0:8], startSlot)
+	for i, offset := range offsets {
+		binary.LittleEndian.PutUint64(data[8+i*8:16+i*8], uint64(offset))
+	}
+	binary.LittleEndian.PutUint64(data[8+len(offsets)*8:], uint64(len(offsets)))
+	return data
+}
+
+func writeEraTestFile(t *testing.T, content []byte) string {
+	path := filepath.Join(t.TempDir(), "test.era")
+	if err := os.WriteFile(path, content, 0o600); err != nil {
+		t.Fatalf("failed writing era file: %v", err)
+	}
+	return path
+}
+
+func TestOpenEraFile(t *testing.T) {
+	t.Run("ValidStateIndex", func(t *testing.T) {
+		// genesis era: a state entry followed by a state index with a single offset
+		stateEntry := buildEraEntry(e2storeTypeCompressedState, 4, []byte{1, 2, 3, 4})
+		indexData := buildEraSlotIndex(0, []int64{-int64(len(stateEntry))})
+		content := append(stateEntry, buildEraEntry(e2storeTypeSlotIndex, uint32(len(indexData)), indexData)...)
+
+		era, err := openEraFile(writeEraTestFile(t, content))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer era.close()
+
+		if era.stateOffset != 0 {
+			t.Errorf("expected state offset 0, got %v", era.stateOffset)
+		}
+	})
+
+	t.Run("TruncatedSlotIndex", func(t *testing.T) {
+		// the trailing count claims 2 offsets, but the index entry header only covers the start slot
+		data := make([]byte, 8+24)
+		binary.LittleEndian.PutUint64(data[24:], 2)
+		content := buildEraEntry(e2storeTypeSlotIndex, 8, data)
+
+		if _, err := openEraFile(writeEraTestFile(t, content)); err == nil {
+			t.Errorf("expected error for truncated slot index")
+		}
+	})
+
+	t.Run("TruncatedFile", func(t *testing.T) {
+		stateEntry := buildEraEntry(e2storeTypeCompressedState, 4, []byte{1, 2, 3, 4})
+		indexData := buildEraSlotIndex(0, []int64{-int64(len(stateEntry))})
+		content := append(stateEntry, buildEraEntry(e2storeTypeSlotIndex, uint32(len(indexData)), indexData)...)
+
+		for _, size := range []int{0, 7, 16, len(content) - 1} {
+			if _, err := openEraFile(writeEraTestFile(t, content[:size])); err == nil {
+				t.Errorf("expected error for file truncated to %v bytes", size)
+			}
+		}
+	})
+
+	t.Run("HugeSlotIndexCount", func(t *testing.T) {
+		data := make([]byte, 24)
+		binary.LittleEndian.PutUint64(data[16:], 1<<62)
+		content := buildEraEntry(e2storeTypeSlotIndex, uint32(len(data)), data)
+
+		if _, err := openEraFile(writeEraTestFile(t, content)); err == nil {
+			t.Errorf("expected error for oversized slot index count")
+		}
+	})
+
+	t.Run("OversizedEntryLength", func(t *testing.T) {
+		// the index entry claims to be bigger than the file
+		indexData := buildEraSlotIndex(0, []int64{0})
+		content := buildEraEntry(e2storeTypeSlotIndex, 1<<31, indexData)
+
+		if _, err := openEraFile(writeEraTestFile(t, content)); err == nil {
+			t.Errorf("expected error for oversized entry length")
+		}
+	})
+}
@@ -0,0 +1,386 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/utils"
+	"github.com/jmoiron/sqlx"
+)
+
+// EraImportStats holds the statistics of an era file import.
+type EraImportStats struct {
+	Files            uint64
+	Blocks           uint64
+	Epochs           uint64
+	SkippedEpochs    uint64
+	IncompleteEpochs uint64
+}
+
+// eraImportState holds the unified data of an era state, which is used as dependent state for the epochs of the era.
+type eraImportState struct {
+	slot         phase0.Slot
+	epochState   *epochState
+	validatorSet []*phase0.Validator
+}
+
+// eraImportEpoch holds the blocks & dependent state of an epoch loaded from an era file.
+type eraImportEpoch struct {
+	epoch         phase0.Epoch
+	blocks        []*Block
+	dependentRoot phase0.Root
+	epochState    *epochState
+	validatorSet  []*phase0.Validator
+}
+
+// ImportEraFiles imports the finalized blocks & states from the given .era files into the database.
+// The epochs are processed in the same way as by the synchronizer, so a new instance can be seeded from era files without any beacon node.
+// The state stored in an era file is the state at the start of the following era, so it is used as dependent state for the first epoch
+// of the following era. Computed proposer duties are verified against the actual block proposers and epochs with mismatching duties are
+// imported without duties. All other epochs have no matching state in the era files, so their blocks are imported without duties as well.
+// The sync state is advanced across the whole imported range.
+func (indexer *Indexer) ImportEraFiles(ctx context.Context, paths []string) (*EraImportStats, error) {
+	chainState := indexer.consensusPool.GetChainState()
+	if chainState.GetSpecs() == nil {
+		return nil, fmt.Errorf("chain specs not loaded")
+	}
+	if indexer.dynSsz == nil {
+		indexer.initDynSsz()
+	}
+
+	eraFiles := make([]*eraFile, 0, len(paths))
+	defer func() {
+		for _, era := range eraFiles {
+			era.close()
+		}
+	}()
+
+	for _, path := range paths {
+		era, err := openEraFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error opening era file %v: %v", path, err)
+		}
+		eraFiles = append(eraFiles, era)
+	}
+
+	sort.Slice(eraFiles, func(i, j int) bool {
+		return eraFiles[i].stateSlot < eraFiles[j].stateSlot
+	})
+
+	stats := &EraImportStats{}
+	var prevState *eraImportState
+	var pendingEpoch *eraImportEpoch
+	var lastBlockRoot phase0.Root
+
+	for _, era := range eraFiles {
+		if ctx.Err() != nil {
+			return stats, ctx.Err()
+		}
+
+		t1 := time.Now()
+		eraState, err := indexer.loadEraState(era)
+		if err != nil {
+			return stats, fmt.Errorf("error loading state from era file %v: %v", era.path, err)
+		}
+
+		eraEpochs, blockCount, err := indexer.loadEraEpochs(era, prevState, eraState, &lastBlockRoot)
+		if err != nil {
+			return stats, fmt.Errorf("error loading blocks from era file %v: %v", era.path, err)
+		}
+
+		// the votes for an epoch are included in the blocks of the following epoch, so each epoch is imported once the next epoch is loaded
+		for _, eraEpoch := range eraEpochs {
+			if ctx.Err() != nil {
+				return stats, ctx.Err()
+			}
+
+			if pendingEpoch != nil {
+				var nextBlocks []*Block
+				if eraEpoch.epoch == pendingEpoch.epoch+1 {
+					nextBlocks = eraEpoch.blocks
+				}
+				if err := indexer.importEraEpoch(pendingEpoch, nextBlocks, stats); err != nil {
+					return stats, err
+				}
+			}
+			pendingEpoch = eraEpoch
+		}
+
+		prevState = eraState
+		stats.Files++
+		stats.Blocks += blockCount
+
+		indexer.logger.Infof("imported era file %v (slots %v-%v, %v blocks, %v ms)", era.path, era.blockStartSlot, era.stateSlot, blockCount, time.Since(t1).Milliseconds())
+	}
+
+	if pendingEpoch != nil {
+		if err := indexer.importEraEpoch(pendingEpoch, nil, stats); err != nil {
+			return stats, err
+		}
+
+		// the epoch stats processing stores the computed duties as unfinalized duties, which are not needed for finalized epochs
+		err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			return db.DeleteUnfinalizedDutiesBefore(uint64(pendingEpoch.epoch+1), tx)
+		})
+		if err != nil {
+			return stats, fmt.Errorf("error deleting unfinalized duties: %v", err)
+		}
+	}
+
+	return stats, nil
+}
+
+// loadEraState decodes the state of an era file and extracts the data required for the epoch processing.
+func (indexer *Indexer) loadEraState(era *eraFile) (*eraImportState, error) {
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	stateSSZ, err := era.readStateSSZ()
+	if err != nil {
+		return nil, err
+	}
+
+	stateVersion := getForkVersionAtEpoch(specs, chainState.EpochOfSlot(era.stateSlot))
+	state, err := unmarshalVersionedBeaconStateSSZ(indexer.dynSsz, stateVersion, stateSSZ)
+	if err != nil {
+		return nil, err
+	}
+
+	if chainState.GetGenesis() == nil {
+		genesisTime, genesisValidatorsRoot := getStateGenesis(state)
+		err = indexer.consensusPool.SetStaticGenesis(&v1.Genesis{
+			GenesisTime:           time.Unix(int64(genesisTime), 0),
+			GenesisValidatorsRoot: genesisValidatorsRoot,
+			GenesisForkVersion:    specs.GenesisForkVersion,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error setting genesis: %v", err)
+		}
+	}
+
+	validatorSet, err := state.Validators()
+	if err != nil {
+		return nil, err
+	}
+
+	eraState := &eraImportState{
+		slot:         era.stateSlot,
+		epochState:   newEpochState(phase0.Root{}),
+		validatorSet: validatorSet,
+	}
+	if err := eraState.epochState.processState(state, nil); err != nil {
+		return nil, err
+	}
+	eraState.epochState.loadingStatus = 2
+
+	for i, validator := range validatorSet {
+		indexer.pubkeyCache.Add(validator.PublicKey, phase0.ValidatorIndex(i))
+	}
+
+	return eraState, nil
+}
+
+// loadEraEpochs loads the blocks of an era file and assigns the previous era state as dependent state of the first epoch within the era.
+func (indexer *Indexer) loadEraEpochs(era *eraFile, prevState *eraImportState, eraState *eraImportState, lastBlockRoot *phase0.Root) ([]*eraImportEpoch, uint64, error) {
+	if len(era.blockOffsets) == 0 {
+		return nil, 0, nil
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	firstEpoch := chainState.EpochOfSlot(era.blockStartSlot)
+	lastEpoch := chainState.EpochOfSlot(era.stateSlot - 1)
+	eraEpochs := make([]*eraImportEpoch, 0, lastEpoch-firstEpoch+1)
+	for epoch := firstEpoch; epoch <= lastEpoch; epoch++ {
+		eraEpochs = append(eraEpochs, &eraImportEpoch{
+			epoch: epoch,
+		})
+	}
+
+	blockCount := uint64(0)
+	for i := range era.blockOffsets {
+		slot := era.blockStartSlot + phase0.Slot(i)
+		blockSSZ, err := era.readBlockSSZ(slot)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error reading block %v: %v", slot, err)
+		}
+		if blockSSZ == nil {
+			continue
+		}
+
+		blockVersion := getForkVersionAtEpoch(specs, chainState.EpochOfSlot(slot))
+		blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, uint64(blockVersion), blockSSZ)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error decoding block %v: %v", slot, err)
+		}
+
+		block, err := indexer.buildEraBlock(slot, blockBody)
+		if err != nil {
+			return nil, 0, fmt.Errorf("error building block %v: %v", slot, err)
+		}
+
+		eraEpoch := eraEpochs[chainState.EpochOfSlot(slot)-firstEpoch]
+		if len(eraEpoch.blocks) == 0 {
+			if slot == 0 {
+				eraEpoch.dependentRoot = block.Root
+			} else {
+				eraEpoch.dependentRoot = block.header.Message.ParentRoot
+			}
+		}
+		eraEpoch.blocks = append(eraEpoch.blocks, block)
+		blockCount++
+	}
+
+	// the deposit index at the start of the era is computed backwards from the deposit index in the era state
+	// and must match the deposit index of the previous era state
+	depositIndex := eraState.epochState.depositIndex
+	for _, eraEpoch := range eraEpochs {
+		for _, block := range eraEpoch.blocks {
+			deposits, _ := block.GetBlock().Deposits()
+			if uint64(len(deposits)) > depositIndex {
+				return nil, 0, fmt.Errorf("deposit count of era blocks exceeds the deposit index of the era state (%v)", eraState.epochState.depositIndex)
+			}
+			depositIndex -= uint64(len(deposits))
+		}
+	}
+	if prevState != nil && prevState.epochState.depositIndex != depositIndex {
+		return nil, 0, fmt.Errorf("deposit index mismatch between era states (expected %v, got %v)", prevState.epochState.depositIndex, depositIndex)
+	}
+
+	for _, eraEpoch := range eraEpochs {
+		if len(eraEpoch.blocks) == 0 {
+			eraEpoch.dependentRoot = *lastBlockRoot
+		} else {
+			*lastBlockRoot = eraEpoch.blocks[len(eraEpoch.blocks)-1].Root
+		}
+
+		// only the previous era state is an actual state of the era, it is the dependent state of the first epoch.
+		// the balances and validator set of all other epochs are unknown, so these epochs are imported without duties.
+		if prevState != nil && chainState.EpochOfSlot(prevState.slot) == eraEpoch.epoch {
+			eraEpoch.epochState = prevState.epochState
+			eraEpoch.validatorSet = prevState.validatorSet
+		}
+	}
+
+	return eraEpochs, blockCount, nil
+}
+
+// buildEraBlock creates a block instance with header from a signed beacon block loaded from an era file.
+func (indexer *Indexer) buildEraBlock(slot phase0.Slot, blockBody *spec.VersionedSignedBeaconBlock) (*Block, error) {
+	proposerIndex, err := blockBody.ProposerIndex()
+	if err != nil {
+		return nil, err
+	}
+	parentRoot, err := blockBody.ParentRoot()
+	if err != nil {
+		return nil, err
+	}
+	stateRoot, err := blockBody.StateRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	var body any
+	var signature phase0.BLSSignature
+	switch blockBody.Version {
+	case spec.DataVersionPhase0:
+		body, signature = blockBody.Phase0.Message.Body, blockBody.Phase0.Signature
+	case spec.DataVersionAltair:
+		body, signature = blockBody.Altair.Message.Body, blockBody.Altair.Signature
+	case spec.DataVersionBellatrix:
+		body, signature = blockBody.Bellatrix.Message.Body, blockBody.Bellatrix.Signature
+	case spec.DataVersionCapella:
+		body, signature = blockBody.Capella.Message.Body, blockBody.Capella.Signature
+	case spec.DataVersionDeneb:
+		body, signature = blockBody.Deneb.Message.Body, blockBody.Deneb.Signature
+	case spec.DataVersionElectra:
+		body, signature = blockBody.Electra.Message.Body, blockBody.Electra.Signature
+	default:
+		return nil, fmt.Errorf("unknown block version")
+	}
+
+	bodyRoot, err := indexer.dynSsz.HashTreeRoot(body)
+	if err != nil {
+		return nil, fmt.Errorf("error computing body root: %v", err)
+	}
+
+	header := &phase0.SignedBeaconBlockHeader{
+		Message: &phase0.BeaconBlockHeader{
+			Slot:          slot,
+			ProposerIndex: proposerIndex,
+			ParentRoot:    parentRoot,
+			StateRoot:     stateRoot,
+			BodyRoot:      bodyRoot,
+		},
+		Signature: signature,
+	}
+
+	blockRoot, err := indexer.dynSsz.HashTreeRoot(header.Message)
+	if err != nil {
+		return nil, fmt.Errorf("error computing block root: %v", err)
+	}
+
+	block := newBlock(indexer.dynSsz, blockRoot, slot)
+	block.SetHeader(header)
+	block.SetBlock(blockBody)
+
+	return block, nil
+}
+
+// importEraEpoch computes the epoch stats for an epoch loaded from an era file and persists the epoch to the db.
+// Epochs without dependent state (or with mismatching proposer duties) are persisted without duties.
+func (indexer *Indexer) importEraEpoch(eraEpoch *eraImportEpoch, nextBlocks []*Block, stats *EraImportStats) error {
+	if !utils.Config.Indexer.ResyncForceUpdate && db.IsEpochSynchronized(uint64(eraEpoch.epoch)) {
+		stats.SkippedEpochs++
+		return nil
+	}
+
+	var epochStats *EpochStats
+	if eraEpoch.epochState != nil {
+		epochStats = newEpochStats(eraEpoch.epoch, eraEpoch.dependentRoot)
+		epochStats.dependentState = eraEpoch.epochState
+		epochStats.processState(indexer, eraEpoch.validatorSet)
+
+		if !indexer.checkEraEpochProposers(epochStats, eraEpoch.blocks) {
+			indexer.logger.Warnf("computed proposer duties for epoch %v do not match the imported blocks, importing epoch without duties", eraEpoch.epoch)
+			epochStats = nil
+		}
+	}
+
+	if epochStats == nil {
+		stats.IncompleteEpochs++
+	}
+
+	err := indexer.persistSyncedEpoch(eraEpoch.epoch, eraEpoch.blocks, nextBlocks, epochStats, eraEpoch.validatorSet, true, true)
+	if err != nil {
+		return fmt.Errorf("error persisting epoch %v: %v", eraEpoch.epoch, err)
+	}
+
+	stats.Epochs++
+	return nil
+}
+
+// checkEraEpochProposers verifies the computed proposer duties against the proposers of the imported blocks.
+func (indexer *Indexer) checkEraEpochProposers(epochStats *EpochStats, blocks []*Block) bool {
+	epochStatsValues := epochStats.GetValues(false)
+	if epochStatsValues == nil {
+		return false
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+	for _, block := range blocks {
+		slotIndex := int(chainState.SlotToSlotIndex(block.Slot))
+		if slotIndex >= len(epochStatsValues.ProposerDuties) || epochStatsValues.ProposerDuties[slotIndex] != block.header.Message.ProposerIndex {
+			return false
+		}
+	}
+
+	return true
+}
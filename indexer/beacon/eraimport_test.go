@@ -0,0 +1,203 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/golang/snappy"
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// snappyCompressEraEntry compresses the data of an era entry with the snappy framing format.
+func snappyCompressEraEntry(t *testing.T, data []byte) []byte {
+	var buf bytes.Buffer
+	writer := snappy.NewBufferedWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		t.Fatalf("failed compressing era entry: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("failed compressing era entry: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// buildEraTestFile encodes an era file with the given blocks (nil for empty slots) and state, followed by the block & state index.
+func buildEraTestFile(t *testing.T, indexer *Indexer, startSlot phase0.Slot, blocks []*phase0.SignedBeaconBlock, state *phase0.BeaconState) []byte {
+	// era files start with a version entry
+	content := buildEraEntry([2]byte{0x65, 0x32}, 0, nil)
+	blockPositions := make([]int64, len(blocks))
+	for i, block := range blocks {
+		if block == nil {
+			continue
+		}
+
+		blockSSZ, err := indexer.dynSsz.MarshalSSZ(block)
+		if err != nil {
+			t.Fatalf("failed encoding block: %v", err)
+		}
+
+		blockPositions[i] = int64(len(content))
+		compressed := snappyCompressEraEntry(t, blockSSZ)
+		content = append(content, buildEraEntry(e2storeTypeCompressedBlock, uint32(len(compressed)), compressed)...)
+	}
+
+	stateSSZ, err := indexer.dynSsz.MarshalSSZ(state)
+	if err != nil {
+		t.Fatalf("failed encoding state: %v", err)
+	}
+	statePos := int64(len(content))
+	compressed := snappyCompressEraEntry(t, stateSSZ)
+	content = append(content, buildEraEntry(e2storeTypeCompressedState, uint32(len(compressed)), compressed)...)
+
+	blockIndexPos := int64(len(content))
+	blockOffsets := make([]int64, len(blocks))
+	for i, block := range blocks {
+		if block != nil {
+			blockOffsets[i] = blockPositions[i] - blockIndexPos
+		}
+	}
+	blockIndex := buildEraSlotIndex(uint64(startSlot), blockOffsets)
+	content = append(content, buildEraEntry(e2storeTypeSlotIndex, uint32(len(blockIndex)), blockIndex)...)
+
+	stateIndexPos := int64(len(content))
+	stateIndex := buildEraSlotIndex(uint64(state.Slot), []int64{statePos - stateIndexPos})
+	content = append(content, buildEraEntry(e2storeTypeSlotIndex, uint32(len(stateIndex)), stateIndex)...)
+
+	return content
+}
+
+// newEraTestState returns an empty phase0 state at the given slot with the given number of validators.
+func newEraTestState(slot phase0.Slot, validatorCount int) *phase0.BeaconState {
+	state := &phase0.BeaconState{
+		GenesisTime:                 1700000000,
+		Slot:                        slot,
+		Fork:                        &phase0.Fork{},
+		LatestBlockHeader:           &phase0.BeaconBlockHeader{},
+		BlockRoots:                  make([]phase0.Root, 8192),
+		StateRoots:                  make([]phase0.Root, 8192),
+		ETH1Data:                    &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+		RANDAOMixes:                 make([]phase0.Root, 65536),
+		Slashings:                   make([]phase0.Gwei, 8192),
+		JustificationBits:           bitfield.NewBitvector4(),
+		PreviousJustifiedCheckpoint: &phase0.Checkpoint{},
+		CurrentJustifiedCheckpoint:  &phase0.Checkpoint{},
+		FinalizedCheckpoint:         &phase0.Checkpoint{},
+	}
+
+	for i := 0; i < validatorCount; i++ {
+		state.Validators = append(state.Validators, &phase0.Validator{
+			PublicKey:             phase0.BLSPubKey{byte(i + 1)},
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      32000000000,
+			ExitEpoch:             FarFutureEpoch,
+			WithdrawableEpoch:     FarFutureEpoch,
+		})
+		state.Balances = append(state.Balances, 32000000000)
+	}
+
+	return state
+}
+
+// initTestDb initializes an empty sqlite db with the latest schema, which is closed when the test finishes.
+func initTestDb(t *testing.T) {
+	utils.Config = &types.Config{}
+	utils.Config.Database.Engine = "sqlite"
+	utils.Config.Database.Sqlite.File = t.TempDir() + "/dora.sqlite"
+	utils.Config.Database.Sqlite.MaxOpenConns = 10
+	utils.Config.Database.Sqlite.MaxIdleConns = 10
+	db.MustInitDB()
+	t.Cleanup(db.MustCloseDB)
+	if err := db.ApplyEmbeddedDbSchema(-2); err != nil {
+		t.Fatalf("failed applying db schema: %v", err)
+	}
+}
+
+func TestImportEraFilesMultipleEpochs(t *testing.T) {
+	initTestDb(t)
+
+	logger, _ := test.NewNullLogger()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	consensusPool := consensus.NewPool(context.Background(), logger)
+	err := consensusPool.SetStaticChainSpecs(map[string]interface{}{
+		"PRESET_BASE":                  "mainnet",
+		"SECONDS_PER_SLOT":             12 * time.Second,
+		"SLOTS_PER_EPOCH":              uint64(8),
+		"EPOCHS_PER_HISTORICAL_VECTOR": uint64(65536),
+		"EPOCHS_PER_SLASHINGS_VECTOR":  uint64(8192),
+		"TARGET_COMMITTEE_SIZE":        uint64(128),
+		"MAX_COMMITTEES_PER_SLOT":      uint64(64),
+		"SHUFFLE_ROUND_COUNT":          uint64(90),
+		"MAX_EFFECTIVE_BALANCE":        uint64(32000000000),
+	})
+	if err != nil {
+		t.Fatalf("failed setting chain specs: %v", err)
+	}
+
+	indexer := NewIndexer(logger, consensusPool)
+	indexer.initDynSsz()
+
+	// era covering the slots 16-31 (epoch 2 & 3) with an empty slot in each epoch
+	startSlot := phase0.Slot(16)
+	blocks := make([]*phase0.SignedBeaconBlock, 16)
+	blockCount := 0
+	for i := range blocks {
+		if i == 3 || i == 10 {
+			continue
+		}
+
+		blocks[i] = &phase0.SignedBeaconBlock{
+			Message: &phase0.BeaconBlock{
+				Slot:          startSlot + phase0.Slot(i),
+				ProposerIndex: phase0.ValidatorIndex(i % 4),
+				Body: &phase0.BeaconBlockBody{
+					ETH1Data: &phase0.ETH1Data{BlockHash: make([]byte, 32)},
+				},
+			},
+		}
+		blockCount++
+	}
+
+	eraPath := writeEraTestFile(t, buildEraTestFile(t, indexer, startSlot, blocks, newEraTestState(32, 4)))
+
+	stats, err := indexer.ImportEraFiles(context.Background(), []string{eraPath})
+	if err != nil {
+		t.Fatalf("unexpected import error: %v", err)
+	}
+
+	if stats.Epochs != 2 || stats.Blocks != uint64(blockCount) {
+		t.Errorf("expected 2 epochs with %v blocks, got %v epochs with %v blocks", blockCount, stats.Epochs, stats.Blocks)
+	}
+
+	// neither epoch has a dependent state in the era file, so both are imported without duties
+	if stats.IncompleteEpochs != 2 {
+		t.Errorf("expected 2 incomplete epochs, got %v", stats.IncompleteEpochs)
+	}
+
+	for _, epoch := range []uint64{2, 3} {
+		if !db.IsEpochSynchronized(epoch) {
+			t.Errorf("expected epoch %v to be synchronized", epoch)
+		}
+	}
+
+	if slots := db.GetSlotsRange(31, 16, false, false); len(slots) != blockCount {
+		t.Errorf("expected %v imported blocks, got %v", blockCount, len(slots))
+	}
+
+	syncState := dbtypes.IndexerSyncState{}
+	db.GetExplorerState("indexer.syncstate", &syncState)
+	if syncState.Epoch != 3 {
+		t.Errorf("expected sync state at epoch 3, got %v", syncState.Epoch)
+	}
+}
@@ -0,0 +1,31 @@
+package beacon
+
+import (
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/clients/consensus"
+)
+
+// FinalizedEpochEvent is fired after an epoch has been finalized and persisted to the database.
+type FinalizedEpochEvent struct {
+	Epoch            phase0.Epoch
+	CanonicalBlocks  []*Block
+	OrphanedBlocks   []*Block
+	EpochStatsValues *EpochStatsValues // nil if the epoch stats could not be loaded
+	EpochVotes       *EpochVotes       // nil if the epoch stats could not be loaded
+}
+
+// SubscribeBlockEvent subscribes to new unfinalized blocks processed by the indexer.
+func (indexer *Indexer) SubscribeBlockEvent(capacity int) *consensus.Subscription[*Block] {
+	return indexer.blockDispatcher.Subscribe(capacity, false)
+}
+
+// SubscribeForkEvent subscribes to newly detected forks.
+func (indexer *Indexer) SubscribeForkEvent(capacity int) *consensus.Subscription[*Fork] {
+	return indexer.forkDispatcher.Subscribe(capacity, false)
+}
+
+// SubscribeFinalizedEpochEvent subscribes to finalized epochs processed by the indexer.
+func (indexer *Indexer) SubscribeFinalizedEpochEvent(capacity int) *consensus.Subscription[*FinalizedEpochEvent] {
+	return indexer.finalizedEpochDispatcher.Subscribe(capacity, false)
+}
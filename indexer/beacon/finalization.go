@@ -397,6 +397,14 @@ func (indexer *Indexer) finalizeEpoch(epoch phase0.Epoch, justifiedRoot phase0.R
 		indexer.forkCache.removeFork(fork.forkId)
 	}
 
+	indexer.finalizedEpochDispatcher.Fire(&FinalizedEpochEvent{
+		Epoch:            epoch,
+		CanonicalBlocks:  canonicalBlocks,
+		OrphanedBlocks:   orphanedBlocks,
+		EpochStatsValues: epochStatsValues,
+		EpochVotes:       epochVotes,
+	})
+
 	// clean epoch stats
 	indexer.epochCache.removeEpochStatsByEpoch(epoch)
 
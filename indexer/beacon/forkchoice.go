@@ -0,0 +1,328 @@
+package beacon
+
+import (
+	"bytes"
+	"math"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/prysmaticlabs/go-bitfield"
+)
+
+// ForkChoiceNode represents a single block in the fork choice tree.
+type ForkChoiceNode struct {
+	Block         *Block
+	ParentRoot    phase0.Root
+	HasParent     bool        // parent block is part of the tree
+	ForkId        ForkKey     // fork id assigned by the fork detection
+	Weight        phase0.Gwei // weight of the latest messages voting for this block
+	BranchWeight  phase0.Gwei // weight of this block including all descendants
+	VoteCount     uint64      // number of latest messages voting for this block
+	IsHeadChain   bool        // block is part of the chain selected by the replayed fork choice
+	IsHead        bool        // block is the head selected by the replayed fork choice
+	IsJustified   bool        // block is the latest justified checkpoint as seen by the attesters
+	IsFinalized   bool        // block is the current finalized checkpoint
+	ChildrenRoots []phase0.Root
+}
+
+// ForkChoiceTree represents the fork choice tree of all unfinalized blocks as seen at a specific slot.
+type ForkChoiceTree struct {
+	ReplaySlot     phase0.Slot
+	Nodes          []*ForkChoiceNode // sorted ascending by slot
+	HeadRoot       phase0.Root
+	JustifiedEpoch phase0.Epoch
+	JustifiedRoot  phase0.Root
+	FinalizedEpoch phase0.Epoch
+	FinalizedRoot  phase0.Root
+	TotalWeight    phase0.Gwei
+	AmountIsCount  bool // weights are vote counts as the validator set was not available for all votes
+}
+
+// forkChoiceVoter identifies a single voter, either by validator index or by its position in the committee (if duties are unknown).
+type forkChoiceVoter struct {
+	validator phase0.ValidatorIndex
+	position  voteDeduplicationKey
+}
+
+type forkChoiceMessage struct {
+	slot   phase0.Slot
+	root   phase0.Root
+	weight phase0.Gwei
+}
+
+// GetForkChoiceTree builds the fork choice tree of all cached blocks up to the given replay slot.
+// Vote weights are computed from the latest attestation of each validator that was included in a block up to the replay slot.
+func (indexer *Indexer) GetForkChoiceTree(replaySlot phase0.Slot) *ForkChoiceTree {
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+
+	tree := &ForkChoiceTree{
+		ReplaySlot: replaySlot,
+		Nodes:      []*ForkChoiceNode{},
+	}
+	blocks := indexer.blockCache.getLatestBlocks(0, nil)
+	slices.Reverse(blocks)
+
+	nodeMap := map[phase0.Root]*ForkChoiceNode{}
+	for _, block := range blocks {
+		if block.Slot > replaySlot {
+			break
+		}
+
+		node := &ForkChoiceNode{
+			Block:  block,
+			ForkId: block.forkId,
+		}
+		if parentRoot := block.GetParentRoot(); parentRoot != nil {
+			node.ParentRoot = *parentRoot
+			if parentNode := nodeMap[*parentRoot]; parentNode != nil {
+				node.HasParent = true
+				parentNode.ChildrenRoots = append(parentNode.ChildrenRoots, block.Root)
+			}
+		}
+		nodeMap[block.Root] = node
+		tree.Nodes = append(tree.Nodes, node)
+	}
+
+	if len(tree.Nodes) == 0 {
+		return tree
+	}
+
+	// collect the latest message of each validator from attestations included up to the replay slot
+	latestMessages := map[forkChoiceVoter]*forkChoiceMessage{}
+	// epoch stats values are resolved once per epoch stats, as loading them might require a db lookup
+	epochStatsValuesCache := map[*EpochStats]*EpochStatsValues{}
+	for _, node := range tree.Nodes {
+		blockBody := node.Block.GetBlock()
+		if blockBody == nil {
+			continue
+		}
+
+		attestations, err := blockBody.Attestations()
+		if err != nil {
+			continue
+		}
+
+		for _, attVersioned := range attestations {
+			attData, err := attVersioned.Data()
+			if err != nil {
+				continue
+			}
+
+			attAggregationBits, err := attVersioned.AggregationBits()
+			if err != nil {
+				continue
+			}
+
+			if attData.Source != nil && attData.Source.Epoch >= tree.JustifiedEpoch {
+				tree.JustifiedEpoch = attData.Source.Epoch
+				tree.JustifiedRoot = attData.Source.Root
+			}
+
+			var epochStatsValues *EpochStatsValues
+			if epochStats := indexer.epochCache.getEpochStatsByEpochAndRoot(chainState.EpochOfSlot(attData.Slot), node.Block.Root); epochStats != nil {
+				if cachedValues, isCached := epochStatsValuesCache[epochStats]; isCached {
+					epochStatsValues = cachedValues
+				} else {
+					epochStatsValues = epochStats.GetOrLoadValues(indexer, true, false)
+					epochStatsValuesCache[epochStats] = epochStatsValues
+				}
+			}
+			if epochStatsValues == nil {
+				tree.AmountIsCount = true
+			}
+
+			addVote := func(voter forkChoiceVoter, weight phase0.Gwei) {
+				latestMessage := latestMessages[voter]
+				if latestMessage != nil && latestMessage.slot >= attData.Slot {
+					return
+				}
+
+				latestMessages[voter] = &forkChoiceMessage{
+					slot:   attData.Slot,
+					root:   attData.BeaconBlockRoot,
+					weight: weight,
+				}
+			}
+
+			slotIndex := chainState.SlotToSlotIndex(attData.Slot)
+			if attVersioned.Version >= spec.DataVersionElectra {
+				committeeBits, err := attVersioned.CommitteeBits()
+				if err != nil {
+					continue
+				}
+
+				aggregationBitsOffset := uint64(0)
+				for committeeIdx, committee := range committeeBits.BitIndices() {
+					if uint64(committee) >= specs.MaxCommitteesPerSlot {
+						continue
+					}
+
+					if epochStatsValues != nil {
+						aggregationBitsOffset += collectForkChoiceVotes(epochStatsValues, slotIndex, uint64(committee), attAggregationBits, aggregationBitsOffset, addVote)
+					} else {
+						collectForkChoiceVotesWithoutDuties(attData.Slot, uint64(committee), attAggregationBits, committeeBits.Count(), uint64(committeeIdx), addVote)
+					}
+				}
+			} else {
+				if epochStatsValues != nil {
+					collectForkChoiceVotes(epochStatsValues, slotIndex, uint64(attData.Index), attAggregationBits, 0, addVote)
+				} else {
+					collectForkChoiceVotesWithoutDuties(attData.Slot, uint64(attData.Index), attAggregationBits, 1, 0, addVote)
+				}
+			}
+		}
+	}
+
+	for _, latestMessage := range latestMessages {
+		node := nodeMap[latestMessage.root]
+		if node == nil {
+			continue
+		}
+
+		// gwei weights and vote counts can't be compared, so fall back to counting votes for the whole tree
+		weight := latestMessage.weight
+		if tree.AmountIsCount {
+			weight = 1
+		}
+
+		node.Weight += weight
+		node.VoteCount++
+		tree.TotalWeight += weight
+	}
+
+	// accumulate branch weights (nodes are sorted by slot, so children are processed before their parents)
+	for i := len(tree.Nodes) - 1; i >= 0; i-- {
+		node := tree.Nodes[i]
+		node.BranchWeight += node.Weight
+		if node.HasParent {
+			nodeMap[node.ParentRoot].BranchWeight += node.BranchWeight
+		}
+
+		if node.Block.Root == tree.JustifiedRoot {
+			node.IsJustified = true
+		}
+	}
+
+	// select the head by walking down the heaviest branches (LMD-GHOST)
+	var headNode *ForkChoiceNode
+	for _, node := range tree.Nodes {
+		if node.HasParent {
+			continue
+		}
+		if headNode == nil || compareForkChoiceNodes(node, headNode) > 0 {
+			headNode = node
+		}
+	}
+
+	for headNode != nil {
+		headNode.IsHeadChain = true
+
+		var bestChild *ForkChoiceNode
+		for _, childRoot := range headNode.ChildrenRoots {
+			childNode := nodeMap[childRoot]
+			if bestChild == nil || compareForkChoiceNodes(childNode, bestChild) > 0 {
+				bestChild = childNode
+			}
+		}
+
+		if bestChild == nil {
+			headNode.IsHead = true
+			tree.HeadRoot = headNode.Block.Root
+			break
+		}
+
+		headNode = bestChild
+	}
+
+	tree.FinalizedEpoch, tree.FinalizedRoot = indexer.getForkChoiceFinalizedCheckpoint(replaySlot, tree.HeadRoot)
+	for _, node := range tree.Nodes {
+		if node.Block.Root == tree.FinalizedRoot {
+			node.IsFinalized = true
+		}
+	}
+
+	return tree
+}
+
+// getForkChoiceFinalizedCheckpoint returns the finalized checkpoint as seen by the head chain at the replay slot.
+// The checkpoint is taken from the dependent state of the replay epoch (or the following epoch if the head is its dependent block).
+// Returns an empty checkpoint if the replay slot is in the past and no state with the checkpoint is available.
+func (indexer *Indexer) getForkChoiceFinalizedCheckpoint(replaySlot phase0.Slot, headRoot phase0.Root) (phase0.Epoch, phase0.Root) {
+	chainState := indexer.consensusPool.GetChainState()
+	replayEpoch := chainState.EpochOfSlot(replaySlot)
+	if replayEpoch >= chainState.CurrentEpoch() {
+		return chainState.GetFinalizedCheckpoint()
+	}
+
+	for _, epoch := range []phase0.Epoch{replayEpoch + 1, replayEpoch} {
+		epochStats := indexer.epochCache.getEpochStatsByEpochAndRoot(epoch, headRoot)
+		if epochStats == nil {
+			continue
+		}
+
+		if dependentBlock := indexer.blockCache.getBlockByRoot(epochStats.dependentRoot); dependentBlock == nil || dependentBlock.Slot > replaySlot {
+			continue
+		}
+
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues != nil && epochStatsValues.FinalizedCheckpoint != nil {
+			return epochStatsValues.FinalizedCheckpoint.Epoch, epochStatsValues.FinalizedCheckpoint.Root
+		}
+	}
+
+	return 0, phase0.Root{}
+}
+
+// compareForkChoiceNodes compares two sibling nodes by branch weight, ties are broken by the lexicographically higher root.
+func compareForkChoiceNodes(nodeA, nodeB *ForkChoiceNode) int {
+	if nodeA.BranchWeight != nodeB.BranchWeight {
+		if nodeA.BranchWeight > nodeB.BranchWeight {
+			return 1
+		}
+		return -1
+	}
+
+	return bytes.Compare(nodeA.Block.Root[:], nodeB.Block.Root[:])
+}
+
+// collectForkChoiceVotes resolves the voting validators of a committee and returns the committee size.
+func collectForkChoiceVotes(epochStatsValues *EpochStatsValues, slotIndex phase0.Slot, committee uint64, aggregationBits bitfield.Bitfield, aggregationBitsOffset uint64, addVote func(voter forkChoiceVoter, weight phase0.Gwei)) uint64 {
+	if int(slotIndex) >= len(epochStatsValues.AttesterDuties) || int(committee) >= len(epochStatsValues.AttesterDuties[slotIndex]) {
+		return 0
+	}
+
+	voteDuties := epochStatsValues.AttesterDuties[slotIndex][committee]
+	for bitIdx, validatorIndice := range voteDuties {
+		if !aggregationBits.BitAt(uint64(bitIdx) + aggregationBitsOffset) {
+			continue
+		}
+
+		addVote(forkChoiceVoter{
+			validator: epochStatsValues.ActiveIndices[validatorIndice],
+		}, phase0.Gwei(epochStatsValues.EffectiveBalances[validatorIndice])*EtherGweiFactor)
+	}
+
+	return uint64(len(voteDuties))
+}
+
+// collectForkChoiceVotesWithoutDuties identifies voters by their committee position and counts each vote as 1.
+func collectForkChoiceVotesWithoutDuties(slot phase0.Slot, committee uint64, aggregationBits bitfield.Bitfield, splitAggregationBits uint64, splitAggregationIndex uint64, addVote func(voter forkChoiceVoter, weight phase0.Gwei)) {
+	bitsLen := aggregationBits.Len() / splitAggregationBits
+	aggregationBitsOffset := splitAggregationIndex * bitsLen
+	if splitAggregationBits == splitAggregationIndex+1 {
+		bitsLen = aggregationBits.Len() - aggregationBitsOffset
+	}
+
+	for bitIdx := uint64(0); bitIdx < bitsLen; bitIdx++ {
+		if !aggregationBits.BitAt(bitIdx + aggregationBitsOffset) {
+			continue
+		}
+
+		addVote(forkChoiceVoter{
+			validator: phase0.ValidatorIndex(math.MaxUint64),
+			position:  getVoteDeduplicationKey(slot, uint16(committee), uint32(bitIdx)),
+		}, 1)
+	}
+}
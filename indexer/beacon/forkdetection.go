@@ -323,6 +323,10 @@ func (cache *forkCache) processBlock(block *Block) error {
 		if err != nil {
 			return err
 		}
+
+		for _, newFork := range newForks {
+			cache.indexer.forkDispatcher.Fire(newFork.fork)
+		}
 	}
 
 	return nil
@@ -335,6 +339,11 @@ func (cache *forkCache) updateForkBlocks(startBlock *Block, forkId ForkKey, skip
 	if !skipStartBlock {
 		blockRoots = append(blockRoots, startBlock.Root[:])
 		startBlock.forkId = forkId
+		if startBlock.isSpilled {
+			if err := cache.indexer.spillCache.updateBlock(startBlock); err != nil {
+				cache.indexer.logger.Warnf("failed updating spilled block %v: %v", startBlock.Root.String(), err)
+			}
+		}
 		headBlock = startBlock
 	}
 
@@ -370,6 +379,11 @@ func (cache *forkCache) updateForkBlocks(startBlock *Block, forkId ForkKey, skip
 		}
 
 		nextBlock.forkId = forkId
+		if nextBlock.isSpilled {
+			if err := cache.indexer.spillCache.updateBlock(nextBlock); err != nil {
+				cache.indexer.logger.Warnf("failed updating spilled block %v: %v", nextBlock.Root.String(), err)
+			}
+		}
 		blockRoots = append(blockRoots, nextBlock.Root[:])
 		headBlock = nextBlock
 
@@ -36,12 +36,14 @@ type Indexer struct {
 	inMemoryEpochs        uint16
 	activityHistoryLength uint16
 	maxParallelStateCalls uint16
+	spillAfterEpochs      uint16
 
 	// caches
 	blockCache        *blockCache
 	epochCache        *epochCache
 	forkCache         *forkCache
 	pubkeyCache       *pubkeyCache
+	spillCache        *spillCache
 	validatorCache    *validatorCache
 	validatorActivity *validatorActivityCache
 
@@ -60,6 +62,11 @@ type Indexer struct {
 	finalitySubscription  *consensus.Subscription[*v1.Finality]
 	wallclockSubscription *consensus.Subscription[*ethwallclock.Slot]
 
+	// event dispatchers
+	blockDispatcher          consensus.Dispatcher[*Block]
+	forkDispatcher           consensus.Dispatcher[*Fork]
+	finalizedEpochDispatcher consensus.Dispatcher[*FinalizedEpochEvent]
+
 	// canonical head state
 	canonicalHeadMutex   sync.Mutex
 	canonicalHead        *Block
@@ -83,6 +90,10 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 	if maxParallelStateCalls < 2 {
 		maxParallelStateCalls = 2
 	}
+	spillAfterEpochs := utils.Config.Indexer.SpillAfterEpochs
+	if spillAfterEpochs == 0 {
+		spillAfterEpochs = 16
+	}
 	blockCompression := true
 	if utils.Config.KillSwitch.DisableBlockCompression {
 		blockCompression = false
@@ -97,6 +108,7 @@ func NewIndexer(logger logrus.FieldLogger, consensusPool *consensus.Pool) *Index
 		inMemoryEpochs:        inMemoryEpochs,
 		activityHistoryLength: activityHistoryLength,
 		maxParallelStateCalls: maxParallelStateCalls,
+		spillAfterEpochs:      spillAfterEpochs,
 
 		clients:              make([]*Client, 0),
 		backfillCompleteChan: make(chan bool),
@@ -199,6 +211,16 @@ func (indexer *Indexer) AddClient(index uint16, client *consensus.Client, priori
 	return indexerClient
 }
 
+// initDynSsz initializes the dynamic SSZ encoder with the specs of the current chain.
+func (indexer *Indexer) initDynSsz() {
+	staticSpec := map[string]any{}
+	specYaml, err := yaml.Marshal(indexer.consensusPool.GetChainState().GetSpecs())
+	if err == nil {
+		yaml.Unmarshal(specYaml, &staticSpec)
+	}
+	indexer.dynSsz = dynssz.NewDynSsz(staticSpec)
+}
+
 // StartIndexer starts the indexing process.
 func (indexer *Indexer) StartIndexer() {
 	if indexer.running {
@@ -209,12 +231,10 @@ func (indexer *Indexer) StartIndexer() {
 	chainState := indexer.consensusPool.GetChainState()
 
 	// initialize dynamic SSZ encoder
-	staticSpec := map[string]any{}
-	specYaml, err := yaml.Marshal(chainState.GetSpecs())
-	if err == nil {
-		yaml.Unmarshal(specYaml, &staticSpec)
-	}
-	indexer.dynSsz = dynssz.NewDynSsz(staticSpec)
+	indexer.initDynSsz()
+
+	// initialize spill cache (only used by the running indexer, as it's cleared on startup)
+	indexer.spillCache = newSpillCache(indexer, utils.Config.Indexer.SpillCachePath)
 
 	// initialize synchronizer & restore state
 	indexer.synchronizer = newSynchronizer(indexer, indexer.logger.WithField("service", "synchronizer"))
@@ -262,7 +282,7 @@ func (indexer *Indexer) StartIndexer() {
 	t1 = time.Now()
 	processingLimiter := make(chan bool, 10)
 	processingWaitGroup := sync.WaitGroup{}
-	err = db.StreamUnfinalizedDuties(uint64(finalizedEpoch), func(dbDuty *dbtypes.UnfinalizedDuty) {
+	err := db.StreamUnfinalizedDuties(uint64(finalizedEpoch), func(dbDuty *dbtypes.UnfinalizedDuty) {
 		// restoring epoch stats can be slow as all duties are recomputed
 		// parallelize the processing to speed up the restore
 		processingWaitGroup.Add(1)
@@ -320,16 +340,40 @@ func (indexer *Indexer) StartIndexer() {
 	}
 
 	// restore unfinalized blocks from db
+	// old pruned blocks are written to the spill cache directly to keep the memory usage bounded
 	restoredBlockCount := 0
 	restoredBodyCount := 0
+	spilledBlockCount := 0
+	spillBlocks := []*Block{}
+	spillSlot := phase0.Slot(0)
+	if spillEpoch := indexer.getSpillEpoch(); spillEpoch > 0 {
+		spillSlot = chainState.EpochToSlot(spillEpoch)
+	}
+	flushSpillBlocks := func() {
+		if err := indexer.spillCache.addBlocks(spillBlocks); err != nil {
+			indexer.logger.WithError(err).Errorf("failed spilling restored blocks")
+		} else {
+			spilledBlockCount += len(spillBlocks)
+		}
+		spillBlocks = []*Block{}
+	}
+
 	t1 = time.Now()
 	err = db.StreamUnfinalizedBlocks(uint64(finalizedSlot), func(dbBlock *dbtypes.UnfinalizedBlock) {
-
-		block, _ := indexer.blockCache.createOrGetBlock(phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
+		var block *Block
+		isSpillBlock := dbBlock.Slot < uint64(spillSlot) && dbBlock.Status != dbtypes.UnfinalizedBlockStatusUnprocessed
+		if isSpillBlock {
+			block = newBlock(indexer.dynSsz, phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
+		} else {
+			block, _ = indexer.blockCache.createOrGetBlock(phase0.Root(dbBlock.Root), phase0.Slot(dbBlock.Slot))
+		}
 		block.forkId = ForkKey(dbBlock.ForkId)
 		block.forkChecked = true
 		block.processingStatus = dbBlock.Status
 		block.isInUnfinalizedDb = true
+		if dbBlock.RecvTs > 0 {
+			block.setRecvTime(time.UnixMilli(dbBlock.RecvTs))
+		}
 
 		if dbBlock.HeaderVer != 1 {
 			indexer.logger.Warnf("failed unmarshal unfinalized block header %v [%x] from db: unsupported header version", dbBlock.Slot, dbBlock.Root)
@@ -344,7 +388,9 @@ func (indexer *Indexer) StartIndexer() {
 		}
 
 		block.SetHeader(header)
-		indexer.blockCache.addBlockToParentMap(block)
+		if !isSpillBlock {
+			indexer.blockCache.addBlockToParentMap(block)
+		}
 
 		blockBody, err := unmarshalVersionedSignedBeaconBlockSSZ(indexer.dynSsz, dbBlock.BlockVer, dbBlock.BlockSSZ)
 		if err != nil {
@@ -357,7 +403,14 @@ func (indexer *Indexer) StartIndexer() {
 			block.isInFinalizedDb = true
 		}
 
-		indexer.blockCache.addBlockToExecBlockMap(block)
+		if isSpillBlock {
+			spillBlocks = append(spillBlocks, block)
+			if len(spillBlocks) >= 1000 {
+				flushSpillBlocks()
+			}
+		} else {
+			indexer.blockCache.addBlockToExecBlockMap(block)
+		}
 
 		blockFork := indexer.forkCache.getForkById(block.forkId)
 		if blockFork != nil {
@@ -374,10 +427,15 @@ func (indexer *Indexer) StartIndexer() {
 			t1 = time.Now()
 		}
 	})
+	flushSpillBlocks()
 	if err != nil {
 		indexer.logger.WithError(err).Errorf("failed restoring unfinalized blocks from DB")
 	} else {
-		indexer.logger.Infof("restored %v unfinalized blocks from DB (%v with bodies, %.3f sec)", restoredBlockCount, restoredBodyCount, time.Since(t1).Seconds())
+		indexer.logger.Infof("restored %v unfinalized blocks from DB (%v with bodies, %v spilled, %.3f sec)", restoredBlockCount, restoredBodyCount, spilledBlockCount, time.Since(t1).Seconds())
+	}
+
+	if _, spilledEpochStats := indexer.processCacheSpilling(); spilledEpochStats > 0 {
+		indexer.logger.Infof("moved %v restored epoch stats to the spill cache", spilledEpochStats)
 	}
 
 	// start indexing for all clients
@@ -415,6 +473,7 @@ func (indexer *Indexer) StartIndexer() {
 
 func (indexer *Indexer) StopIndexer() {
 	indexer.pubkeyCache.Close()
+	indexer.spillCache.Close()
 }
 
 func (indexer *Indexer) runIndexerLoop() {
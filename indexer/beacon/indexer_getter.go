@@ -6,6 +6,7 @@ import (
 	"math/rand/v2"
 	"slices"
 	"sort"
+	"time"
 
 	v1 "github.com/attestantio/go-eth2-client/api/v1"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
@@ -198,6 +199,9 @@ func (indexer *Indexer) GetOrphanedBlockByRoot(blockRoot phase0.Root) (*Block, e
 	block := newBlock(indexer.dynSsz, blockRoot, header.Message.Slot)
 	block.SetHeader(header)
 	block.SetBlock(blockBody)
+	if orphanedBlock.RecvTs > 0 {
+		block.setRecvTime(time.UnixMilli(orphanedBlock.RecvTs))
+	}
 
 	return block, nil
 }
@@ -281,6 +285,11 @@ func (indexer *Indexer) StreamActiveValidatorDataForRoot(blockRoot phase0.Root,
 	return indexer.validatorCache.streamValidatorSetForRoot(blockRoot, activeOnly, epoch, cb)
 }
 
+// StreamFilteredValidatorSetForRoot streams the validators matching the filter for a given blockRoot.
+func (indexer *Indexer) StreamFilteredValidatorSetForRoot(blockRoot phase0.Root, filter *ValidatorSetFilter, cb ValidatorSetStreamer) error {
+	return indexer.validatorCache.streamFilteredValidatorSetForRoot(blockRoot, filter, cb)
+}
+
 // GetValidatorSetSize returns the size of the validator set cache.
 func (indexer *Indexer) GetValidatorSetSize() uint64 {
 	return indexer.validatorCache.getValidatorSetSize()
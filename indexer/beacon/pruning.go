@@ -399,10 +399,55 @@ func (indexer *Indexer) processCachePruning(prunedEpochStats, prunedEpochStates
 
 	prunedEpochStates += indexer.epochCache.removeUnreferencedEpochStates()
 
+	// move old pruned blocks & epoch stats to the spill cache
+	spilledBlocks, spilledEpochStats := indexer.processCacheSpilling()
+	indexer.spillCache.cleanup(indexer.lastFinalizedEpoch)
+
 	// run gc to clean up memory
 	runtime.GC()
 
-	indexer.logger.Infof("cache pruning complete! pruned %v blocks, %v epoch stats and %v epoch states", len(pruningData), prunedEpochStats, prunedEpochStates)
+	indexer.logger.Infof("cache pruning complete! pruned %v blocks, %v epoch stats and %v epoch states (spilled %v blocks, %v epoch stats)", len(pruningData), prunedEpochStats, prunedEpochStates, spilledBlocks, spilledEpochStats)
 
 	return nil
 }
+
+// getSpillEpoch returns the epoch before which pruned blocks & epoch stats are moved to the spill cache (0 if spilling is disabled).
+func (indexer *Indexer) getSpillEpoch() phase0.Epoch {
+	if !indexer.spillCache.isEnabled() {
+		return 0
+	}
+
+	minInMemoryEpoch := indexer.getMinInMemoryEpoch()
+	if minInMemoryEpoch <= phase0.Epoch(indexer.spillAfterEpochs) {
+		return 0
+	}
+
+	spillEpoch := minInMemoryEpoch - phase0.Epoch(indexer.spillAfterEpochs)
+	if spillEpoch <= indexer.lastFinalizedEpoch {
+		return 0
+	}
+
+	return spillEpoch
+}
+
+// processCacheSpilling moves pruned blocks & epoch stats that are older than spillAfterEpochs pruned epochs to the spill cache.
+func (indexer *Indexer) processCacheSpilling() (uint64, uint64) {
+	spillEpoch := indexer.getSpillEpoch()
+	if spillEpoch == 0 {
+		return 0, 0
+	}
+
+	chainState := indexer.consensusPool.GetChainState()
+
+	spilledBlocks, err := indexer.blockCache.spillBlocks(chainState.EpochToSlot(spillEpoch))
+	if err != nil {
+		indexer.logger.Errorf("error spilling pruned blocks: %v", err)
+	}
+
+	spilledEpochStats, err := indexer.epochCache.spillEpochStats(spillEpoch)
+	if err != nil {
+		indexer.logger.Errorf("error spilling pruned epoch stats: %v", err)
+	}
+
+	return spilledBlocks, spilledEpochStats
+}
@@ -0,0 +1,780 @@
+package beacon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// name of the spill cache store directory within the configured spill cache path
+const spillCacheDirName = "dora-spill"
+
+// spill cache key prefixes
+const (
+	spillKeyBlock           byte = 'b' // b + root -> spillBlockRecord
+	spillKeySlot            byte = 's' // s + slot + root -> nil
+	spillKeyParent          byte = 'p' // p + parentRoot + root -> nil
+	spillKeyExecHash        byte = 'x' // x + execution block hash + root -> nil
+	spillKeyExecNumber      byte = 'n' // n + execution block number + root -> nil
+	spillKeyStateRoot       byte = 't' // t + stateRoot + root -> nil
+	spillKeyEpochStats      byte = 'e' // e + epoch + dependentRoot -> spillEpochStatsRecord
+	spillKeyEpochVotes      byte = 'v' // v + epoch + epochVotesKey -> EpochVotes
+	spillBlockPageSize           = 1000
+	spillEpochStatsPageSize      = 64
+)
+
+// spill block record flags
+const (
+	spillBlockFlagDependentRoot uint8 = 1 << iota
+	spillBlockFlagForkChecked
+	spillBlockFlagInUnfinalizedDb
+	spillBlockFlagInFinalizedDb
+	spillBlockFlagBlockIndex
+)
+
+// spill epoch stats record flags
+const (
+	spillStatsFlagReady uint8 = 1 << iota
+	spillStatsFlagInDb
+	spillStatsFlagValues
+)
+
+// spillCache is a disk backed store for pruned blocks, epoch stats & epoch votes.
+// it keeps the memory usage bounded during long periods of non-finality by moving old pruned entries out of the block & epoch cache.
+// spilled entries are paged back in on demand and kept in small lru caches.
+// the spill cache only lives for the current process, the unfinalized db tables stay the source of truth across restarts.
+type spillCache struct {
+	indexer      *Indexer
+	spillDb      *leveldb.DB
+	spillMutex   sync.RWMutex // mutex to protect spilledSlot & spilledEpoch
+	spilledSlot  phase0.Slot  // all spilled blocks are before this slot
+	spilledEpoch phase0.Epoch // all spilled epoch stats are before this epoch
+
+	blockPage *lru.Cache[phase0.Root, *Block]
+	statsPage *lru.Cache[epochStatsKey, *EpochStats]
+
+	spilledBlocks uint64
+	spilledStats  uint64
+	spilledVotes  uint64
+	pageInHit     uint64
+	pageInMiss    uint64
+}
+
+// spillBlockRecord is the serialized representation of a spilled block.
+type spillBlockRecord struct {
+	Slot               uint64
+	ParentRoot         phase0.Root
+	DependentRoot      phase0.Root
+	ForkId             uint64
+	Status             uint32
+	Flags              uint8
+	RecvTs             uint64
+	Header             *phase0.SignedBeaconBlockHeader
+	Graffiti           [32]byte
+	ExecutionExtraData []byte `ssz-max:"32"`
+	ExecutionHash      phase0.Hash32
+	ExecutionNumber    uint64
+}
+
+// spillEpochStatsRecord is the serialized representation of spilled epoch stats (pruned values only).
+type spillEpochStatsRecord struct {
+	Flags               uint8
+	RandaoMix           phase0.Hash32
+	NextRandaoMix       phase0.Hash32
+	ProposerDuties      []phase0.ValidatorIndex `ssz-max:"100"`
+	SyncCommitteeDuties []phase0.ValidatorIndex `ssz-max:"10000"`
+	NextSyncCommittee   []phase0.ValidatorIndex `ssz-max:"10000"`
+	ActiveValidators    uint64
+	TotalBalance        phase0.Gwei
+	ActiveBalance       phase0.Gwei
+	EffectiveBalance    phase0.Gwei
+	FirstDepositIndex   uint64
+	Aggregations        []*spillEpochAggregation `ssz-max:"1000"`
+}
+
+// spillEpochAggregation is the serialized representation of a pruned epoch aggregation.
+type spillEpochAggregation struct {
+	EpochHeadRoot         phase0.Root
+	EpochHeadForkId       uint64
+	ValidatorCount        uint64
+	ValidatorBalance      uint64
+	Eligible              uint64
+	VotedTarget           uint64
+	VotedHead             uint64
+	VotedTotal            uint64
+	BlockCount            uint16
+	OrphanedCount         uint16
+	AttestationCount      uint64
+	DepositCount          uint64
+	ExitCount             uint64
+	WithdrawCount         uint64
+	WithdrawAmount        uint64
+	AttesterSlashingCount uint64
+	ProposerSlashingCount uint64
+	BLSChangeCount        uint64
+	EthTransactionCount   uint64
+	SyncParticipation     uint32 // float32 bits
+}
+
+// newSpillCache creates a new spill cache in the given directory.
+// the spill cache is disabled if no directory is given or the store cannot be opened.
+func newSpillCache(indexer *Indexer, cacheDir string) *spillCache {
+	cache := &spillCache{
+		indexer:   indexer,
+		blockPage: lru.NewCache[phase0.Root, *Block](spillBlockPageSize),
+		statsPage: lru.NewCache[epochStatsKey, *EpochStats](spillEpochStatsPageSize),
+	}
+
+	if cacheDir != "" {
+		// the store is kept in a dedicated subdirectory, so clearing it never touches other files in the configured directory
+		spillDir := filepath.Join(cacheDir, spillCacheDirName)
+
+		// spilled entries are rebuilt from the unfinalized db tables on startup, so drop leftovers from previous runs
+		if err := os.RemoveAll(spillDir); err != nil {
+			indexer.logger.WithError(err).Error("failed to clear spill cache")
+		}
+
+		db, err := leveldb.OpenFile(spillDir, nil)
+		if err != nil {
+			indexer.logger.WithError(err).Error("failed to open spill cache")
+		} else {
+			cache.spillDb = db
+		}
+	}
+
+	return cache
+}
+
+// isEnabled returns true if the spill cache is backed by a disk store.
+func (c *spillCache) isEnabled() bool {
+	return c != nil && c.spillDb != nil
+}
+
+func (c *spillCache) Close() error {
+	if c.isEnabled() {
+		return c.spillDb.Close()
+	}
+	return nil
+}
+
+func (c *spillCache) getSpilledSlot() phase0.Slot {
+	c.spillMutex.RLock()
+	defer c.spillMutex.RUnlock()
+	return c.spilledSlot
+}
+
+func (c *spillCache) getSpilledEpoch() phase0.Epoch {
+	c.spillMutex.RLock()
+	defer c.spillMutex.RUnlock()
+	return c.spilledEpoch
+}
+
+func getSpillKey(prefix byte, parts ...[]byte) []byte {
+	key := []byte{prefix}
+	for _, part := range parts {
+		key = append(key, part...)
+	}
+	return key
+}
+
+func getSpillUint64(value uint64) []byte {
+	res := make([]byte, 8)
+	binary.BigEndian.PutUint64(res, value)
+	return res
+}
+
+// getSpillIndexRoots returns the block roots referenced by all index keys with the given prefix.
+func (c *spillCache) getSpillIndexRoots(prefix []byte) []phase0.Root {
+	roots := []phase0.Root{}
+
+	iter := c.spillDb.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) < len(prefix)+32 {
+			continue
+		}
+		roots = append(roots, phase0.Root(key[len(key)-32:]))
+	}
+
+	return roots
+}
+
+// addBlocks writes the given pruned blocks to the spill cache.
+func (c *spillCache) addBlocks(blocks []*Block) error {
+	if !c.isEnabled() || len(blocks) == 0 {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	highestSlot := phase0.Slot(0)
+	for _, block := range blocks {
+		if err := c.writeBlock(batch, block); err != nil {
+			return fmt.Errorf("failed serializing block %v: %v", block.Root.String(), err)
+		}
+
+		if block.Slot >= highestSlot {
+			highestSlot = block.Slot + 1
+		}
+	}
+
+	if err := c.spillDb.Write(batch, nil); err != nil {
+		return err
+	}
+
+	c.spillMutex.Lock()
+	if highestSlot > c.spilledSlot {
+		c.spilledSlot = highestSlot
+	}
+	c.spillMutex.Unlock()
+
+	for _, block := range blocks {
+		block.isSpilled = true
+	}
+	c.spilledBlocks += uint64(len(blocks))
+
+	return nil
+}
+
+// updateBlock rewrites the record of a spilled block after it has been modified (eg. fork id updates).
+func (c *spillCache) updateBlock(block *Block) error {
+	if !c.isEnabled() || !block.isSpilled {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	if err := c.writeBlock(batch, block); err != nil {
+		return err
+	}
+
+	return c.spillDb.Write(batch, nil)
+}
+
+func (c *spillCache) writeBlock(batch *leveldb.Batch, block *Block) error {
+	header := block.GetHeader()
+	if header == nil {
+		return fmt.Errorf("block header not loaded")
+	}
+
+	record := &spillBlockRecord{
+		Slot:   uint64(block.Slot),
+		ForkId: uint64(block.forkId),
+		Status: uint32(block.processingStatus),
+		RecvTs: uint64(block.getRecvTs()),
+		Header: header,
+	}
+
+	if parentRoot := block.GetParentRoot(); parentRoot != nil {
+		record.ParentRoot = *parentRoot
+	}
+	if block.dependentRoot != nil {
+		record.Flags |= spillBlockFlagDependentRoot
+		record.DependentRoot = *block.dependentRoot
+	}
+	if block.forkChecked {
+		record.Flags |= spillBlockFlagForkChecked
+	}
+	if block.isInUnfinalizedDb {
+		record.Flags |= spillBlockFlagInUnfinalizedDb
+	}
+	if block.isInFinalizedDb {
+		record.Flags |= spillBlockFlagInFinalizedDb
+	}
+	if blockIndex := block.blockIndex; blockIndex != nil {
+		record.Flags |= spillBlockFlagBlockIndex
+		record.Graffiti = blockIndex.Graffiti
+		record.ExecutionExtraData = blockIndex.ExecutionExtraData
+		record.ExecutionHash = blockIndex.ExecutionHash
+		record.ExecutionNumber = blockIndex.ExecutionNumber
+	}
+
+	recordSsz, err := c.indexer.dynSsz.MarshalSSZ(record)
+	if err != nil {
+		return err
+	}
+
+	batch.Put(getSpillKey(spillKeyBlock, block.Root[:]), recordSsz)
+	for _, indexKey := range c.getBlockIndexKeys(block.Root, record) {
+		batch.Put(indexKey, nil)
+	}
+
+	return nil
+}
+
+// getBlockIndexKeys returns all index keys that reference the given block record.
+func (c *spillCache) getBlockIndexKeys(root phase0.Root, record *spillBlockRecord) [][]byte {
+	indexKeys := [][]byte{
+		getSpillKey(spillKeySlot, getSpillUint64(record.Slot), root[:]),
+		getSpillKey(spillKeyParent, record.ParentRoot[:], root[:]),
+		getSpillKey(spillKeyStateRoot, record.Header.Message.StateRoot[:], root[:]),
+	}
+
+	if record.Flags&spillBlockFlagBlockIndex != 0 && !bytes.Equal(record.ExecutionHash[:], zeroHash[:]) {
+		indexKeys = append(indexKeys,
+			getSpillKey(spillKeyExecHash, record.ExecutionHash[:], root[:]),
+			getSpillKey(spillKeyExecNumber, getSpillUint64(record.ExecutionNumber), root[:]),
+		)
+	}
+
+	return indexKeys
+}
+
+func (c *spillCache) loadBlockRecord(root phase0.Root) *spillBlockRecord {
+	recordSsz, err := c.spillDb.Get(getSpillKey(spillKeyBlock, root[:]), nil)
+	if err != nil {
+		return nil
+	}
+
+	record := &spillBlockRecord{}
+	if err := c.indexer.dynSsz.UnmarshalSSZ(record, recordSsz); err != nil {
+		c.indexer.logger.Warnf("failed unmarshal spilled block %v: %v", root.String(), err)
+		return nil
+	}
+
+	return record
+}
+
+// getBlock pages in the spilled block with the given root.
+func (c *spillCache) getBlock(root phase0.Root) *Block {
+	if !c.isEnabled() || c.getSpilledSlot() == 0 {
+		return nil
+	}
+
+	if block, isOk := c.blockPage.Get(root); isOk {
+		c.pageInHit++
+		return block
+	}
+
+	record := c.loadBlockRecord(root)
+	if record == nil {
+		return nil
+	}
+	c.pageInMiss++
+
+	block := newBlock(c.indexer.dynSsz, root, phase0.Slot(record.Slot))
+	block.isSpilled = true
+	block.parentRoot = &record.ParentRoot
+	block.forkId = ForkKey(record.ForkId)
+	block.processingStatus = dbtypes.UnfinalizedBlockStatus(record.Status)
+	block.forkChecked = record.Flags&spillBlockFlagForkChecked != 0
+	block.isInUnfinalizedDb = record.Flags&spillBlockFlagInUnfinalizedDb != 0
+	block.isInFinalizedDb = record.Flags&spillBlockFlagInFinalizedDb != 0
+	if record.RecvTs > 0 {
+		block.setRecvTime(time.UnixMilli(int64(record.RecvTs)))
+	}
+	if record.Flags&spillBlockFlagDependentRoot != 0 {
+		block.dependentRoot = &record.DependentRoot
+	}
+	if record.Flags&spillBlockFlagBlockIndex != 0 {
+		block.blockIndex = &BlockBodyIndex{
+			Graffiti:           record.Graffiti,
+			ExecutionExtraData: record.ExecutionExtraData,
+			ExecutionHash:      record.ExecutionHash,
+			ExecutionNumber:    record.ExecutionNumber,
+		}
+	}
+	block.SetHeader(record.Header)
+
+	c.blockPage.Add(root, block)
+	return block
+}
+
+// getBlocksByIndex pages in all spilled blocks referenced by index keys with the given prefix.
+func (c *spillCache) getBlocksByIndex(prefix []byte) []*Block {
+	if !c.isEnabled() || c.getSpilledSlot() == 0 {
+		return nil
+	}
+
+	blocks := []*Block{}
+	for _, root := range c.getSpillIndexRoots(prefix) {
+		if block := c.getBlock(root); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+// getBlocksBySlotRange pages in all spilled blocks in the given slot range (inclusive).
+func (c *spillCache) getBlocksBySlotRange(firstSlot phase0.Slot, lastSlot phase0.Slot) []*Block {
+	if !c.isEnabled() || firstSlot >= c.getSpilledSlot() {
+		return nil
+	}
+
+	blockKeys := c.getSpillKeysInRange(spillKeySlot, uint64(firstSlot), uint64(lastSlot)+1)
+
+	blocks := []*Block{}
+	for _, key := range blockKeys {
+		if block := c.getBlock(phase0.Root(key[len(key)-32:])); block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks
+}
+
+func (c *spillCache) getBlocksByParentRoot(parentRoot phase0.Root) []*Block {
+	return c.getBlocksByIndex(getSpillKey(spillKeyParent, parentRoot[:]))
+}
+
+func (c *spillCache) getBlocksByExecutionBlockHash(blockHash phase0.Hash32) []*Block {
+	return c.getBlocksByIndex(getSpillKey(spillKeyExecHash, blockHash[:]))
+}
+
+func (c *spillCache) getBlocksByExecutionBlockNumber(blockNumber uint64) []*Block {
+	return c.getBlocksByIndex(getSpillKey(spillKeyExecNumber, getSpillUint64(blockNumber)))
+}
+
+func (c *spillCache) getBlockByStateRoot(stateRoot phase0.Root) *Block {
+	blocks := c.getBlocksByIndex(getSpillKey(spillKeyStateRoot, stateRoot[:]))
+	if len(blocks) == 0 {
+		return nil
+	}
+	return blocks[0]
+}
+
+// removeBlock removes a spilled block from the spill cache.
+func (c *spillCache) removeBlock(block *Block) {
+	if !c.isEnabled() {
+		return
+	}
+
+	batch := new(leveldb.Batch)
+	c.deleteBlock(batch, block.Root)
+	if err := c.spillDb.Write(batch, nil); err != nil {
+		c.indexer.logger.WithError(err).Warnf("failed removing spilled block %v", block.Root.String())
+	}
+}
+
+func (c *spillCache) deleteBlock(batch *leveldb.Batch, root phase0.Root) {
+	if record := c.loadBlockRecord(root); record != nil {
+		for _, indexKey := range c.getBlockIndexKeys(root, record) {
+			batch.Delete(indexKey)
+		}
+		c.spilledBlocks--
+	}
+
+	batch.Delete(getSpillKey(spillKeyBlock, root[:]))
+	c.blockPage.Remove(root)
+}
+
+// addEpochStats writes the given pruned epoch stats to the spill cache.
+func (c *spillCache) addEpochStats(statsList []*EpochStats) error {
+	if !c.isEnabled() || len(statsList) == 0 {
+		return nil
+	}
+
+	batch := new(leveldb.Batch)
+	highestEpoch := phase0.Epoch(0)
+	for _, epochStats := range statsList {
+		record := &spillEpochStatsRecord{}
+		if epochStats.ready {
+			record.Flags |= spillStatsFlagReady
+		}
+		if epochStats.isInDb {
+			record.Flags |= spillStatsFlagInDb
+		}
+
+		if values := epochStats.GetValues(false); values != nil {
+			record.Flags |= spillStatsFlagValues
+			record.RandaoMix = values.RandaoMix
+			record.NextRandaoMix = values.NextRandaoMix
+			record.ProposerDuties = values.ProposerDuties
+			record.SyncCommitteeDuties = values.SyncCommitteeDuties
+			record.NextSyncCommittee = values.NextSyncCommittee
+			record.ActiveValidators = values.ActiveValidators
+			record.TotalBalance = values.TotalBalance
+			record.ActiveBalance = values.ActiveBalance
+			record.EffectiveBalance = values.EffectiveBalance
+			record.FirstDepositIndex = values.FirstDepositIndex
+		}
+
+		for _, epochAgg := range epochStats.prunedEpochAggregations {
+			record.Aggregations = append(record.Aggregations, &spillEpochAggregation{
+				EpochHeadRoot:         phase0.Root(epochAgg.EpochHeadRoot),
+				EpochHeadForkId:       epochAgg.EpochHeadForkId,
+				ValidatorCount:        epochAgg.ValidatorCount,
+				ValidatorBalance:      epochAgg.ValidatorBalance,
+				Eligible:              epochAgg.Eligible,
+				VotedTarget:           epochAgg.VotedTarget,
+				VotedHead:             epochAgg.VotedHead,
+				VotedTotal:            epochAgg.VotedTotal,
+				BlockCount:            epochAgg.BlockCount,
+				OrphanedCount:         epochAgg.OrphanedCount,
+				AttestationCount:      epochAgg.AttestationCount,
+				DepositCount:          epochAgg.DepositCount,
+				ExitCount:             epochAgg.ExitCount,
+				WithdrawCount:         epochAgg.WithdrawCount,
+				WithdrawAmount:        epochAgg.WithdrawAmount,
+				AttesterSlashingCount: epochAgg.AttesterSlashingCount,
+				ProposerSlashingCount: epochAgg.ProposerSlashingCount,
+				BLSChangeCount:        epochAgg.BLSChangeCount,
+				EthTransactionCount:   epochAgg.EthTransactionCount,
+				SyncParticipation:     math.Float32bits(epochAgg.SyncParticipation),
+			})
+		}
+
+		recordSsz, err := c.indexer.dynSsz.MarshalSSZ(record)
+		if err != nil {
+			return fmt.Errorf("failed serializing epoch stats %v (%v): %v", epochStats.epoch, epochStats.dependentRoot.String(), err)
+		}
+
+		batch.Put(getSpillKey(spillKeyEpochStats, getSpillUint64(uint64(epochStats.epoch)), epochStats.dependentRoot[:]), recordSsz)
+
+		if epochStats.epoch >= highestEpoch {
+			highestEpoch = epochStats.epoch + 1
+		}
+	}
+
+	if err := c.spillDb.Write(batch, nil); err != nil {
+		return err
+	}
+
+	c.spillMutex.Lock()
+	if highestEpoch > c.spilledEpoch {
+		c.spilledEpoch = highestEpoch
+	}
+	c.spillMutex.Unlock()
+
+	c.spilledStats += uint64(len(statsList))
+
+	return nil
+}
+
+func (c *spillCache) loadEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root, recordSsz []byte) *EpochStats {
+	record := &spillEpochStatsRecord{}
+	if err := c.indexer.dynSsz.UnmarshalSSZ(record, recordSsz); err != nil {
+		c.indexer.logger.Warnf("failed unmarshal spilled epoch stats %v (%v): %v", epoch, dependentRoot.String(), err)
+		return nil
+	}
+
+	epochStats := newEpochStats(epoch, dependentRoot)
+	epochStats.ready = record.Flags&spillStatsFlagReady != 0
+	epochStats.isInDb = record.Flags&spillStatsFlagInDb != 0
+
+	if record.Flags&spillStatsFlagValues != 0 {
+		epochStats.prunedValues = &EpochStatsValues{
+			RandaoMix:           record.RandaoMix,
+			NextRandaoMix:       record.NextRandaoMix,
+			ProposerDuties:      record.ProposerDuties,
+			SyncCommitteeDuties: c.indexer.epochCache.getOrUpdateSyncCommittee(record.SyncCommitteeDuties),
+			NextSyncCommittee:   record.NextSyncCommittee,
+			ActiveValidators:    record.ActiveValidators,
+			TotalBalance:        record.TotalBalance,
+			ActiveBalance:       record.ActiveBalance,
+			EffectiveBalance:    record.EffectiveBalance,
+			FirstDepositIndex:   record.FirstDepositIndex,
+		}
+	}
+
+	for _, epochAgg := range record.Aggregations {
+		epochStats.prunedEpochAggregations = append(epochStats.prunedEpochAggregations, &dbtypes.UnfinalizedEpoch{
+			Epoch:                 uint64(epoch),
+			DependentRoot:         dependentRoot[:],
+			EpochHeadRoot:         epochAgg.EpochHeadRoot[:],
+			EpochHeadForkId:       epochAgg.EpochHeadForkId,
+			ValidatorCount:        epochAgg.ValidatorCount,
+			ValidatorBalance:      epochAgg.ValidatorBalance,
+			Eligible:              epochAgg.Eligible,
+			VotedTarget:           epochAgg.VotedTarget,
+			VotedHead:             epochAgg.VotedHead,
+			VotedTotal:            epochAgg.VotedTotal,
+			BlockCount:            epochAgg.BlockCount,
+			OrphanedCount:         epochAgg.OrphanedCount,
+			AttestationCount:      epochAgg.AttestationCount,
+			DepositCount:          epochAgg.DepositCount,
+			ExitCount:             epochAgg.ExitCount,
+			WithdrawCount:         epochAgg.WithdrawCount,
+			WithdrawAmount:        epochAgg.WithdrawAmount,
+			AttesterSlashingCount: epochAgg.AttesterSlashingCount,
+			ProposerSlashingCount: epochAgg.ProposerSlashingCount,
+			BLSChangeCount:        epochAgg.BLSChangeCount,
+			EthTransactionCount:   epochAgg.EthTransactionCount,
+			SyncParticipation:     math.Float32frombits(epochAgg.SyncParticipation),
+		})
+	}
+
+	return epochStats
+}
+
+// getEpochStats pages in the spilled epoch stats for the given epoch and dependent root.
+func (c *spillCache) getEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root) *EpochStats {
+	if !c.isEnabled() || epoch >= c.getSpilledEpoch() {
+		return nil
+	}
+
+	statsKey := getEpochStatsKey(epoch, dependentRoot)
+	if epochStats, isOk := c.statsPage.Get(statsKey); isOk {
+		c.pageInHit++
+		return epochStats
+	}
+
+	recordSsz, err := c.spillDb.Get(getSpillKey(spillKeyEpochStats, getSpillUint64(uint64(epoch)), dependentRoot[:]), nil)
+	if err != nil {
+		return nil
+	}
+	c.pageInMiss++
+
+	epochStats := c.loadEpochStats(epoch, dependentRoot, recordSsz)
+	if epochStats != nil {
+		c.statsPage.Add(statsKey, epochStats)
+	}
+
+	return epochStats
+}
+
+// getEpochStatsByEpoch pages in all spilled epoch stats for the given epoch.
+func (c *spillCache) getEpochStatsByEpoch(epoch phase0.Epoch) []*EpochStats {
+	if !c.isEnabled() || epoch >= c.getSpilledEpoch() {
+		return nil
+	}
+
+	roots := c.getSpillIndexRoots(getSpillKey(spillKeyEpochStats, getSpillUint64(uint64(epoch))))
+	statsList := make([]*EpochStats, 0, len(roots))
+	for _, dependentRoot := range roots {
+		if epochStats := c.getEpochStats(epoch, dependentRoot); epochStats != nil {
+			statsList = append(statsList, epochStats)
+		}
+	}
+
+	return statsList
+}
+
+// unspillEpochStats pages in the spilled epoch stats for the given epoch and dependent root and removes them from the spill cache.
+// used when the epoch stats are needed in the epoch cache again.
+func (c *spillCache) unspillEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root) *EpochStats {
+	epochStats := c.getEpochStats(epoch, dependentRoot)
+	if epochStats != nil {
+		c.removeEpochStats(epoch, dependentRoot)
+	}
+
+	return epochStats
+}
+
+// removeEpochStats removes the spilled epoch stats for the given epoch and dependent root.
+func (c *spillCache) removeEpochStats(epoch phase0.Epoch, dependentRoot phase0.Root) {
+	if !c.isEnabled() || epoch >= c.getSpilledEpoch() {
+		return
+	}
+
+	statsKey := getEpochStatsKey(epoch, dependentRoot)
+	spillKey := getSpillKey(spillKeyEpochStats, getSpillUint64(uint64(epoch)), dependentRoot[:])
+
+	c.statsPage.Remove(statsKey)
+	if has, _ := c.spillDb.Has(spillKey, nil); !has {
+		return
+	}
+
+	if err := c.spillDb.Delete(spillKey, nil); err != nil {
+		c.indexer.logger.WithError(err).Warnf("failed removing spilled epoch stats %v (%v)", epoch, dependentRoot.String())
+		return
+	}
+	c.spilledStats--
+}
+
+// addEpochVotes writes the given epoch votes aggregation to the spill cache.
+func (c *spillCache) addEpochVotes(epoch phase0.Epoch, votesKey epochVotesKey, votes *EpochVotes) {
+	if !c.isEnabled() {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, votes); err != nil {
+		return
+	}
+
+	if err := c.spillDb.Put(getSpillKey(spillKeyEpochVotes, getSpillUint64(uint64(epoch)), votesKey[:]), buf.Bytes(), nil); err != nil {
+		c.indexer.logger.WithError(err).Warnf("failed spilling epoch %v votes", epoch)
+		return
+	}
+	c.spilledVotes++
+}
+
+// getEpochVotes loads a spilled epoch votes aggregation.
+func (c *spillCache) getEpochVotes(epoch phase0.Epoch, votesKey epochVotesKey) *EpochVotes {
+	if !c.isEnabled() {
+		return nil
+	}
+
+	data, err := c.spillDb.Get(getSpillKey(spillKeyEpochVotes, getSpillUint64(uint64(epoch)), votesKey[:]), nil)
+	if err != nil {
+		return nil
+	}
+
+	votes := &EpochVotes{}
+	if err := binary.Read(bytes.NewReader(data), binary.LittleEndian, votes); err != nil {
+		return nil
+	}
+
+	return votes
+}
+
+// getSpillKeysInRange returns all keys with the given prefix and a uint64 key part in the range [from, to).
+func (c *spillCache) getSpillKeysInRange(prefix byte, from uint64, to uint64) [][]byte {
+	keys := [][]byte{}
+
+	iter := c.spillDb.NewIterator(&util.Range{
+		Start: getSpillKey(prefix, getSpillUint64(from)),
+		Limit: getSpillKey(prefix, getSpillUint64(to)),
+	}, nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		keys = append(keys, bytes.Clone(iter.Key()))
+	}
+
+	return keys
+}
+
+// cleanup removes all spilled entries of finalized epochs.
+func (c *spillCache) cleanup(finalizedEpoch phase0.Epoch) {
+	if !c.isEnabled() || finalizedEpoch == 0 {
+		return
+	}
+
+	chainState := c.indexer.consensusPool.GetChainState()
+	batch := new(leveldb.Batch)
+
+	if c.getSpilledSlot() > 0 {
+		for _, key := range c.getSpillKeysInRange(spillKeySlot, 0, uint64(chainState.EpochToSlot(finalizedEpoch))) {
+			c.deleteBlock(batch, phase0.Root(key[len(key)-32:]))
+		}
+	}
+
+	if c.getSpilledEpoch() > 0 {
+		statsKeys := c.getSpillKeysInRange(spillKeyEpochStats, 0, uint64(finalizedEpoch))
+		for _, key := range statsKeys {
+			batch.Delete(key)
+			c.spilledStats--
+		}
+		if len(statsKeys) > 0 {
+			c.statsPage.Purge()
+		}
+	}
+
+	for _, key := range c.getSpillKeysInRange(spillKeyEpochVotes, 0, uint64(finalizedEpoch)) {
+		batch.Delete(key)
+		c.spilledVotes--
+	}
+
+	if batch.Len() == 0 {
+		return
+	}
+
+	if err := c.spillDb.Write(batch, nil); err != nil {
+		c.indexer.logger.WithError(err).Warnf("failed cleaning up spill cache")
+	}
+}
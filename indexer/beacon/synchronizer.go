@@ -29,9 +29,15 @@ type synchronizer struct {
 	stateMutex   sync.Mutex
 	running      bool
 	currentEpoch phase0.Epoch
+	reindexState *dbtypes.IndexerReindexState
+	resyncEpochs map[phase0.Epoch]bool
+	recentRanges []*dbtypes.IndexerRecentSyncRange
+}
 
-	cachedSlot   phase0.Slot
-	cachedBlocks map[phase0.Slot]*Block
+// SyncGap is a range of finalized epochs that has not been synchronized yet.
+type SyncGap struct {
+	FromEpoch phase0.Epoch // first epoch of the gap
+	ToEpoch   phase0.Epoch // first epoch after the gap
 }
 
 func (indexer *Indexer) startSynchronizer(startEpoch phase0.Epoch) {
@@ -43,10 +49,67 @@ func (indexer *Indexer) startSynchronizer(startEpoch phase0.Epoch) {
 	}
 }
 
+// ResyncEpochs schedules a synchronization of the given finalized epochs, overwriting the existing db entries.
+func (indexer *Indexer) ResyncEpochs(epochs []phase0.Epoch) error {
+	if indexer.disableSync || indexer.synchronizer == nil {
+		return fmt.Errorf("synchronizer is disabled")
+	}
+	if len(epochs) == 0 {
+		return nil
+	}
+
+	startEpoch := indexer.synchronizer.addResyncEpochs(epochs)
+	indexer.startSynchronizer(startEpoch)
+	return nil
+}
+
+// GetPendingResyncEpochs returns the epochs that are scheduled for synchronization via ResyncEpochs.
+func (indexer *Indexer) GetPendingResyncEpochs() []phase0.Epoch {
+	sync := indexer.synchronizer
+	if sync == nil {
+		return nil
+	}
+
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+
+	epochs := make([]phase0.Epoch, 0, len(sync.resyncEpochs))
+	for epoch := range sync.resyncEpochs {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(a, b int) bool {
+		return epochs[a] < epochs[b]
+	})
+	return epochs
+}
+
+// GetSyncGaps returns the finalized epoch ranges that are not synchronized yet by the recent-first synchronization.
+func (indexer *Indexer) GetSyncGaps() []SyncGap {
+	sync := indexer.synchronizer
+	if sync == nil {
+		return nil
+	}
+
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+
+	syncGaps := make([]SyncGap, 0, len(sync.recentRanges))
+	for _, syncRange := range sync.recentRanges {
+		if syncRange.TailEpoch > syncRange.TargetEpoch {
+			syncGaps = append(syncGaps, SyncGap{
+				FromEpoch: phase0.Epoch(syncRange.TargetEpoch),
+				ToEpoch:   phase0.Epoch(syncRange.TailEpoch),
+			})
+		}
+	}
+	return syncGaps
+}
+
 func newSynchronizer(indexer *Indexer, logger logrus.FieldLogger) *synchronizer {
 	sync := &synchronizer{
-		indexer: indexer,
-		logger:  logger,
+		indexer:      indexer,
+		logger:       logger,
+		resyncEpochs: map[phase0.Epoch]bool{},
 	}
 
 	// restore sync state
@@ -55,6 +118,44 @@ func newSynchronizer(indexer *Indexer, logger logrus.FieldLogger) *synchronizer
 		sync.currentEpoch = phase0.Epoch(syncState.Epoch)
 	}
 
+	// restore requested reindex range
+	reindexState := &dbtypes.IndexerReindexState{}
+	if _, err := db.GetExplorerState("indexer.reindexstate", reindexState); err == nil {
+		sync.reindexState = reindexState
+		if phase0.Epoch(reindexState.FromEpoch) < sync.currentEpoch {
+			sync.currentEpoch = phase0.Epoch(reindexState.FromEpoch)
+		}
+		logger.Infof("reindexing epochs %v - %v", reindexState.FromEpoch, reindexState.ToEpoch)
+	}
+
+	// restore scheduled resync epochs
+	resyncState := &dbtypes.IndexerResyncState{}
+	if _, err := db.GetExplorerState("indexer.resyncstate", resyncState); err == nil {
+		for _, epoch := range resyncState.Epochs {
+			sync.resyncEpochs[phase0.Epoch(epoch)] = true
+			if phase0.Epoch(epoch) < sync.currentEpoch {
+				sync.currentEpoch = phase0.Epoch(epoch)
+			}
+		}
+		if len(resyncState.Epochs) > 0 {
+			logger.Infof("resyncing %v scheduled epochs", len(resyncState.Epochs))
+		}
+	}
+
+	// restore recent-first sync ranges
+	recentSyncState := &dbtypes.IndexerRecentSyncState{}
+	if _, err := db.GetExplorerState("indexer.recentsyncstate", recentSyncState); err == nil {
+		sync.recentRanges = recentSyncState.Ranges
+		if !utils.Config.Indexer.SyncRecentFirst {
+			// recent-first mode has been disabled, synchronize the remaining gaps forward
+			for _, syncRange := range sync.recentRanges {
+				if syncRange.TailEpoch > syncRange.TargetEpoch && phase0.Epoch(syncRange.TargetEpoch) < sync.currentEpoch {
+					sync.currentEpoch = phase0.Epoch(syncRange.TargetEpoch)
+				}
+			}
+		}
+	}
+
 	return sync
 }
 
@@ -113,6 +214,128 @@ func (s *synchronizer) stopSync() {
 	lockedMutex = &s.runMutex
 }
 
+// syncEpochResult holds the data of an epoch that has been loaded by a sync worker and is waiting to be persisted.
+type syncEpochResult struct {
+	epoch        phase0.Epoch
+	client       *Client
+	skipped      bool
+	lastTry      bool
+	err          error
+	duration     time.Duration
+	waitTime     time.Duration
+	blocks       []*Block
+	stateLoaded  bool
+	epochStats   *EpochStats
+	validatorSet []*phase0.Validator
+}
+
+// syncClientLimit tracks the adaptive number of epochs that are loaded from a client in parallel.
+// The limit is increased while the client responds fast and reduced when it slows down or fails.
+type syncClientLimit struct {
+	limit       int
+	inflight    int
+	baseLatency time.Duration
+}
+
+func (cl *syncClientLimit) update(latency time.Duration, failed bool, maxLimit int) {
+	switch {
+	case failed:
+		cl.limit = max(cl.limit/2, 1)
+	case cl.baseLatency == 0 || latency < cl.baseLatency:
+		cl.baseLatency = latency
+		cl.limit = min(cl.limit+1, maxLimit)
+	case latency <= cl.baseLatency*2:
+		cl.limit = min(cl.limit+1, maxLimit)
+		cl.baseLatency += (latency - cl.baseLatency) / 20
+	default:
+		// the base latency slowly follows slower responses, so a single fast epoch does not throttle the client forever
+		cl.limit = max(cl.limit-1, 1)
+		cl.baseLatency += (latency - cl.baseLatency) / 20
+	}
+}
+
+// syncStateLimiter limits the number of epochs that hold a loaded validator set, from loading the state until the epoch is committed.
+// The head epoch may always acquire a slot, as the buffered results of later epochs are only released after the head epoch has been committed.
+type syncStateLimiter struct {
+	mutex     sync.Mutex
+	cond      *sync.Cond
+	limit     int
+	held      map[phase0.Epoch]bool
+	headEpoch phase0.Epoch
+}
+
+func newSyncStateLimiter(limit int) *syncStateLimiter {
+	limiter := &syncStateLimiter{
+		limit: limit,
+		held:  map[phase0.Epoch]bool{},
+	}
+	limiter.cond = sync.NewCond(&limiter.mutex)
+	return limiter
+}
+
+// acquire waits for a free slot for the given epoch, returns false if the context has been cancelled.
+func (limiter *syncStateLimiter) acquire(ctx context.Context, epoch phase0.Epoch) bool {
+	stopWakeup := context.AfterFunc(ctx, func() {
+		limiter.mutex.Lock()
+		limiter.cond.Broadcast()
+		limiter.mutex.Unlock()
+	})
+	defer stopWakeup()
+
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	for len(limiter.held) >= limiter.limit && epoch != limiter.headEpoch && !limiter.held[epoch] {
+		if ctx.Err() != nil {
+			return false
+		}
+		limiter.cond.Wait()
+	}
+
+	limiter.held[epoch] = true
+	return true
+}
+
+// release frees the slot of the given epoch, if it holds one.
+func (limiter *syncStateLimiter) release(epoch phase0.Epoch) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	if limiter.held[epoch] {
+		delete(limiter.held, epoch)
+		limiter.cond.Broadcast()
+	}
+}
+
+// setHead updates the head epoch, which is allowed to exceed the limit.
+func (limiter *syncStateLimiter) setHead(epoch phase0.Epoch) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+
+	limiter.headEpoch = epoch
+	limiter.cond.Broadcast()
+}
+
+// syncRun holds the state of a single synchronizer run.
+type syncRun struct {
+	sync          *synchronizer
+	maxParallel   int
+	results       map[phase0.Epoch]*syncEpochResult
+	loading       map[phase0.Epoch]bool
+	retryCount    map[phase0.Epoch]int
+	retryTime     map[phase0.Epoch]time.Time
+	clientLimits  map[*Client]*syncClientLimit
+	resultChan    chan *syncEpochResult
+	stateLimiter  *syncStateLimiter
+	lastNoClients time.Time
+
+	headEpoch phase0.Epoch // next epoch to persist
+	tailRoot  phase0.Root  // root of the last canonical block before the head epoch, if hasTail is set
+	hasTail   bool
+	endEpoch  phase0.Epoch // first epoch after the synchronized range, if fixedEnd is set
+	fixedEnd  bool         // synchronize up to endEpoch instead of the finalized epoch
+}
+
 func (sync *synchronizer) runSync() {
 	defer utils.HandleSubroutinePanic("runSync", nil)
 
@@ -124,87 +347,675 @@ func (sync *synchronizer) runSync() {
 		sync.syncCtxCancel()
 	}()
 
-	sync.cachedBlocks = make(map[phase0.Slot]*Block)
-	sync.cachedSlot = 0
+	maxParallel := int(utils.Config.Indexer.SyncMaxParallelEpochs)
+	if maxParallel < 1 {
+		maxParallel = 4
+	}
+	maxHeldStates := int(utils.Config.Indexer.SyncMaxHeldValidatorSets)
+	if maxHeldStates < 1 {
+		maxHeldStates = maxParallel
+	}
+
+	run := &syncRun{
+		sync:         sync,
+		maxParallel:  maxParallel,
+		results:      map[phase0.Epoch]*syncEpochResult{},
+		loading:      map[phase0.Epoch]bool{},
+		retryCount:   map[phase0.Epoch]int{},
+		retryTime:    map[phase0.Epoch]time.Time{},
+		clientLimits: map[*Client]*syncClientLimit{},
+		resultChan:   make(chan *syncEpochResult, maxParallel),
+		stateLimiter: newSyncStateLimiter(maxHeldStates),
+	}
+
 	isComplete := false
-	retryCount := 0
+	if utils.Config.Indexer.SyncRecentFirst {
+		sync.logger.Infof("recent-first synchronization started. head epoch: %v (max %v parallel epochs, %v held validator sets)", sync.currentEpoch, maxParallel, maxHeldStates)
+		isComplete = sync.runRecentFirstSync(run)
+	} else {
+		sync.logger.Infof("synchronization started. head epoch: %v (max %v parallel epochs, %v held validator sets)", sync.currentEpoch, maxParallel, maxHeldStates)
+		run.setHeadEpoch(sync.currentEpoch)
+		isComplete = run.syncEpochs()
+
+		if isComplete && len(sync.recentRanges) > 0 {
+			// remaining recent-first ranges have been covered by the forward synchronization
+			sync.stateMutex.Lock()
+			sync.recentRanges = nil
+			sync.stateMutex.Unlock()
+			if err := sync.saveRecentSyncState(); err != nil {
+				sync.logger.Errorf("failed saving recent-first sync state: %v", err)
+			}
+		}
+	}
+
+	// wait for running workers
+	for len(run.loading) > 0 {
+		run.processResult(<-run.resultChan)
+	}
 
-	sync.logger.Infof("synchronization started. head epoch: %v", sync.currentEpoch)
+	if isComplete {
+		sync.logger.Infof("synchronization complete. Head epoch: %v", sync.currentEpoch)
+		db.RunDBTransaction(func(tx *sqlx.Tx) error {
+			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
+				Epoch: uint64(sync.currentEpoch),
+			}, tx)
+		})
+	} else {
+		sync.logger.Infof("synchronization aborted. Head epoch: %v", sync.currentEpoch)
+	}
+
+	sync.running = false
+}
+
+// runRecentFirstSync synchronizes the missing epochs backwards from the finalized checkpoint.
+// Whenever the finalized checkpoint moved ahead of the sync head, a new range is started at the finalized epoch.
+// The ranges are processed newest first in windows of ascending epochs, the range tail is moved down after each window.
+// Returns false if the synchronization has been aborted.
+func (sync *synchronizer) runRecentFirstSync(run *syncRun) bool {
+	windowSize := phase0.Epoch(run.maxParallel * 8)
+	floorEpoch := phase0.Epoch(utils.Config.Indexer.SyncFloorEpoch)
+
+	for sync.syncCtx.Err() == nil {
+		var syncRange, completedRange *dbtypes.IndexerRecentSyncRange
+		stateChanged := false
+
+		sync.stateMutex.Lock()
+		finalizedEpoch := sync.indexer.lastFinalizedEpoch
+		if sync.currentEpoch < finalizedEpoch {
+			targetEpoch := max(sync.currentEpoch, floorEpoch)
+			if targetEpoch < finalizedEpoch {
+				sync.recentRanges = append(sync.recentRanges, &dbtypes.IndexerRecentSyncRange{
+					HeadEpoch:   uint64(finalizedEpoch),
+					TailEpoch:   uint64(finalizedEpoch),
+					TargetEpoch: uint64(targetEpoch),
+				})
+			}
+			sync.currentEpoch = finalizedEpoch
+			stateChanged = true
+		}
+		if rangeCount := len(sync.recentRanges); rangeCount > 0 {
+			syncRange = sync.recentRanges[rangeCount-1]
+			if syncRange.TailEpoch <= syncRange.TargetEpoch {
+				completedRange = syncRange
+				sync.recentRanges = sync.recentRanges[:rangeCount-1]
+				stateChanged = true
+			}
+		}
+		sync.stateMutex.Unlock()
+
+		if stateChanged {
+			if err := sync.saveRecentSyncState(); err != nil {
+				sync.logger.Errorf("failed saving recent-first sync state: %v", err)
+			}
+		}
+
+		if completedRange != nil {
+			sync.logger.Infof("recent-first synchronization of epochs %v - %v complete", completedRange.TargetEpoch, completedRange.HeadEpoch-1)
+			if sync.reindexState != nil && completedRange.TargetEpoch <= max(sync.reindexState.FromEpoch, uint64(floorEpoch)) && completedRange.HeadEpoch > sync.reindexState.ToEpoch {
+				sync.clearReindexState()
+			}
+			continue
+		}
+		if syncRange == nil {
+			return true
+		}
+
+		tailEpoch := phase0.Epoch(syncRange.TailEpoch)
+		windowStart := phase0.Epoch(syncRange.TargetEpoch)
+		if tailEpoch-windowStart > windowSize {
+			windowStart = tailEpoch - windowSize
+		}
+
+		sync.logger.Infof("synchronizing epochs %v - %v (recent-first)", windowStart, tailEpoch-1)
+		run.setHeadEpoch(windowStart)
+		run.endEpoch = tailEpoch
+		run.fixedEnd = true
+		if !run.syncEpochs() {
+			return false
+		}
+
+		sync.stateMutex.Lock()
+		syncRange.TailEpoch = uint64(windowStart)
+		sync.stateMutex.Unlock()
+
+		if err := sync.saveRecentSyncState(); err != nil {
+			sync.logger.Errorf("failed saving recent-first sync state: %v", err)
+		}
+	}
+
+	return false
+}
+
+// saveRecentSyncState persists the recent-first sync ranges together with the forward sync head.
+func (sync *synchronizer) saveRecentSyncState() error {
+	sync.stateMutex.Lock()
+	recentSyncState := &dbtypes.IndexerRecentSyncState{
+		Ranges: make([]*dbtypes.IndexerRecentSyncRange, len(sync.recentRanges)),
+	}
+	for idx, syncRange := range sync.recentRanges {
+		rangeCopy := *syncRange
+		recentSyncState.Ranges[idx] = &rangeCopy
+	}
+	syncState := &dbtypes.IndexerSyncState{
+		Epoch: uint64(sync.currentEpoch),
+	}
+	sync.stateMutex.Unlock()
+
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if err := db.SetExplorerState("indexer.recentsyncstate", recentSyncState, tx); err != nil {
+			return err
+		}
+		return db.SetExplorerState("indexer.syncstate", syncState, tx)
+	})
+}
+
+// syncEpochs synchronizes all epochs from the head epoch up to the end epoch.
+// Returns false if the synchronization has been aborted.
+func (run *syncRun) syncEpochs() bool {
+	sync := run.sync
 
 	for {
-		// synchronize next epoch
-		syncEpoch := sync.currentEpoch
-		syncClients := sync.getSyncClients(syncEpoch)
-		if len(syncClients) == 0 {
-			sync.logger.Warnf("no clients available for synchronization of epoch %v", syncEpoch)
+		run.commitEpochs()
+
+		if run.headEpoch >= run.getEndEpoch() {
+			return true
+		}
+		if sync.syncCtx.Err() != nil {
+			return false
+		}
+
+		if run.dispatchEpochs() {
+			// already synchronized epochs can be committed right away
+			continue
+		}
+
+		select {
+		case result := <-run.resultChan:
+			run.processResult(result)
+		case <-sync.syncCtx.Done():
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func (run *syncRun) setHeadEpoch(epoch phase0.Epoch) {
+	if epoch != run.headEpoch+1 {
+		// the tracked tail root is only valid for consecutive epochs
+		run.hasTail = false
+	}
+	run.headEpoch = epoch
+	run.stateLimiter.setHead(epoch)
+}
+
+func (run *syncRun) getEndEpoch() phase0.Epoch {
+	if run.fixedEnd {
+		return run.endEpoch
+	}
+	return run.sync.indexer.lastFinalizedEpoch
+}
+
+// dispatchEpochs starts loading the next epochs on the sync clients with free capacity.
+// Epochs are loaded at most maxParallel*2 epochs ahead of the head epoch, to limit the number of buffered results.
+// The number of buffered results holding a validator set is limited by the state limiter.
+// Returns true if already synchronized epochs have been skipped.
+func (run *syncRun) dispatchEpochs() bool {
+	sync := run.sync
+	skipped := false
+	now := time.Now()
+	headEpoch := run.headEpoch
+	lookaheadEpoch := headEpoch + phase0.Epoch(run.maxParallel*2)
+	if endEpoch := run.getEndEpoch(); lookaheadEpoch > endEpoch {
+		lookaheadEpoch = endEpoch
+	}
 
-			// wait for 10 seconds before retrying
-			time.Sleep(10 * time.Second)
+	for epoch := headEpoch; epoch < lookaheadEpoch && len(run.loading) < run.maxParallel; epoch++ {
+		if run.results[epoch] != nil || run.loading[epoch] || run.retryTime[epoch].After(now) {
 			continue
 		}
 
-		if syncEpoch >= sync.indexer.lastFinalizedEpoch {
-			isComplete = true
-			break
+		if !utils.Config.Indexer.ResyncForceUpdate && !sync.isReindexEpoch(epoch) && db.IsEpochSynchronized(uint64(epoch)) {
+			run.results[epoch] = &syncEpochResult{
+				epoch:   epoch,
+				skipped: true,
+			}
+			skipped = true
+			continue
+		}
+
+		syncClients := sync.getSyncClients(epoch)
+		if len(syncClients) == 0 {
+			if len(run.loading) == 0 && time.Since(run.lastNoClients) > 10*time.Second {
+				sync.logger.Warnf("no clients available for synchronization of epoch %v", epoch)
+				run.lastNoClients = time.Now()
+			}
+			return skipped
 		}
 
 		retryLimit := len(syncClients)
 		if retryLimit < 30 {
 			retryLimit = 30
 		}
-		lastRetry := retryCount >= retryLimit
-		syncClient := syncClients[retryCount%len(syncClients)]
+		retryCount := run.retryCount[epoch]
+		lastTry := retryCount >= retryLimit
+
+		client := run.selectClient(syncClients, retryCount)
+		if client == nil {
+			// all clients are busy
+			return skipped
+		}
 
 		synclogger := sync.logger.WithFields(logrus.Fields{
-			"epoch":  syncEpoch,
-			"client": syncClient.client.GetName(),
+			"epoch":  epoch,
+			"client": client.client.GetName(),
 		})
-
-		if lastRetry {
-			synclogger.Infof("synchronizing epoch %v (retry: %v, last retry!)", syncEpoch, retryCount)
+		if lastTry {
+			synclogger.Infof("synchronizing epoch %v (retry: %v, last retry!)", epoch, retryCount)
 		} else if retryCount > 0 {
-			synclogger.Infof("synchronizing epoch %v (retry: %v)", syncEpoch, retryCount)
+			synclogger.Infof("synchronizing epoch %v (retry: %v)", epoch, retryCount)
 		} else {
-			synclogger.Infof("synchronizing epoch %v", syncEpoch)
+			synclogger.Infof("synchronizing epoch %v", epoch)
 		}
 
-		done, err := sync.syncEpoch(syncEpoch, syncClient, lastRetry)
-		if done || lastRetry {
-			if err != nil {
-				sync.logger.Errorf("synchronization of epoch %v failed: %v - skipping epoch", syncEpoch, err)
+		run.loading[epoch] = true
+		run.getClientLimit(client).inflight++
+
+		go func(epoch phase0.Epoch, client *Client, lastTry bool) {
+			result := &syncEpochResult{
+				epoch:   epoch,
+				client:  client,
+				lastTry: lastTry,
+				err:     fmt.Errorf("sync worker crashed"),
 			}
-			retryCount = 0
-			sync.stateMutex.Lock()
-			syncEpoch++
-			sync.currentEpoch = syncEpoch
-			sync.stateMutex.Unlock()
-			if syncEpoch >= sync.indexer.lastFinalizedEpoch {
-				isComplete = true
-				break
+			defer func() {
+				run.resultChan <- result
+			}()
+			defer utils.HandleSubroutinePanic("runSync.loadEpoch", nil)
+
+			result = run.loadEpoch(epoch, client, lastTry)
+		}(epoch, client, lastTry)
+	}
+
+	return skipped
+}
+
+// selectClient returns the least busy client relative to its adaptive limit, or nil if all clients are busy.
+// Archive clients are preferred for the first try, retries rotate through all available clients.
+func (run *syncRun) selectClient(syncClients []*Client, retryCount int) *Client {
+	if retryCount > 0 {
+		for i := range syncClients {
+			client := syncClients[(retryCount+i)%len(syncClients)]
+			clientLimit := run.getClientLimit(client)
+			if clientLimit.inflight < clientLimit.limit {
+				return client
 			}
-		} else if err != nil {
-			synclogger.Warnf("synchronization of epoch %v failed: %v - Retrying in 10 sec...", syncEpoch, err)
-			retryCount++
-			time.Sleep(10 * time.Second)
 		}
+		return nil
+	}
 
-		if sync.syncCtx.Err() != nil {
-			break
+	candidates := syncClients
+	if syncClients[0].archive {
+		candidates = make([]*Client, 0, len(syncClients))
+		for _, client := range syncClients {
+			if client.archive {
+				candidates = append(candidates, client)
+			}
 		}
 	}
 
-	if isComplete {
-		sync.logger.Infof("synchronization complete. Head epoch: %v", sync.currentEpoch)
-		db.RunDBTransaction(func(tx *sqlx.Tx) error {
-			return db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
-				Epoch: uint64(sync.currentEpoch),
-			}, tx)
-		})
+	var bestClient *Client
+	var bestLoad float64
+	for _, client := range candidates {
+		clientLimit := run.getClientLimit(client)
+		if clientLimit.inflight >= clientLimit.limit {
+			continue
+		}
+
+		load := float64(clientLimit.inflight) / float64(clientLimit.limit)
+		if bestClient == nil || load < bestLoad {
+			bestClient = client
+			bestLoad = load
+		}
+	}
+
+	return bestClient
+}
+
+func (run *syncRun) getClientLimit(client *Client) *syncClientLimit {
+	clientLimit := run.clientLimits[client]
+	if clientLimit == nil {
+		clientLimit = &syncClientLimit{
+			limit: 1,
+		}
+		run.clientLimits[client] = clientLimit
+	}
+	return clientLimit
+}
+
+// processResult handles a loaded epoch from a sync worker and adapts the client concurrency.
+func (run *syncRun) processResult(result *syncEpochResult) {
+	sync := run.sync
+	delete(run.loading, result.epoch)
+
+	clientLimit := run.getClientLimit(result.client)
+	clientLimit.inflight--
+
+	if sync.syncCtx.Err() != nil {
+		return
+	}
+
+	clientLimit.update(result.duration, result.err != nil, run.maxParallel)
+
+	if result.err == nil {
+		run.results[result.epoch] = result
+		return
+	}
+
+	if result.lastTry {
+		sync.logger.Errorf("synchronization of epoch %v failed: %v - skipping epoch", result.epoch, result.err)
+		run.results[result.epoch] = &syncEpochResult{
+			epoch:   result.epoch,
+			skipped: true,
+		}
+		return
+	}
+
+	sync.logger.WithFields(logrus.Fields{
+		"epoch":  result.epoch,
+		"client": result.client.client.GetName(),
+	}).Warnf("synchronization of epoch %v failed: %v - Retrying in 10 sec...", result.epoch, result.err)
+	run.scheduleRetry(result.epoch)
+}
+
+func (run *syncRun) scheduleRetry(epoch phase0.Epoch) {
+	delete(run.results, epoch)
+	run.stateLimiter.release(epoch)
+	run.retryCount[epoch]++
+	run.retryTime[epoch] = time.Now().Add(10 * time.Second)
+}
+
+// commitEpochs persists the loaded epochs in order, starting at the head epoch.
+func (run *syncRun) commitEpochs() {
+	sync := run.sync
+
+	for sync.syncCtx.Err() == nil {
+		epoch := run.headEpoch
+		result := run.results[epoch]
+		if result == nil {
+			return
+		}
+
+		if !result.skipped {
+			// the vote aggregation needs the blocks of the next epoch
+			var nextEpochBlocks []*Block
+			nextResult := run.results[epoch+1]
+			switch {
+			case nextResult != nil && !nextResult.skipped:
+				nextEpochBlocks = nextResult.blocks
+			case nextResult == nil && epoch+1 < run.getEndEpoch():
+				// next epoch is still loading or waiting for a retry
+				return
+			default:
+				blocks, err := sync.loadEpochBlocks(result.client, epoch+1)
+				if err != nil {
+					if sync.syncCtx.Err() != nil {
+						return
+					}
+					if !result.lastTry {
+						sync.logger.Warnf("synchronization of epoch %v failed: error fetching next epoch blocks: %v - Retrying in 10 sec...", epoch, err)
+						run.scheduleRetry(epoch)
+						return
+					}
+				}
+				nextEpochBlocks = blocks
+			}
+
+			err := run.persistEpoch(result, nextEpochBlocks)
+			if sync.syncCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				if !result.lastTry {
+					sync.logger.Warnf("synchronization of epoch %v failed: %v - Retrying in 10 sec...", epoch, err)
+					run.scheduleRetry(epoch)
+					return
+				}
+				sync.logger.Errorf("synchronization of epoch %v failed: %v - skipping epoch", epoch, err)
+			}
+		}
+
+		delete(run.results, epoch)
+		delete(run.retryCount, epoch)
+		delete(run.retryTime, epoch)
+		run.stateLimiter.release(epoch)
+
+		switch {
+		case result.skipped:
+			run.hasTail = false
+		case len(result.blocks) > 0:
+			run.tailRoot = result.blocks[len(result.blocks)-1].Root
+			run.hasTail = true
+		}
+
+		run.setHeadEpoch(epoch + 1)
+
+		sync.stateMutex.Lock()
+		isResyncEpoch := sync.resyncEpochs[epoch]
+		delete(sync.resyncEpochs, epoch)
+		if !run.fixedEnd {
+			sync.currentEpoch = epoch + 1
+		}
+		sync.stateMutex.Unlock()
+
+		if isResyncEpoch {
+			sync.persistResyncState()
+		}
+
+		if !run.fixedEnd {
+			sync.checkReindexComplete(epoch + 1)
+		}
+	}
+}
+
+// loadEpoch loads the blocks and the dependent state of an epoch.
+// The state of epochs without blocks is loaded when persisting the epoch, as the dependent root is taken from the previous epoch then.
+func (run *syncRun) loadEpoch(epoch phase0.Epoch, client *Client, lastTry bool) *syncEpochResult {
+	sync := run.sync
+	startTime := time.Now()
+	result := &syncEpochResult{
+		epoch:   epoch,
+		client:  client,
+		lastTry: lastTry,
+	}
+
+	result.blocks, result.err = sync.loadEpochBlocks(client, epoch)
+	if result.err == nil && len(result.blocks) > 0 {
+		result.err = run.loadEpochState(result, getEpochDependentRoot(result.blocks[0]))
+	}
+
+	// waiting for the state limiter does not count towards the client latency
+	result.duration = time.Since(startTime) - result.waitTime
+	return result
+}
+
+func (run *syncRun) loadEpochState(result *syncEpochResult, dependentRoot phase0.Root) error {
+	sync := run.sync
+
+	// the slot is held until the epoch is committed, as the buffered result keeps the validator set in memory
+	waitStart := time.Now()
+	if !run.stateLimiter.acquire(sync.syncCtx, result.epoch) {
+		return sync.syncCtx.Err()
+	}
+	result.waitTime += time.Since(waitStart)
+	stateHeld := false
+	defer func() {
+		if !stateHeld {
+			run.stateLimiter.release(result.epoch)
+		}
+	}()
+
+	epochState := newEpochState(dependentRoot)
+	state, err := epochState.loadState(sync.syncCtx, result.client, nil)
+	if (err != nil || epochState.loadingStatus != 2) && !result.lastTry {
+		return fmt.Errorf("error fetching epoch %v state: %v", result.epoch, err)
+	}
+
+	if state == nil {
+		sync.logger.Warnf("state for epoch %v not found", result.epoch)
 	} else {
-		sync.logger.Infof("synchronization aborted. Head epoch: %v", sync.currentEpoch)
+		result.validatorSet, err = state.Validators()
+		if err != nil {
+			sync.logger.Warnf("error getting validator set from state %v: %v", dependentRoot.String(), err)
+		}
 	}
 
-	sync.running = false
+	if epochState.loadingStatus == 2 {
+		result.epochStats = newEpochStats(result.epoch, dependentRoot)
+		result.epochStats.dependentState = epochState
+		result.epochStats.processState(sync.indexer, result.validatorSet)
+	}
+
+	result.stateLoaded = true
+	stateHeld = true
+	return nil
+}
+
+func (run *syncRun) persistEpoch(result *syncEpochResult, nextEpochBlocks []*Block) error {
+	sync := run.sync
+
+	if !result.stateLoaded {
+		// no blocks in this epoch, the dependent root is the last canonical block before the epoch
+		depRoot, err := run.getEmptyEpochDependentRoot(result, nextEpochBlocks)
+		if err != nil {
+			return err
+		}
+		if err := run.loadEpochState(result, depRoot); err != nil {
+			return err
+		}
+	}
+
+	// the sync state tracks the forward synchronization, ranges synchronized in recent-first mode are tracked separately
+	return sync.indexer.persistSyncedEpoch(result.epoch, result.blocks, nextEpochBlocks, result.epochStats, result.validatorSet, result.lastTry, !run.fixedEnd)
+}
+
+// getEmptyEpochDependentRoot returns the root of the last canonical block before an epoch without blocks.
+// The db cannot be used for this, as the previous epochs might not be synchronized yet in recent-first mode.
+func (run *syncRun) getEmptyEpochDependentRoot(result *syncEpochResult, nextEpochBlocks []*Block) (phase0.Root, error) {
+	if run.hasTail && result.epoch == run.headEpoch {
+		return run.tailRoot, nil
+	}
+
+	// the next epoch has the same dependent root, as this epoch has no blocks
+	if len(nextEpochBlocks) > 0 {
+		return getEpochDependentRoot(nextEpochBlocks[0]), nil
+	}
+
+	// walk back to the last block before the epoch
+	chainState := run.sync.indexer.consensusPool.GetChainState()
+	for slot := chainState.EpochStartSlot(result.epoch); slot > 0; {
+		slot--
+		blockHeader, blockRoot, err := run.sync.loadBlockHeader(result.client, slot)
+		if err != nil {
+			return phase0.Root{}, fmt.Errorf("error fetching slot %v header: %v", slot, err)
+		}
+		if blockHeader != nil {
+			return blockRoot, nil
+		}
+		if run.sync.syncCtx.Err() != nil {
+			return phase0.Root{}, run.sync.syncCtx.Err()
+		}
+	}
+
+	return phase0.Root{}, fmt.Errorf("no block found before epoch %v", result.epoch)
+}
+
+// getEpochDependentRoot returns the dependent root for the epoch of the first block in an epoch.
+func getEpochDependentRoot(firstBlock *Block) phase0.Root {
+	if firstBlock.Slot == 0 { // epoch 0 dependent root is the genesis block
+		return firstBlock.Root
+	}
+	return firstBlock.header.Message.ParentRoot
+}
+
+// isReindexEpoch checks if the epoch is within the requested reindex range or scheduled for resync, so it needs to be synchronized again.
+func (sync *synchronizer) isReindexEpoch(epoch phase0.Epoch) bool {
+	sync.stateMutex.Lock()
+	isResyncEpoch := sync.resyncEpochs[epoch]
+	sync.stateMutex.Unlock()
+	if isResyncEpoch {
+		return true
+	}
+
+	return sync.reindexState != nil && epoch >= phase0.Epoch(sync.reindexState.FromEpoch) && epoch <= phase0.Epoch(sync.reindexState.ToEpoch)
+}
+
+// addResyncEpochs schedules the given epochs for synchronization and returns the lowest scheduled epoch.
+func (sync *synchronizer) addResyncEpochs(epochs []phase0.Epoch) phase0.Epoch {
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+
+	startEpoch := epochs[0]
+	for _, epoch := range epochs {
+		sync.resyncEpochs[epoch] = true
+		if epoch < startEpoch {
+			startEpoch = epoch
+		}
+	}
+
+	sync.persistResyncStateLocked()
+
+	return startEpoch
+}
+
+// persistResyncState writes the scheduled resync epochs to the db, so they survive a restart.
+func (sync *synchronizer) persistResyncState() {
+	sync.stateMutex.Lock()
+	defer sync.stateMutex.Unlock()
+
+	sync.persistResyncStateLocked()
+}
+
+func (sync *synchronizer) persistResyncStateLocked() {
+	resyncState := &dbtypes.IndexerResyncState{
+		Epochs: make([]uint64, 0, len(sync.resyncEpochs)),
+	}
+	for epoch := range sync.resyncEpochs {
+		resyncState.Epochs = append(resyncState.Epochs, uint64(epoch))
+	}
+	sort.Slice(resyncState.Epochs, func(a, b int) bool {
+		return resyncState.Epochs[a] < resyncState.Epochs[b]
+	})
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if len(resyncState.Epochs) == 0 {
+			return db.DeleteExplorerState("indexer.resyncstate", tx)
+		}
+		return db.SetExplorerState("indexer.resyncstate", resyncState, tx)
+	})
+	if err != nil {
+		sync.logger.Errorf("failed persisting resync state: %v", err)
+	}
+}
+
+// checkReindexComplete clears the requested reindex range when the synchronizer passed it.
+func (sync *synchronizer) checkReindexComplete(epoch phase0.Epoch) {
+	if sync.reindexState == nil || epoch <= phase0.Epoch(sync.reindexState.ToEpoch) {
+		return
+	}
+
+	sync.clearReindexState()
+}
+
+func (sync *synchronizer) clearReindexState() {
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.DeleteExplorerState("indexer.reindexstate", tx)
+	})
+	if err != nil {
+		sync.logger.Errorf("failed clearing reindex state: %v", err)
+		return
+	}
+
+	sync.logger.Infof("reindexing epochs %v - %v complete", sync.reindexState.FromEpoch, sync.reindexState.ToEpoch)
+	sync.reindexState = nil
 }
 
 func (sync *synchronizer) getSyncClients(epoch phase0.Epoch) []*Client {
@@ -267,141 +1078,105 @@ func (sync *synchronizer) loadBlockBody(client *Client, root phase0.Root) (*spec
 	return LoadBeaconBlock(ctx, client, root)
 }
 
-func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, lastTry bool) (bool, error) {
-	if !utils.Config.Indexer.ResyncForceUpdate && db.IsEpochSynchronized(uint64(syncEpoch)) {
-		return true, nil
-	}
-
+// loadEpochBlocks loads the canonical blocks of an epoch from the given client.
+func (sync *synchronizer) loadEpochBlocks(client *Client, epoch phase0.Epoch) ([]*Block, error) {
 	chainState := sync.indexer.consensusPool.GetChainState()
-	specs := chainState.GetSpecs()
+	firstSlot := chainState.EpochStartSlot(epoch)
+	lastSlot := chainState.EpochStartSlot(epoch+1) - 1
+	blocks := []*Block{}
 
-	// load headers & blocks from this & next epoch
-	firstSlot := chainState.EpochStartSlot(syncEpoch)
-	lastSlot := chainState.EpochStartSlot(syncEpoch+2) - 1
-	canonicalBlocks := []*Block{}
-	canonicalBlockRoots := [][]byte{}
-	canonicalBlockHashes := [][]byte{}
-	nextEpochCanonicalBlocks := []*Block{}
-
-	var firstBlock *Block
 	for slot := firstSlot; slot <= lastSlot; slot++ {
-		if sync.cachedSlot < slot || sync.cachedBlocks[slot] == nil {
-			blockHeader, blockRoot, err := sync.loadBlockHeader(client, slot)
-			if err != nil {
-				return false, fmt.Errorf("error fetching slot %v header: %v", slot, err)
-			}
-			if blockHeader == nil {
-				continue
-			}
-			if sync.syncCtx.Err() != nil {
-				return false, nil
-			}
-
-			block := newBlock(sync.indexer.dynSsz, blockRoot, slot)
-			block.SetHeader(blockHeader)
-
-			if slot > 0 {
-				blockBody, err := sync.loadBlockBody(client, phase0.Root(blockRoot))
-				if err != nil {
-					return false, fmt.Errorf("error fetching slot %v block: %v", slot, err)
-				}
-				if blockBody == nil {
-					return false, fmt.Errorf("error fetching slot %v block: not found", slot)
-				}
-
-				block.SetBlock(blockBody)
-			}
-
-			sync.cachedBlocks[slot] = block
+		blockHeader, blockRoot, err := sync.loadBlockHeader(client, slot)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching slot %v header: %v", slot, err)
 		}
-
-		if firstBlock == nil && sync.cachedBlocks[slot] != nil {
-			firstBlock = sync.cachedBlocks[slot]
+		if blockHeader == nil {
+			continue
 		}
-
-		if chainState.EpochOfSlot(slot) == syncEpoch {
-			canonicalBlocks = append(canonicalBlocks, sync.cachedBlocks[slot])
-			canonicalBlockRoots = append(canonicalBlockRoots, sync.cachedBlocks[slot].Root[:])
-			if blockIndex := sync.cachedBlocks[slot].GetBlockIndex(); blockIndex != nil {
-				canonicalBlockHashes = append(canonicalBlockHashes, blockIndex.ExecutionHash[:])
-			}
-		} else {
-			nextEpochCanonicalBlocks = append(nextEpochCanonicalBlocks, sync.cachedBlocks[slot])
+		if sync.syncCtx.Err() != nil {
+			return nil, sync.syncCtx.Err()
 		}
-	}
-	sync.cachedSlot = lastSlot
 
-	if sync.syncCtx.Err() != nil {
-		return false, nil
-	}
+		block := newBlock(sync.indexer.dynSsz, blockRoot, slot)
+		block.SetHeader(blockHeader)
 
-	// load epoch state
-	var dependentRoot phase0.Root
-	if firstBlock != nil {
-		if firstBlock.Slot == 0 { // epoch 0 dependent root is the genesis block
-			dependentRoot = firstBlock.Root
-		} else {
-			dependentRoot = firstBlock.header.Message.ParentRoot
+		if slot > 0 {
+			blockBody, err := sync.loadBlockBody(client, phase0.Root(blockRoot))
+			if err != nil {
+				return nil, fmt.Errorf("error fetching slot %v block: %v", slot, err)
+			}
+			if blockBody == nil {
+				return nil, fmt.Errorf("error fetching slot %v block: not found", slot)
+			}
+
+			block.SetBlock(blockBody)
 		}
-	} else {
-		// get from db
-		depRoot := db.GetHighestRootBeforeSlot(uint64(firstSlot), false)
-		dependentRoot = phase0.Root(depRoot)
-	}
 
-	epochState := newEpochState(dependentRoot)
-	state, err := epochState.loadState(sync.syncCtx, client, nil)
-	if (err != nil || epochState.loadingStatus != 2) && !lastTry {
-		return false, fmt.Errorf("error fetching epoch %v state: %v", syncEpoch, err)
+		blocks = append(blocks, block)
 	}
 
-	var validatorSet []*phase0.Validator
-	if state == nil {
-		sync.logger.Warnf("state for epoch %v not found", syncEpoch)
-	} else {
-		validatorSet, err = state.Validators()
-		if err != nil {
-			sync.logger.Warnf("error getting validator set from state %v: %v", dependentRoot.String(), err)
-		}
-	}
+	return blocks, nil
+}
 
-	var epochStats *EpochStats
-	var epochStatsValues *EpochStatsValues
-	if epochState != nil && epochState.loadingStatus == 2 {
-		epochStats = newEpochStats(syncEpoch, dependentRoot)
-		epochStats.dependentState = epochState
-		epochStats.processState(sync.indexer, validatorSet)
-		epochStatsValues = epochStats.GetValues(false)
-	}
+// persistSyncedEpoch aggregates the votes of a finalized epoch and writes the epoch with its canonical blocks to the db.
+// It is used by the synchronizer and the era file importer, so both produce the same db entries.
+func (indexer *Indexer) persistSyncedEpoch(syncEpoch phase0.Epoch, canonicalBlocks []*Block, nextEpochCanonicalBlocks []*Block, epochStats *EpochStats, validatorSet []*phase0.Validator, lastTry bool, updateSyncState bool) error {
+	chainState := indexer.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
 
-	if sync.syncCtx.Err() != nil {
-		return false, nil
+	canonicalBlockRoots := make([][]byte, 0, len(canonicalBlocks))
+	canonicalBlockHashes := make([][]byte, 0, len(canonicalBlocks))
+	for _, block := range canonicalBlocks {
+		canonicalBlockRoots = append(canonicalBlockRoots, block.Root[:])
+		if blockIndex := block.GetBlockIndex(); blockIndex != nil {
+			canonicalBlockHashes = append(canonicalBlockHashes, blockIndex.ExecutionHash[:])
+		}
 	}
 
 	// process epoch vote aggregations
 	var epochVotes *EpochVotes
-	if epochStatsValues != nil {
+	if epochStats != nil && epochStats.GetValues(false) != nil {
 		votingBlocks := make([]*Block, len(canonicalBlocks)+len(nextEpochCanonicalBlocks))
 		copy(votingBlocks, canonicalBlocks)
 		copy(votingBlocks[len(canonicalBlocks):], nextEpochCanonicalBlocks)
-		epochVotes = sync.indexer.aggregateEpochVotes(syncEpoch, chainState, votingBlocks, epochStats)
+		epochVotes = indexer.aggregateEpochVotes(syncEpoch, chainState, votingBlocks, epochStats)
 		if epochVotes == nil && !lastTry {
-			return false, fmt.Errorf("failed computing votes for epoch %v", syncEpoch)
+			return fmt.Errorf("failed computing votes for epoch %v", syncEpoch)
 		}
 	}
 
-	sim := newStateSimulator(sync.indexer, epochStats)
-	sim.validatorSet = validatorSet
+	// load leftover canonical blocks from a previous (broken) synchronization of this epoch, they need to be kept as orphaned blocks
+	firstSlot := chainState.EpochStartSlot(syncEpoch)
+	lastSlot := chainState.EpochStartSlot(syncEpoch+1) - 1
+	staleBlocks, err := indexer.loadStaleOrphanedBlocks(firstSlot, lastSlot, canonicalBlockRoots, lastTry)
+	if err != nil {
+		return err
+	}
+
+	sim := newStateSimulator(indexer, epochStats)
+	if sim != nil {
+		sim.validatorSet = validatorSet
+	}
 
 	// save blocks
-	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
-		err = sync.indexer.dbWriter.persistEpochData(tx, syncEpoch, canonicalBlocks, epochStats, epochVotes, sim)
+	return db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		// demote leftover canonical blocks from a previous (broken) synchronization of this epoch
+		for _, orphanedBlock := range staleBlocks {
+			if err := db.InsertOrphanedBlock(orphanedBlock, tx); err != nil {
+				return fmt.Errorf("failed persisting orphaned block %x: %v", orphanedBlock.Root, err)
+			}
+		}
+		if err := db.OrphanStaleCanonicalSlots(uint64(firstSlot), uint64(lastSlot), canonicalBlockRoots, tx); err != nil {
+			return fmt.Errorf("error orphaning stale canonical blocks: %v", err)
+		}
+
+		err := indexer.dbWriter.persistEpochData(tx, syncEpoch, canonicalBlocks, epochStats, epochVotes, sim)
 		if err != nil {
 			return fmt.Errorf("error persisting epoch data to db: %v", err)
 		}
 
 		// persist sync committee assignments
-		if err := sync.indexer.dbWriter.persistSyncAssignments(tx, syncEpoch, epochStats); err != nil {
+		if err := indexer.dbWriter.persistSyncAssignments(tx, syncEpoch, epochStats); err != nil {
 			return fmt.Errorf("error persisting sync committee assignments to db: %v", err)
 		}
 
@@ -424,25 +1199,70 @@ func (sync *synchronizer) syncEpoch(syncEpoch phase0.Epoch, client *Client, last
 			}
 		}
 
-		err = db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
-			Epoch: uint64(syncEpoch),
-		}, tx)
-		if err != nil {
-			return fmt.Errorf("error while updating sync state: %v", err)
+		if updateSyncState {
+			err = db.SetExplorerState("indexer.syncstate", &dbtypes.IndexerSyncState{
+				Epoch: uint64(syncEpoch),
+			}, tx)
+			if err != nil {
+				return fmt.Errorf("error while updating sync state: %v", err)
+			}
 		}
 
 		return nil
 	})
+}
+
+// loadStaleOrphanedBlocks builds the orphaned block entries for canonical blocks in the db that are not part of the canonical roots.
+// The blocks are taken from the block cache or loaded from the clients. Blocks that cannot be loaded on the last try are skipped.
+func (indexer *Indexer) loadStaleOrphanedBlocks(firstSlot phase0.Slot, lastSlot phase0.Slot, canonicalRoots [][]byte, lastTry bool) ([]*dbtypes.OrphanedBlock, error) {
+	staleSlots, err := db.GetStaleCanonicalSlots(uint64(firstSlot), uint64(lastSlot), canonicalRoots)
 	if err != nil {
-		return false, err
+		return nil, fmt.Errorf("error loading stale canonical blocks: %v", err)
 	}
 
-	// cleanup cache (remove blocks from this epoch)
-	for slot := range sync.cachedBlocks {
-		if slot <= lastSlot {
-			delete(sync.cachedBlocks, slot)
+	orphanedBlocks := make([]*dbtypes.OrphanedBlock, 0, len(staleSlots))
+	for _, staleSlot := range staleSlots {
+		blockRoot := phase0.Root(staleSlot.Root)
+		block := indexer.blockCache.getBlockByRoot(blockRoot)
+		if block == nil || block.GetHeader() == nil || block.GetBlock() == nil {
+			block = indexer.loadStaleBlock(blockRoot, phase0.Slot(staleSlot.Slot))
+		}
+		if block == nil {
+			if !lastTry {
+				return nil, fmt.Errorf("failed loading stale canonical block %v (%v)", staleSlot.Slot, blockRoot.String())
+			}
+			indexer.logger.Warnf("failed loading stale canonical block %v (%v), orphaning slot without block data", staleSlot.Slot, blockRoot.String())
+			continue
 		}
+
+		orphanedBlock, err := block.buildOrphanedBlock(indexer.blockCompression)
+		if err != nil {
+			return nil, fmt.Errorf("failed building orphaned block %v (%v): %v", block.Slot, block.Root.String(), err)
+		}
+		orphanedBlocks = append(orphanedBlocks, orphanedBlock)
+	}
+
+	return orphanedBlocks, nil
+}
+
+// loadStaleBlock loads the header and body of a block by root from the ready clients.
+func (indexer *Indexer) loadStaleBlock(blockRoot phase0.Root, slot phase0.Slot) *Block {
+	for _, client := range indexer.GetReadyClients(true) {
+		header, err := LoadBeaconHeader(context.Background(), client, blockRoot)
+		if err != nil || header == nil {
+			continue
+		}
+
+		blockBody, err := LoadBeaconBlock(context.Background(), client, blockRoot)
+		if err != nil || blockBody == nil {
+			continue
+		}
+
+		block := newBlock(indexer.dynSsz, blockRoot, slot)
+		block.SetHeader(header)
+		block.SetBlock(blockBody)
+		return block
 	}
 
-	return true, nil
+	return nil
 }
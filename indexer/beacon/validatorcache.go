@@ -2,11 +2,15 @@ package beacon
 
 import (
 	"bytes"
+	"cmp"
+	"database/sql"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"hash/crc64"
 	"math"
 	"runtime/debug"
+	"slices"
 	"sync"
 	"time"
 
@@ -29,25 +33,20 @@ const (
 	ValidatorStatusCompounding                    // Validator is set to compound rewards (0x02)
 )
 
-// validatorCache manages the in-memory cache of validator states and handles updates
+// validatorCache manages the in-memory cache of validator states and handles updates.
+// The finalized validator set is stored in columnar segments (see validatorSegment), unfinalized
+// changes are tracked as sparse per-fork diffs on top of it.
 type validatorCache struct {
 	indexer                  *Indexer
-	valsetCache              []*validatorEntry // cache for validators
-	cacheMutex               sync.RWMutex      // mutex to protect valsetCache for concurrent access
-	lastFinalized            phase0.Epoch      // last finalized epoch
+	segments                 []*validatorSegment                        // columnar finalized validator set (copy-on-write)
+	validatorCount           uint64                                     // number of validators covered by segments
+	validatorDiffs           map[phase0.ValidatorIndex][]*validatorDiff // unfinalized validator changes per fork
+	cacheMutex               sync.RWMutex                               // mutex to protect the cache for concurrent access
+	lastFinalized            phase0.Epoch                               // last finalized epoch
 	lastFinalizedActiveCount uint64
 	triggerDbUpdate          chan bool
 }
 
-// validatorEntry represents a single validator's state in the cache
-type validatorEntry struct {
-	validatorDiffs []*validatorDiff
-	finalChecksum  uint64
-	finalValidator *phase0.Validator
-	activeData     *ValidatorData
-	statusFlags    uint16
-}
-
 // ValidatorData contains the essential validator state information for active validators
 type ValidatorData struct {
 	ActivationEligibilityEpoch phase0.Epoch
@@ -67,6 +66,7 @@ type validatorDiff struct {
 func newValidatorCache(indexer *Indexer) *validatorCache {
 	cache := &validatorCache{
 		indexer:         indexer,
+		validatorDiffs:  map[phase0.ValidatorIndex][]*validatorDiff{},
 		triggerDbUpdate: make(chan bool, 1),
 	}
 
@@ -122,21 +122,13 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 
 	t1 := time.Now()
 
-	if len(cache.valsetCache) < len(validators) {
-		if len(validators) > cap(cache.valsetCache) {
-			newCache := make([]*validatorEntry, len(validators), len(validators)+1000)
-			copy(newCache, cache.valsetCache)
-			cache.valsetCache = newCache
-		} else {
-			cache.valsetCache = cache.valsetCache[:len(validators)]
-		}
-	}
-
+	writer := cache.newSegmentWriter()
 	isParentMap := map[phase0.Root]bool{}
 	isAheadMap := map[phase0.Root]bool{}
 	updatedCount := uint64(0)
 
 	for i := range validators {
+		index := phase0.ValidatorIndex(i)
 		var parentChecksum uint64
 		var parentValidator *phase0.Validator
 		parentEpoch := phase0.Epoch(0)
@@ -145,22 +137,19 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 		foundAhead := false
 		aheadEpoch := phase0.Epoch(math.MaxInt64)
 
-		cachedValidator := cache.valsetCache[i]
-		if cachedValidator == nil {
-			cachedValidator = &validatorEntry{}
-			cache.valsetCache[i] = cachedValidator
-
-			cache.indexer.pubkeyCache.Add(validators[i].PublicKey, phase0.ValidatorIndex(i))
+		if !writer.isPresent(index) {
+			writer.setPresent(index)
+			cache.indexer.pubkeyCache.Add(validators[i].PublicKey, index)
 		} else {
-			parentValidator = cachedValidator.finalValidator
-			parentChecksum = cachedValidator.finalChecksum
+			parentChecksum = writer.getChecksum(index)
 		}
 
+		validatorDiffs := cache.validatorDiffs[index]
 		deleteKeys := []int{}
 
 		if !isFinalizedValidatorSet {
 			// search for parent diffs that this update depends on
-			for diffkey, diff := range cachedValidator.validatorDiffs {
+			for diffkey, diff := range validatorDiffs {
 				if diff.epoch < cutOffEpoch {
 					deleteKeys = append(deleteKeys, diffkey)
 					continue
@@ -206,30 +195,33 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 		}
 
 		if isFinalizedValidatorSet {
-			cachedValidator.finalValidator = validators[i]
-			cachedValidator.finalChecksum = checksum
-			cachedValidator.statusFlags = GetValidatorStatusFlags(validators[i])
+			writer.setValidator(index, validators[i], checksum, cache.isActiveValidator(&ValidatorData{
+				ActivationEligibilityEpoch: validators[i].ActivationEligibilityEpoch,
+				ActivationEpoch:            validators[i].ActivationEpoch,
+				ExitEpoch:                  validators[i].ExitEpoch,
+			}))
+			writer.setPending(index, validators[i], epoch)
 			updatedCount++
 		}
 
-		if foundAhead && cache.checkValidatorEqual(cachedValidator.validatorDiffs[aheadDiffIdx].validator, validators[i]) {
+		if foundAhead && cache.checkValidatorEqual(validatorDiffs[aheadDiffIdx].validator, validators[i]) {
 			if isFinalizedValidatorSet {
 				deleteKeys = append(deleteKeys, aheadDiffIdx)
 			} else {
-				diff := cachedValidator.validatorDiffs[aheadDiffIdx]
+				diff := validatorDiffs[aheadDiffIdx]
 				diff.epoch = epoch
 				diff.dependentRoot = dependentRoot
-				cachedValidator.validatorDiffs[aheadDiffIdx] = diff
+				validatorDiffs[aheadDiffIdx] = diff
 			}
 		} else if isFinalizedValidatorSet {
 		} else if len(deleteKeys) == 0 {
-			cachedValidator.validatorDiffs = append(cachedValidator.validatorDiffs, &validatorDiff{
+			validatorDiffs = append(validatorDiffs, &validatorDiff{
 				epoch:         epoch,
 				dependentRoot: dependentRoot,
 				validator:     validators[i],
 			})
 		} else {
-			cachedValidator.validatorDiffs[deleteKeys[0]] = &validatorDiff{
+			validatorDiffs[deleteKeys[0]] = &validatorDiff{
 				epoch:         epoch,
 				dependentRoot: dependentRoot,
 				validator:     validators[i],
@@ -238,7 +230,7 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 		}
 
 		if len(deleteKeys) > 0 {
-			lastIdx := len(cachedValidator.validatorDiffs) - 1
+			lastIdx := len(validatorDiffs) - 1
 			delLen := len(deleteKeys)
 			for delIdx := 0; delIdx < delLen; delIdx++ {
 				for delLen > 0 && deleteKeys[delLen-1] == lastIdx {
@@ -248,14 +240,22 @@ func (cache *validatorCache) updateValidatorSet(slot phase0.Slot, dependentRoot
 				if delLen == 0 {
 					break
 				}
-				cachedValidator.validatorDiffs[deleteKeys[delIdx]] = cachedValidator.validatorDiffs[lastIdx]
+				validatorDiffs[deleteKeys[delIdx]] = validatorDiffs[lastIdx]
 				lastIdx--
 			}
 
-			cachedValidator.validatorDiffs = cachedValidator.validatorDiffs[:lastIdx+1]
+			validatorDiffs = validatorDiffs[:lastIdx+1]
+		}
+
+		if len(validatorDiffs) > 0 {
+			cache.validatorDiffs[index] = validatorDiffs
+		} else if cache.validatorDiffs[index] != nil {
+			delete(cache.validatorDiffs, index)
 		}
 	}
 
+	writer.commit()
+
 	if updatedCount > 0 {
 		select {
 		case cache.triggerDbUpdate <- true:
@@ -319,7 +319,7 @@ func (cache *validatorCache) getValidatorSetSize() uint64 {
 	cache.cacheMutex.RLock()
 	defer cache.cacheMutex.RUnlock()
 
-	return uint64(len(cache.valsetCache))
+	return cache.validatorCount
 }
 
 // getValidatorFlags returns the status flags for a specific validator
@@ -328,11 +328,12 @@ func (cache *validatorCache) getValidatorFlags(validatorIndex phase0.ValidatorIn
 	cache.cacheMutex.RLock()
 	defer cache.cacheMutex.RUnlock()
 
-	if validatorIndex >= phase0.ValidatorIndex(len(cache.valsetCache)) || cache.valsetCache[validatorIndex] == nil {
+	segment, pos := getSegmentPosition(cache.segments, validatorIndex)
+	if segment == nil || segment.entryFlags[pos]&validatorEntryPresent == 0 {
 		return 0
 	}
 
-	return cache.valsetCache[validatorIndex].statusFlags
+	return segment.statusFlags[pos]
 }
 
 // setFinalizedEpoch sets the last finalized epoch and updates the validator set
@@ -341,52 +342,39 @@ func (cache *validatorCache) setFinalizedEpoch(epoch phase0.Epoch, nextEpochDepe
 	defer cache.cacheMutex.Unlock()
 
 	cache.lastFinalized = epoch
-	activeCount := uint64(0)
 	updatedCount := uint64(0)
+	writer := cache.newSegmentWriter()
 
-	for _, cachedValidator := range cache.valsetCache {
-		if cachedValidator == nil {
-			continue
-		}
-
+	for index, validatorDiffs := range cache.validatorDiffs {
 		// Find the finalized validator state
-		for _, diff := range cachedValidator.validatorDiffs {
+		for _, diff := range validatorDiffs {
 			if diff.dependentRoot == nextEpochDependentRoot {
-				cachedValidator.finalValidator = diff.validator
-				cachedValidator.finalChecksum = calculateValidatorChecksum(diff.validator)
-				cachedValidator.statusFlags = GetValidatorStatusFlags(diff.validator)
+				writer.setValidator(index, diff.validator, calculateValidatorChecksum(diff.validator), true)
+				writer.setPending(index, diff.validator, diff.epoch)
 				updatedCount++
-
-				cachedValidator.activeData = &ValidatorData{
-					ActivationEligibilityEpoch: diff.validator.ActivationEligibilityEpoch,
-					ActivationEpoch:            diff.validator.ActivationEpoch,
-					ExitEpoch:                  diff.validator.ExitEpoch,
-					EffectiveBalanceEth:        uint16(diff.validator.EffectiveBalance / EtherGweiFactor),
-				}
 				break
 			}
 		}
 
 		// Clean up old diffs
 		newDiffs := make([]*validatorDiff, 0)
-		for _, diff := range cachedValidator.validatorDiffs {
+		for _, diff := range validatorDiffs {
 			if diff.epoch > epoch {
 				newDiffs = append(newDiffs, diff)
 			}
 		}
-		cachedValidator.validatorDiffs = newDiffs
 
-		// clear old active data
-		if cachedValidator.activeData != nil {
-			if !cache.isActiveValidator(cachedValidator.activeData) {
-				cachedValidator.activeData = nil
-			} else {
-				activeCount++
-			}
+		if len(newDiffs) > 0 {
+			cache.validatorDiffs[index] = newDiffs
+		} else {
+			delete(cache.validatorDiffs, index)
 		}
 	}
 
-	cache.lastFinalizedActiveCount = activeCount
+	// clear old active data
+	cache.lastFinalizedActiveCount = writer.clearActive(cache.isActiveValidator)
+
+	writer.commit()
 
 	if updatedCount > 0 {
 		select {
@@ -396,8 +384,87 @@ func (cache *validatorCache) setFinalizedEpoch(epoch phase0.Epoch, nextEpochDepe
 	}
 }
 
+// ValidatorSetStreamer is the callback used to stream the validator set.
+// activeData is only valid for the duration of the callback and must not be retained.
 type ValidatorSetStreamer func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error
 
+// ValidatorSetFilter narrows down a validator set stream.
+// The checks are evaluated on the columnar validator data before the callback is invoked.
+type ValidatorSetFilter struct {
+	MinIndex     *phase0.ValidatorIndex // lowest validator index to include
+	MaxIndex     *phase0.ValidatorIndex // highest validator index to include
+	OnlyActive   bool                   // only include validators with active data (active at Epoch if set)
+	OnlyInMemory bool                   // only include validators with a full validator state in memory (unpersisted or unfinalized)
+	StatusFlags  uint16                 // status flags that must all be set
+	Status       []v1.ValidatorState    // only include validators in one of these states at Epoch (ignored if Epoch is nil)
+	Epoch        *phase0.Epoch          // epoch to evaluate OnlyActive and Status against
+	Balances     []phase0.Gwei          // optional validator balances to evaluate Status with
+}
+
+// validatorOverlayEntry represents the resolved unfinalized state of a validator for a specific block root
+type validatorOverlayEntry struct {
+	index  phase0.ValidatorIndex
+	parent *phase0.Validator // latest diff on the chain of the block root
+	ahead  *phase0.Validator // earliest diff on a chain that builds on top of the block root
+}
+
+// getValidatorOverlay resolves the unfinalized validator diffs for a given blockRoot
+// The caller must hold the cache lock. Returns the overlay entries sorted by validator index.
+func (cache *validatorCache) getValidatorOverlay(blockRoot phase0.Root) []validatorOverlayEntry {
+	if len(cache.validatorDiffs) == 0 {
+		return nil
+	}
+
+	isParentMap := map[phase0.Root]bool{}
+	isAheadMap := map[phase0.Root]bool{}
+	overlay := make([]validatorOverlayEntry, 0, len(cache.validatorDiffs))
+
+	for index, validatorDiffs := range cache.validatorDiffs {
+		entry := validatorOverlayEntry{
+			index: index,
+		}
+		validatorEpoch := cache.lastFinalized
+		aheadEpoch := phase0.Epoch(math.MaxInt64)
+
+		for _, diff := range validatorDiffs {
+			isParent, checkedParent := isParentMap[diff.dependentRoot]
+			if !checkedParent {
+				isParent = cache.indexer.blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot)
+				isParentMap[diff.dependentRoot] = isParent
+			}
+
+			if isParent {
+				if diff.epoch >= validatorEpoch {
+					entry.parent = diff.validator
+					validatorEpoch = diff.epoch
+				}
+				continue
+			}
+
+			isAhead, checkedAhead := isAheadMap[diff.dependentRoot]
+			if !checkedAhead {
+				isAhead = cache.indexer.blockCache.isCanonicalBlock(blockRoot, diff.dependentRoot)
+				isAheadMap[diff.dependentRoot] = isAhead
+			}
+
+			if isAhead && diff.epoch < aheadEpoch {
+				entry.ahead = diff.validator
+				aheadEpoch = diff.epoch
+			}
+		}
+
+		if entry.parent != nil || entry.ahead != nil {
+			overlay = append(overlay, entry)
+		}
+	}
+
+	slices.SortFunc(overlay, func(a, b validatorOverlayEntry) int {
+		return cmp.Compare(a.index, b.index)
+	})
+
+	return overlay
+}
+
 // streamValidatorSetForRoot streams the validator set for a given blockRoot
 // Parameters:
 //   - blockRoot: Get the latest validator set that's based on a chain that includes this blockRoot
@@ -408,78 +475,151 @@ type ValidatorSetStreamer func(index phase0.ValidatorIndex, flags uint16, active
 // Returns:
 //   - error: Any error that occurred during streaming
 func (cache *validatorCache) streamValidatorSetForRoot(blockRoot phase0.Root, onlyActive bool, epoch *phase0.Epoch, cb ValidatorSetStreamer) error {
+	return cache.streamFilteredValidatorSetForRoot(blockRoot, &ValidatorSetFilter{
+		OnlyActive: onlyActive,
+		Epoch:      epoch,
+	}, cb)
+}
+
+// streamFilteredValidatorSetForRoot streams the validators matching a filter for a given blockRoot
+// The stream works on a snapshot of the columnar validator set, so the cache lock is not held while
+// the callback is invoked.
+// Parameters:
+//   - blockRoot: Get the latest validator set that's based on a chain that includes this blockRoot
+//   - filter: Filter to apply to the validator set
+//   - cb: Callback function to process each validator (activeData/validator might be nil based on activation/caching state)
+//
+// Returns:
+//   - error: Any error that occurred during streaming
+func (cache *validatorCache) streamFilteredValidatorSetForRoot(blockRoot phase0.Root, filter *ValidatorSetFilter, cb ValidatorSetStreamer) error {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
+	segments := cache.segments
+	validatorCount := cache.validatorCount
+	overlay := cache.getValidatorOverlay(blockRoot)
+	cache.cacheMutex.RUnlock()
 
-	isParentMap := map[phase0.Root]bool{}
-	isAheadMap := map[phase0.Root]bool{}
+	minIndex := uint64(0)
+	if filter.MinIndex != nil {
+		minIndex = uint64(*filter.MinIndex)
+	}
+	endIndex := validatorCount
+	if filter.MaxIndex != nil && uint64(*filter.MaxIndex) < endIndex {
+		endIndex = uint64(*filter.MaxIndex) + 1
+	}
 
-	for index, cachedValidator := range cache.valsetCache {
-		if cachedValidator == nil {
-			continue
+	statusMask := uint64(0)
+	if filter.Epoch != nil {
+		for _, status := range filter.Status {
+			statusMask |= 1 << uint(status)
 		}
+	}
 
-		latestValidator := cachedValidator.finalValidator
-		validatorData := cachedValidator.activeData
-		validatorEpoch := cache.lastFinalized
+	overlayIdx := 0
+	validatorData := ValidatorData{}
+	columnValidator := phase0.Validator{}
 
-		var aheadValidator *phase0.Validator
-		aheadEpoch := phase0.Epoch(math.MaxInt64)
+	for segmentIdx := int(minIndex / validatorSegmentSize); segmentIdx < len(segments); segmentIdx++ {
+		segment := segments[segmentIdx]
+		segmentStart := uint64(segmentIdx) * validatorSegmentSize
+		if segmentStart >= endIndex {
+			break
+		}
 
-		for _, diff := range cachedValidator.validatorDiffs {
-			isParent, checkedParent := isParentMap[diff.dependentRoot]
-			if !checkedParent {
-				isParent = cache.indexer.blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot)
-				isParentMap[diff.dependentRoot] = isParent
+		segmentEnd := min(segmentStart+validatorSegmentSize, endIndex)
+		for overlayIdx < len(overlay) && uint64(overlay[overlayIdx].index) < segmentStart {
+			overlayIdx++
+		}
+
+		if filter.OnlyInMemory && segment.pending == nil && (overlayIdx >= len(overlay) || uint64(overlay[overlayIdx].index) >= segmentEnd) {
+			// no validators with a full state in this segment
+			continue
+		}
+
+		pos := 0
+		if minIndex > segmentStart {
+			pos = int(minIndex - segmentStart)
+		}
+
+		for endPos := int(segmentEnd - segmentStart); pos < endPos; pos++ {
+			entryFlags := segment.entryFlags[pos]
+			if entryFlags&validatorEntryPresent == 0 {
+				continue
 			}
 
-			if isParent && diff.epoch >= validatorEpoch {
-				validatorData = &ValidatorData{
-					ActivationEligibilityEpoch: diff.validator.ActivationEligibilityEpoch,
-					ActivationEpoch:            diff.validator.ActivationEpoch,
-					ExitEpoch:                  diff.validator.ExitEpoch,
-					EffectiveBalanceEth:        uint16(diff.validator.EffectiveBalance / EtherGweiFactor),
+			index := phase0.ValidatorIndex(segmentStart + uint64(pos))
+			latestValidator := segment.getPendingValidator(pos)
+			hasData := entryFlags&validatorEntryActive != 0
+			hasState := entryFlags&validatorEntryFinal != 0
+
+			for overlayIdx < len(overlay) && overlay[overlayIdx].index < index {
+				overlayIdx++
+			}
+			if overlayIdx < len(overlay) && overlay[overlayIdx].index == index {
+				if entry := &overlay[overlayIdx]; entry.parent != nil {
+					latestValidator = entry.parent
+					hasData = true
+				} else if !hasData && entry.ahead != nil {
+					latestValidator = entry.ahead
+					hasData = true
 				}
-				validatorEpoch = diff.epoch
-				latestValidator = diff.validator
 			}
 
-			if !isParent && validatorData == nil {
-				isAhead, checkedAhead := isAheadMap[diff.dependentRoot]
-				if !checkedAhead {
-					isAhead = cache.indexer.blockCache.isCanonicalBlock(blockRoot, diff.dependentRoot)
-					isAheadMap[diff.dependentRoot] = isAhead
-				}
+			if filter.OnlyInMemory && latestValidator == nil {
+				continue
+			}
 
-				if isAhead && diff.epoch < aheadEpoch {
-					aheadValidator = diff.validator
-					aheadEpoch = diff.epoch
+			if hasData {
+				if latestValidator != nil {
+					validatorData.ActivationEligibilityEpoch = latestValidator.ActivationEligibilityEpoch
+					validatorData.ActivationEpoch = latestValidator.ActivationEpoch
+					validatorData.ExitEpoch = latestValidator.ExitEpoch
+					validatorData.EffectiveBalanceEth = uint16(latestValidator.EffectiveBalance / EtherGweiFactor)
+				} else {
+					segment.getValidatorData(pos, &validatorData)
 				}
 			}
-		}
 
-		if validatorData == nil && aheadValidator != nil {
-			validatorData = &ValidatorData{
-				ActivationEligibilityEpoch: aheadValidator.ActivationEligibilityEpoch,
-				ActivationEpoch:            aheadValidator.ActivationEpoch,
-				ExitEpoch:                  aheadValidator.ExitEpoch,
-				EffectiveBalanceEth:        uint16(aheadValidator.EffectiveBalance / EtherGweiFactor),
+			if filter.OnlyActive && (!hasData || (filter.Epoch != nil && (validatorData.ActivationEpoch > *filter.Epoch || validatorData.ExitEpoch < *filter.Epoch))) {
+				continue
 			}
-			latestValidator = aheadValidator
-		}
 
-		if onlyActive && (validatorData == nil || (epoch != nil && (validatorData.ActivationEpoch > *epoch || validatorData.ExitEpoch < *epoch))) {
-			continue
-		}
+			validatorFlags := segment.statusFlags[pos]
+			if latestValidator != nil {
+				validatorFlags = GetValidatorStatusFlags(latestValidator)
+			}
+			if validatorFlags&filter.StatusFlags != filter.StatusFlags {
+				continue
+			}
 
-		validatorFlags := cachedValidator.statusFlags
-		if latestValidator != nil {
-			validatorFlags = GetValidatorStatusFlags(latestValidator)
-		}
+			if statusMask != 0 {
+				validatorState := v1.ValidatorStateUnknown
+				stateValidator := latestValidator
+				if stateValidator == nil && hasState {
+					segment.getValidator(pos, &columnValidator)
+					stateValidator = &columnValidator
+				}
+				if stateValidator != nil {
+					var balance *phase0.Gwei
+					if int(index) < len(filter.Balances) {
+						balance = &filter.Balances[index]
+					}
+					validatorState = v1.ValidatorToState(stateValidator, balance, *filter.Epoch, FarFutureEpoch)
+				}
 
-		err := cb(phase0.ValidatorIndex(index), validatorFlags, validatorData, latestValidator)
-		if err != nil {
-			return err
+				if statusMask&(1<<uint(validatorState)) == 0 {
+					continue
+				}
+			}
+
+			var activeData *ValidatorData
+			if hasData {
+				activeData = &validatorData
+			}
+
+			err := cb(index, validatorFlags, activeData, latestValidator)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -588,20 +728,16 @@ func (cache *validatorCache) getValidatorByIndexAndRoot(index phase0.ValidatorIn
 	cache.cacheMutex.RLock()
 	defer cache.cacheMutex.RUnlock()
 
-	if index >= phase0.ValidatorIndex(len(cache.valsetCache)) {
+	segment, pos := getSegmentPosition(cache.segments, index)
+	if segment == nil || segment.entryFlags[pos]&validatorEntryPresent == 0 {
 		return nil
 	}
 
-	cachedValidator := cache.valsetCache[index]
-	if cachedValidator == nil {
-		return nil
-	}
-
-	validator := cachedValidator.finalValidator
+	validator := segment.getPendingValidator(pos)
 	validatorEpoch := cache.lastFinalized
 
 	// Find the latest valid diff
-	for _, diff := range cachedValidator.validatorDiffs {
+	for _, diff := range cache.validatorDiffs[index] {
 		if cache.indexer.blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot) && diff.epoch >= validatorEpoch {
 			validator = diff.validator
 			validatorEpoch = diff.epoch
@@ -672,9 +808,7 @@ func (cache *validatorCache) prepopulateFromDB() (uint64, error) {
 		return 0, fmt.Errorf("error getting max validator index: %v", err)
 	}
 
-	// Pre-allocate slice
-	cache.valsetCache = make([]*validatorEntry, maxIndex+1, maxIndex+1+1000)
-
+	writer := cache.newSegmentWriter()
 	activeCount := uint64(0)
 	restoreCount := uint64(0)
 
@@ -690,23 +824,17 @@ func (cache *validatorCache) prepopulateFromDB() (uint64, error) {
 		for _, dbVal := range validators {
 			// Convert db validator to phase0.Validator
 			val := UnwrapDbValidator(dbVal)
-			valEntry := &validatorEntry{
-				finalChecksum: calculateValidatorChecksum(val),
-			}
-			valData := &ValidatorData{
-				ActivationEligibilityEpoch: phase0.Epoch(db.ConvertInt64ToUint64(dbVal.ActivationEligibilityEpoch)),
-				ActivationEpoch:            phase0.Epoch(db.ConvertInt64ToUint64(dbVal.ActivationEpoch)),
-				ExitEpoch:                  phase0.Epoch(db.ConvertInt64ToUint64(dbVal.ExitEpoch)),
-				EffectiveBalanceEth:        uint16(val.EffectiveBalance / EtherGweiFactor),
-			}
-			if cache.isActiveValidator(valData) {
-				valEntry.activeData = valData
+			isActive := cache.isActiveValidator(&ValidatorData{
+				ActivationEligibilityEpoch: val.ActivationEligibilityEpoch,
+				ActivationEpoch:            val.ActivationEpoch,
+				ExitEpoch:                  val.ExitEpoch,
+			})
+			if isActive {
 				activeCount++
 			}
-			valEntry.statusFlags = GetValidatorStatusFlags(val)
 
-			// Create cache entry with checksum
-			cache.valsetCache[dbVal.ValidatorIndex] = valEntry
+			// Write columnar cache entry with checksum
+			writer.setValidator(phase0.ValidatorIndex(dbVal.ValidatorIndex), val, calculateValidatorChecksum(val), isActive)
 
 			// Update pubkey cache
 			cache.indexer.pubkeyCache.Add(phase0.BLSPubKey(dbVal.Pubkey), phase0.ValidatorIndex(dbVal.ValidatorIndex))
@@ -715,6 +843,7 @@ func (cache *validatorCache) prepopulateFromDB() (uint64, error) {
 		}
 	}
 
+	writer.commit()
 	cache.lastFinalizedActiveCount = activeCount
 
 	return restoreCount, nil
@@ -750,7 +879,7 @@ func (cache *validatorCache) runPersistLoop() {
 	}
 }
 
-// persistValidators writes a batch of validator states to the database
+// persistValidators writes a batch of validator states to the database and records the changes in the validator history
 // Parameters:
 //   - tx: Database transaction to use for the writes
 //
@@ -759,68 +888,105 @@ func (cache *validatorCache) runPersistLoop() {
 //   - error: any error that occurred during persistence
 func (cache *validatorCache) persistValidators(tx *sqlx.Tx) (bool, error) {
 	cache.cacheMutex.RLock()
-	defer cache.cacheMutex.RUnlock()
+	segments := cache.segments
+	cache.cacheMutex.RUnlock()
 
-	batch := make([]*dbtypes.Validator, 0, 1000)
-	persisted := 0
-	firstIndex := uint64(0)
-	lastIndex := uint64(0)
+	persistIndexes := make([]phase0.ValidatorIndex, 0, 1000)
+	persistValidators := make([]*phase0.Validator, 0, 1000)
+	persistEpochs := make([]phase0.Epoch, 0, 1000)
 	hasMore := false
 
-	for index, entry := range cache.valsetCache {
-		if entry == nil || entry.finalValidator == nil {
+	// collect unpersisted finalized validator states from the segment snapshot
+collectLoop:
+	for segmentIdx, segment := range segments {
+		if segment.pending == nil {
 			continue
 		}
 
-		if persisted == 0 && len(batch) == 0 {
-			firstIndex = uint64(index)
+		for pos, states := range segment.pending.states {
+			for _, state := range states {
+				if len(persistValidators) >= 10000 {
+					hasMore = true
+					break collectLoop // Max 10k validator states per run
+				}
+
+				persistIndexes = append(persistIndexes, phase0.ValidatorIndex(segmentIdx*validatorSegmentSize+pos))
+				persistValidators = append(persistValidators, state.validator)
+				persistEpochs = append(persistEpochs, state.epoch)
+			}
 		}
-		lastIndex = uint64(index)
+	}
 
-		// Convert to db type
-		dbVal := &dbtypes.Validator{
-			ValidatorIndex:             uint64(index),
-			Pubkey:                     entry.finalValidator.PublicKey[:],
-			WithdrawalCredentials:      entry.finalValidator.WithdrawalCredentials[:],
-			EffectiveBalance:           uint64(entry.finalValidator.EffectiveBalance),
-			Slashed:                    entry.finalValidator.Slashed,
-			ActivationEligibilityEpoch: db.ConvertUint64ToInt64(uint64(entry.finalValidator.ActivationEligibilityEpoch)),
-			ActivationEpoch:            db.ConvertUint64ToInt64(uint64(entry.finalValidator.ActivationEpoch)),
-			ExitEpoch:                  db.ConvertUint64ToInt64(uint64(entry.finalValidator.ExitEpoch)),
-			WithdrawableEpoch:          db.ConvertUint64ToInt64(uint64(entry.finalValidator.WithdrawableEpoch)),
+	if len(persistValidators) == 0 {
+		return hasMore, nil
+	}
+
+	// the validator history is only complete from the first recorded epoch on, so remember that epoch as history start
+	historyState := &dbtypes.ValidatorHistoryState{}
+	if _, err := db.GetExplorerState("indexer.validatorhistory", historyState); errors.Is(err, sql.ErrNoRows) {
+		historyState.StartEpoch = uint64(slices.Min(persistEpochs))
+		if err := db.SetExplorerState("indexer.validatorhistory", historyState, tx); err != nil {
+			return false, fmt.Errorf("error persisting validator history start: %v", err)
 		}
+	} else if err != nil {
+		return false, fmt.Errorf("error loading validator history start: %v", err)
+	}
 
-		batch = append(batch, dbVal)
-		entry.finalValidator = nil
+	batch := make([]*dbtypes.Validator, 0, 1000)
+	historyBatch := make([]*dbtypes.ValidatorHistory, 0, 1000)
+	for i, validator := range persistValidators {
+		// Convert to db type (the validators table only stores the latest collected state of each validator)
+		if i == len(persistValidators)-1 || persistIndexes[i+1] != persistIndexes[i] {
+			batch = append(batch, &dbtypes.Validator{
+				ValidatorIndex:             uint64(persistIndexes[i]),
+				Pubkey:                     validator.PublicKey[:],
+				WithdrawalCredentials:      validator.WithdrawalCredentials[:],
+				EffectiveBalance:           uint64(validator.EffectiveBalance),
+				Slashed:                    validator.Slashed,
+				ActivationEligibilityEpoch: db.ConvertUint64ToInt64(uint64(validator.ActivationEligibilityEpoch)),
+				ActivationEpoch:            db.ConvertUint64ToInt64(uint64(validator.ActivationEpoch)),
+				ExitEpoch:                  db.ConvertUint64ToInt64(uint64(validator.ExitEpoch)),
+				WithdrawableEpoch:          db.ConvertUint64ToInt64(uint64(validator.WithdrawableEpoch)),
+			})
+		}
+		historyBatch = append(historyBatch, &dbtypes.ValidatorHistory{
+			ValidatorIndex:             uint64(persistIndexes[i]),
+			StartEpoch:                 uint64(persistEpochs[i]),
+			WithdrawalCredentials:      validator.WithdrawalCredentials[:],
+			EffectiveBalance:           uint64(validator.EffectiveBalance),
+			Slashed:                    validator.Slashed,
+			ActivationEligibilityEpoch: db.ConvertUint64ToInt64(uint64(validator.ActivationEligibilityEpoch)),
+			ActivationEpoch:            db.ConvertUint64ToInt64(uint64(validator.ActivationEpoch)),
+			ExitEpoch:                  db.ConvertUint64ToInt64(uint64(validator.ExitEpoch)),
+			WithdrawableEpoch:          db.ConvertUint64ToInt64(uint64(validator.WithdrawableEpoch)),
+		})
 
-		if len(batch) >= 1000 {
-			err := db.InsertValidatorBatch(batch, tx)
+		if len(historyBatch) >= 1000 || i == len(persistValidators)-1 {
+			// record the changes in the validator history before the latest state is overwritten
+			err := db.InsertValidatorHistoryBatch(historyBatch, tx)
 			if err != nil {
-				return false, fmt.Errorf("error persisting validator batch: %v", err)
+				return false, fmt.Errorf("error persisting validator history batch: %v", err)
 			}
-			batch = batch[:0]
-			persisted += 1000
+			historyBatch = historyBatch[:0]
 
-			if persisted >= 10000 {
-				hasMore = true
-				break // Max 10k validators per run
+			err = db.InsertValidatorBatch(batch, tx)
+			if err != nil {
+				return false, fmt.Errorf("error persisting validator batch: %v", err)
 			}
+			batch = batch[:0]
 		}
 	}
 
-	// Insert remaining batch
-	if len(batch) > 0 {
-		err := db.InsertValidatorBatch(batch, tx)
-		if err != nil {
-			return false, fmt.Errorf("error persisting final validator batch: %v", err)
-		}
-
-		persisted += len(batch)
+	// drop persisted states from the pending lists (unless they have been replaced in the meantime)
+	cache.cacheMutex.Lock()
+	writer := cache.newSegmentWriter()
+	for i, validator := range persistValidators {
+		writer.clearPending(persistIndexes[i], validator, persistEpochs[i])
 	}
+	writer.commit()
+	cache.cacheMutex.Unlock()
 
-	if persisted > 0 {
-		cache.indexer.logger.Infof("persisted %d validators to db [%d-%d]", persisted, firstIndex, lastIndex)
-	}
+	cache.indexer.logger.Infof("persisted %d validator states to db [%d-%d]", len(persistValidators), persistIndexes[0], persistIndexes[len(persistIndexes)-1])
 
 	return hasMore, nil
 }
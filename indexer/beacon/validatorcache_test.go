@@ -0,0 +1,785 @@
+package beacon
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"testing"
+
+	v1 "github.com/attestantio/go-eth2-client/api/v1"
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// Benchmarks comparing the columnar validator cache against the previous pointer based layout
+// (one validatorEntry + ValidatorData per validator), run with:
+//
+//	go test ./indexer/beacon -run '^$' -bench BenchmarkValidatorCache -benchmem
+
+const (
+	benchValidatorCount  = 2_000_000
+	benchInMemoryModulus = 100 // every 100th validator has an unpersisted state
+	benchEpoch           = phase0.Epoch(300000)
+)
+
+// legacyValidatorEntry mirrors the previous per-validator cache entry
+type legacyValidatorEntry struct {
+	validatorDiffs []*validatorDiff
+	finalChecksum  uint64
+	finalValidator *phase0.Validator
+	activeData     *ValidatorData
+	statusFlags    uint16
+}
+
+func newBenchValidator(index int) *phase0.Validator {
+	validator := &phase0.Validator{
+		WithdrawalCredentials:      make([]byte, 32),
+		EffectiveBalance:           32 * EtherGweiFactor,
+		ActivationEligibilityEpoch: phase0.Epoch(index / 100),
+		ActivationEpoch:            phase0.Epoch(index/100 + 5),
+		ExitEpoch:                  FarFutureEpoch,
+		WithdrawableEpoch:          FarFutureEpoch,
+	}
+	validator.PublicKey[0] = byte(index)
+	validator.PublicKey[1] = byte(index >> 8)
+	validator.PublicKey[2] = byte(index >> 16)
+	validator.WithdrawalCredentials[0] = byte(index % 3)
+
+	switch index % 10 {
+	case 0:
+		validator.ExitEpoch = benchEpoch - 1000
+		validator.WithdrawableEpoch = benchEpoch - 744
+	case 1:
+		validator.ExitEpoch = benchEpoch + 100
+		validator.WithdrawableEpoch = benchEpoch + 356
+	}
+	if index%1000 == 0 {
+		validator.Slashed = true
+	}
+
+	return validator
+}
+
+func isBenchActiveValidator(data *ValidatorData) bool {
+	return data.ActivationEligibilityEpoch < FarFutureEpoch && data.ExitEpoch > benchEpoch-10
+}
+
+func buildLegacyValidatorCache(count int) []*legacyValidatorEntry {
+	entries := make([]*legacyValidatorEntry, count, count+1000)
+	for i := 0; i < count; i++ {
+		validator := newBenchValidator(i)
+		entry := &legacyValidatorEntry{
+			finalChecksum: calculateValidatorChecksum(validator),
+			statusFlags:   GetValidatorStatusFlags(validator),
+		}
+		activeData := &ValidatorData{
+			ActivationEligibilityEpoch: validator.ActivationEligibilityEpoch,
+			ActivationEpoch:            validator.ActivationEpoch,
+			ExitEpoch:                  validator.ExitEpoch,
+			EffectiveBalanceEth:        uint16(validator.EffectiveBalance / EtherGweiFactor),
+		}
+		if isBenchActiveValidator(activeData) {
+			entry.activeData = activeData
+		}
+		if i%benchInMemoryModulus == 0 {
+			entry.finalValidator = validator
+		}
+		entries[i] = entry
+	}
+	return entries
+}
+
+func buildColumnarValidatorCache(count int) *validatorCache {
+	cache := &validatorCache{
+		validatorDiffs: map[phase0.ValidatorIndex][]*validatorDiff{},
+	}
+
+	writer := cache.newSegmentWriter()
+	for i := 0; i < count; i++ {
+		validator := newBenchValidator(i)
+		isActive := isBenchActiveValidator(&ValidatorData{
+			ActivationEligibilityEpoch: validator.ActivationEligibilityEpoch,
+			ActivationEpoch:            validator.ActivationEpoch,
+			ExitEpoch:                  validator.ExitEpoch,
+		})
+		writer.setValidator(phase0.ValidatorIndex(i), validator, calculateValidatorChecksum(validator), isActive)
+		if i%benchInMemoryModulus == 0 {
+			writer.setPending(phase0.ValidatorIndex(i), validator, benchEpoch)
+		}
+	}
+	writer.commit()
+
+	return cache
+}
+
+// legacyStreamValidatorSet mirrors the previous streamValidatorSetForRoot loop for a cache without fork diffs
+func legacyStreamValidatorSet(entries []*legacyValidatorEntry, onlyActive bool, epoch *phase0.Epoch, cb ValidatorSetStreamer) error {
+	for index, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		latestValidator := entry.finalValidator
+		validatorData := entry.activeData
+
+		if onlyActive && (validatorData == nil || (epoch != nil && (validatorData.ActivationEpoch > *epoch || validatorData.ExitEpoch < *epoch))) {
+			continue
+		}
+
+		validatorFlags := entry.statusFlags
+		if latestValidator != nil {
+			validatorFlags = GetValidatorStatusFlags(latestValidator)
+		}
+
+		if err := cb(phase0.ValidatorIndex(index), validatorFlags, validatorData, latestValidator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func measureHeapAlloc() uint64 {
+	var memStats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&memStats)
+	return memStats.HeapAlloc
+}
+
+func BenchmarkValidatorCacheBuild(b *testing.B) {
+	b.Run("legacy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := measureHeapAlloc()
+			entries := buildLegacyValidatorCache(benchValidatorCount)
+			after := measureHeapAlloc()
+			b.ReportMetric(float64(after-before)/benchValidatorCount, "heap-B/validator")
+			runtime.KeepAlive(entries)
+		}
+	})
+
+	b.Run("columnar", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			before := measureHeapAlloc()
+			cache := buildColumnarValidatorCache(benchValidatorCount)
+			after := measureHeapAlloc()
+			b.ReportMetric(float64(after-before)/benchValidatorCount, "heap-B/validator")
+			runtime.KeepAlive(cache)
+		}
+	})
+}
+
+func BenchmarkValidatorCacheGC(b *testing.B) {
+	b.Run("legacy", func(b *testing.B) {
+		entries := buildLegacyValidatorCache(benchValidatorCount)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+		}
+		runtime.KeepAlive(entries)
+	})
+
+	b.Run("columnar", func(b *testing.B) {
+		cache := buildColumnarValidatorCache(benchValidatorCount)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			runtime.GC()
+		}
+		runtime.KeepAlive(cache)
+	})
+}
+
+func BenchmarkValidatorCacheStreamActive(b *testing.B) {
+	epoch := benchEpoch
+
+	b.Run("legacy", func(b *testing.B) {
+		entries := buildLegacyValidatorCache(benchValidatorCount)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			effectiveBalance := phase0.Gwei(0)
+			legacyStreamValidatorSet(entries, true, &epoch, func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error {
+				effectiveBalance += activeData.EffectiveBalance()
+				return nil
+			})
+		}
+	})
+
+	b.Run("columnar", func(b *testing.B) {
+		cache := buildColumnarValidatorCache(benchValidatorCount)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			effectiveBalance := phase0.Gwei(0)
+			cache.streamValidatorSetForRoot(phase0.Root{}, true, &epoch, func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error {
+				effectiveBalance += activeData.EffectiveBalance()
+				return nil
+			})
+		}
+	})
+}
+
+// BenchmarkValidatorCacheFilter compares the cache lookup done by the validators page
+// (in-memory validators with withdrawal address in a given index range & state).
+func BenchmarkValidatorCacheFilter(b *testing.B) {
+	epoch := benchEpoch
+	minIndex := phase0.ValidatorIndex(benchValidatorCount / 4)
+	maxIndex := phase0.ValidatorIndex(benchValidatorCount / 2)
+	status := []v1.ValidatorState{v1.ValidatorStateActiveOngoing, v1.ValidatorStateActiveExiting}
+
+	b.Run("legacy", func(b *testing.B) {
+		entries := buildLegacyValidatorCache(benchValidatorCount)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			matches := 0
+			legacyStreamValidatorSet(entries, false, &epoch, func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error {
+				if validator == nil || index < minIndex || index > maxIndex {
+					return nil
+				}
+				if validator.WithdrawalCredentials[0] != 0x01 && validator.WithdrawalCredentials[0] != 0x02 {
+					return nil
+				}
+				validatorState := v1.ValidatorToState(validator, nil, epoch, FarFutureEpoch)
+				if validatorState != status[0] && validatorState != status[1] {
+					return nil
+				}
+				matches++
+				return nil
+			})
+		}
+	})
+
+	b.Run("columnar", func(b *testing.B) {
+		cache := buildColumnarValidatorCache(benchValidatorCount)
+		filter := &ValidatorSetFilter{
+			MinIndex:     &minIndex,
+			MaxIndex:     &maxIndex,
+			OnlyInMemory: true,
+			StatusFlags:  ValidatorStatusHasAddress,
+			Status:       status,
+			Epoch:        &epoch,
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			matches := 0
+			cache.streamFilteredValidatorSetForRoot(phase0.Root{}, filter, func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error {
+				matches++
+				return nil
+			})
+		}
+	})
+}
+
+// Behavioural tests comparing the columnar validator cache against the previous per-validator
+// implementation on a small fork tree:
+//
+//	A (slot 32) <- B (slot 64) <- C (slot 96)
+//	            \- F (slot 64)
+
+const testValidatorCount = 4100
+
+var (
+	testRootA = phase0.Root{0xa}
+	testRootB = phase0.Root{0xb}
+	testRootC = phase0.Root{0xc}
+	testRootF = phase0.Root{0xf}
+)
+
+type testValidatorCacheFixture struct {
+	cache  *validatorCache
+	legacy []*legacyValidatorEntry
+}
+
+func newTestBlockCache() *Indexer {
+	indexer := &Indexer{}
+	indexer.blockCache = newBlockCache(indexer)
+
+	addBlock := func(root phase0.Root, slot phase0.Slot, parentRoot phase0.Root) {
+		block, _ := indexer.blockCache.createOrGetBlock(root, slot)
+		block.SetHeader(&phase0.SignedBeaconBlockHeader{
+			Message: &phase0.BeaconBlockHeader{
+				Slot:       slot,
+				ParentRoot: parentRoot,
+			},
+		})
+	}
+	addBlock(testRootA, 32, phase0.Root{})
+	addBlock(testRootB, 64, testRootA)
+	addBlock(testRootC, 96, testRootB)
+	addBlock(testRootF, 64, testRootA)
+
+	return indexer
+}
+
+// newTestValidatorCacheFixture builds the same validator set in the columnar cache and the legacy layout.
+// Validators around the segment boundary get parent, ahead & fork diffs, every 3rd validator has an unpersisted state.
+func newTestValidatorCacheFixture() *testValidatorCacheFixture {
+	indexer := newTestBlockCache()
+	cache := &validatorCache{
+		indexer:        indexer,
+		validatorDiffs: map[phase0.ValidatorIndex][]*validatorDiff{},
+		lastFinalized:  1,
+	}
+	legacy := make([]*legacyValidatorEntry, testValidatorCount)
+
+	writer := cache.newSegmentWriter()
+	for i := 0; i < testValidatorCount; i++ {
+		validator := newBenchValidator(i)
+		activeData := &ValidatorData{
+			ActivationEligibilityEpoch: validator.ActivationEligibilityEpoch,
+			ActivationEpoch:            validator.ActivationEpoch,
+			ExitEpoch:                  validator.ExitEpoch,
+			EffectiveBalanceEth:        uint16(validator.EffectiveBalance / EtherGweiFactor),
+		}
+		isActive := isBenchActiveValidator(activeData)
+
+		entry := &legacyValidatorEntry{
+			finalChecksum: calculateValidatorChecksum(validator),
+			statusFlags:   GetValidatorStatusFlags(validator),
+		}
+		if isActive {
+			entry.activeData = activeData
+		}
+
+		writer.setValidator(phase0.ValidatorIndex(i), validator, entry.finalChecksum, isActive)
+		if i%3 == 0 || i == 4095 || i == 4096 {
+			writer.setPending(phase0.ValidatorIndex(i), validator, 1)
+			entry.finalValidator = validator
+		}
+		legacy[i] = entry
+	}
+	writer.commit()
+
+	addDiff := func(index int, epoch phase0.Epoch, root phase0.Root, update func(validator *phase0.Validator)) {
+		validator := newBenchValidator(index)
+		update(validator)
+		diff := &validatorDiff{
+			epoch:         epoch,
+			dependentRoot: root,
+			validator:     validator,
+		}
+		cache.validatorDiffs[phase0.ValidatorIndex(index)] = append(cache.validatorDiffs[phase0.ValidatorIndex(index)], diff)
+		legacy[index].validatorDiffs = append(legacy[index].validatorDiffs, diff)
+	}
+
+	for _, index := range []int{5, 4095, 4096, 4099} {
+		addDiff(index, 3, testRootC, func(validator *phase0.Validator) {
+			validator.ExitEpoch = benchEpoch + 50
+		})
+		addDiff(index, 2, testRootB, func(validator *phase0.Validator) {
+			validator.EffectiveBalance = 31 * EtherGweiFactor
+		})
+		addDiff(index, 2, testRootF, func(validator *phase0.Validator) {
+			validator.Slashed = true
+		})
+	}
+
+	// inactive validators that only have diffs on top of B (ahead for A & B)
+	for _, index := range []int{4080, 4090} {
+		addDiff(index, 3, testRootC, func(validator *phase0.Validator) {
+			validator.ExitEpoch = FarFutureEpoch
+			validator.WithdrawableEpoch = FarFutureEpoch
+		})
+	}
+
+	return &testValidatorCacheFixture{
+		cache:  cache,
+		legacy: legacy,
+	}
+}
+
+// legacyStreamValidatorSetForRoot mirrors the previous streamValidatorSetForRoot implementation
+func (fixture *testValidatorCacheFixture) legacyStreamValidatorSetForRoot(blockRoot phase0.Root, onlyActive bool, epoch *phase0.Epoch, cb ValidatorSetStreamer) error {
+	blockCache := fixture.cache.indexer.blockCache
+
+	for index, cachedValidator := range fixture.legacy {
+		latestValidator := cachedValidator.finalValidator
+		validatorData := cachedValidator.activeData
+		validatorEpoch := fixture.cache.lastFinalized
+
+		var aheadValidator *phase0.Validator
+		aheadEpoch := phase0.Epoch(math.MaxInt64)
+
+		for _, diff := range cachedValidator.validatorDiffs {
+			isParent := blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot)
+			if isParent && diff.epoch >= validatorEpoch {
+				validatorData = &ValidatorData{
+					ActivationEligibilityEpoch: diff.validator.ActivationEligibilityEpoch,
+					ActivationEpoch:            diff.validator.ActivationEpoch,
+					ExitEpoch:                  diff.validator.ExitEpoch,
+					EffectiveBalanceEth:        uint16(diff.validator.EffectiveBalance / EtherGweiFactor),
+				}
+				validatorEpoch = diff.epoch
+				latestValidator = diff.validator
+			}
+
+			if !isParent && validatorData == nil && blockCache.isCanonicalBlock(blockRoot, diff.dependentRoot) && diff.epoch < aheadEpoch {
+				aheadValidator = diff.validator
+				aheadEpoch = diff.epoch
+			}
+		}
+
+		if validatorData == nil && aheadValidator != nil {
+			validatorData = &ValidatorData{
+				ActivationEligibilityEpoch: aheadValidator.ActivationEligibilityEpoch,
+				ActivationEpoch:            aheadValidator.ActivationEpoch,
+				ExitEpoch:                  aheadValidator.ExitEpoch,
+				EffectiveBalanceEth:        uint16(aheadValidator.EffectiveBalance / EtherGweiFactor),
+			}
+			latestValidator = aheadValidator
+		}
+
+		if onlyActive && (validatorData == nil || (epoch != nil && (validatorData.ActivationEpoch > *epoch || validatorData.ExitEpoch < *epoch))) {
+			continue
+		}
+
+		validatorFlags := cachedValidator.statusFlags
+		if latestValidator != nil {
+			validatorFlags = GetValidatorStatusFlags(latestValidator)
+		}
+
+		if err := cb(phase0.ValidatorIndex(index), validatorFlags, validatorData, latestValidator); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// legacyGetValidatorByIndexAndRoot mirrors the previous getValidatorByIndexAndRoot implementation (without db fallback)
+func (fixture *testValidatorCacheFixture) legacyGetValidatorByIndexAndRoot(index phase0.ValidatorIndex, blockRoot phase0.Root) *phase0.Validator {
+	cachedValidator := fixture.legacy[index]
+	validator := cachedValidator.finalValidator
+	validatorEpoch := fixture.cache.lastFinalized
+
+	for _, diff := range cachedValidator.validatorDiffs {
+		if fixture.cache.indexer.blockCache.isCanonicalBlock(diff.dependentRoot, blockRoot) && diff.epoch >= validatorEpoch {
+			validator = diff.validator
+			validatorEpoch = diff.epoch
+		}
+	}
+
+	return validator
+}
+
+type testStreamedValidator struct {
+	index     phase0.ValidatorIndex
+	flags     uint16
+	data      ValidatorData
+	hasData   bool
+	validator *phase0.Validator
+}
+
+func collectTestStream(stream func(cb ValidatorSetStreamer) error, minIndex, maxIndex phase0.ValidatorIndex) ([]testStreamedValidator, error) {
+	result := []testStreamedValidator{}
+	err := stream(func(index phase0.ValidatorIndex, flags uint16, activeData *ValidatorData, validator *phase0.Validator) error {
+		if index < minIndex || index > maxIndex {
+			return nil
+		}
+
+		entry := testStreamedValidator{
+			index:     index,
+			flags:     flags,
+			validator: validator,
+		}
+		if activeData != nil {
+			entry.data = *activeData
+			entry.hasData = true
+		}
+		result = append(result, entry)
+		return nil
+	})
+	return result, err
+}
+
+func compareTestStreams(t *testing.T, expected, actual []testStreamedValidator) {
+	t.Helper()
+
+	if len(expected) != len(actual) {
+		t.Fatalf("expected %v streamed validators, got %v", len(expected), len(actual))
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.Fatalf("streamed validator %v mismatch: expected %+v, got %+v", expected[i].index, expected[i], actual[i])
+		}
+	}
+}
+
+func TestValidatorCacheStreamMatchesLegacy(t *testing.T) {
+	fixture := newTestValidatorCacheFixture()
+	epoch := benchEpoch
+
+	roots := map[string]phase0.Root{
+		"A":       testRootA,
+		"B":       testRootB,
+		"C":       testRootC,
+		"F":       testRootF,
+		"unknown": {0xee},
+	}
+	indexRanges := []struct {
+		name     string
+		minIndex *phase0.ValidatorIndex
+		maxIndex *phase0.ValidatorIndex
+	}{
+		{"All", nil, nil},
+		{"SegmentBoundary", ptrValidatorIndex(4095), ptrValidatorIndex(4096)},
+		{"FirstSegmentEnd", nil, ptrValidatorIndex(4095)},
+		{"SecondSegmentStart", ptrValidatorIndex(4096), nil},
+		{"CrossSegment", ptrValidatorIndex(4000), ptrValidatorIndex(4099)},
+		{"SecondSegmentOffset", ptrValidatorIndex(4097), ptrValidatorIndex(4098)},
+		{"FirstSegmentOffset", ptrValidatorIndex(1), ptrValidatorIndex(6)},
+		{"SingleIndex", ptrValidatorIndex(5), ptrValidatorIndex(5)},
+		{"BeyondSet", ptrValidatorIndex(testValidatorCount), nil},
+	}
+
+	for rootName, root := range roots {
+		for _, onlyActive := range []bool{false, true} {
+			for _, indexRange := range indexRanges {
+				name := fmt.Sprintf("%v/active=%v/%v", rootName, onlyActive, indexRange.name)
+				t.Run(name, func(t *testing.T) {
+					minIndex := phase0.ValidatorIndex(0)
+					if indexRange.minIndex != nil {
+						minIndex = *indexRange.minIndex
+					}
+					maxIndex := phase0.ValidatorIndex(math.MaxUint64)
+					if indexRange.maxIndex != nil {
+						maxIndex = *indexRange.maxIndex
+					}
+
+					expected, err := collectTestStream(func(cb ValidatorSetStreamer) error {
+						return fixture.legacyStreamValidatorSetForRoot(root, onlyActive, &epoch, cb)
+					}, minIndex, maxIndex)
+					if err != nil {
+						t.Fatalf("legacy stream failed: %v", err)
+					}
+
+					actual, err := collectTestStream(func(cb ValidatorSetStreamer) error {
+						return fixture.cache.streamFilteredValidatorSetForRoot(root, &ValidatorSetFilter{
+							MinIndex:   indexRange.minIndex,
+							MaxIndex:   indexRange.maxIndex,
+							OnlyActive: onlyActive,
+							Epoch:      &epoch,
+						}, cb)
+					}, 0, math.MaxUint64)
+					if err != nil {
+						t.Fatalf("stream failed: %v", err)
+					}
+
+					compareTestStreams(t, expected, actual)
+				})
+			}
+		}
+	}
+}
+
+func TestValidatorCacheStreamOverlay(t *testing.T) {
+	fixture := newTestValidatorCacheFixture()
+
+	getStreamed := func(root phase0.Root, index phase0.ValidatorIndex) *phase0.Validator {
+		var streamed *phase0.Validator
+		fixture.cache.streamFilteredValidatorSetForRoot(root, &ValidatorSetFilter{
+			MinIndex: &index,
+			MaxIndex: &index,
+		}, func(_ phase0.ValidatorIndex, _ uint16, _ *ValidatorData, validator *phase0.Validator) error {
+			streamed = validator
+			return nil
+		})
+		return streamed
+	}
+
+	for _, index := range []phase0.ValidatorIndex{4095, 4096} {
+		diffs := fixture.cache.validatorDiffs[index]
+
+		// C builds on B, so the latest parent diff (epoch 3 on C) wins
+		if validator := getStreamed(testRootC, index); validator != diffs[0].validator {
+			t.Errorf("validator %v at C: expected diff of C", index)
+		}
+		// the diff of C is ahead of B, but the parent diff of B is preferred
+		if validator := getStreamed(testRootB, index); validator != diffs[1].validator {
+			t.Errorf("validator %v at B: expected diff of B", index)
+		}
+		// fork diffs are not visible on other chains
+		if validator := getStreamed(testRootF, index); validator != diffs[2].validator {
+			t.Errorf("validator %v at F: expected diff of F", index)
+		}
+		// A has no parent diffs and active data, so the pending finalized state is streamed
+		if validator := getStreamed(testRootA, index); validator != fixture.legacy[index].finalValidator {
+			t.Errorf("validator %v at A: expected pending state", index)
+		}
+	}
+
+	// inactive validator without pending state: the ahead diff of C is used for A & B, but not for the fork F
+	aheadDiff := fixture.cache.validatorDiffs[4090][0].validator
+	for _, root := range []phase0.Root{testRootA, testRootB, testRootC} {
+		if validator := getStreamed(root, 4090); validator != aheadDiff {
+			t.Errorf("validator 4090 at %v: expected ahead diff", root.String())
+		}
+	}
+	if validator := getStreamed(testRootF, 4090); validator != nil {
+		t.Errorf("validator 4090 at F: expected no state, got %+v", validator)
+	}
+}
+
+func TestValidatorCacheGetByIndexMatchesLegacy(t *testing.T) {
+	fixture := newTestValidatorCacheFixture()
+
+	for _, root := range []phase0.Root{testRootA, testRootB, testRootC, testRootF, {0xee}} {
+		for index := phase0.ValidatorIndex(0); index < testValidatorCount; index++ {
+			expected := fixture.legacyGetValidatorByIndexAndRoot(index, root)
+			if expected == nil {
+				// resolved via the db fallback
+				continue
+			}
+
+			actual := fixture.cache.getValidatorByIndexAndRoot(index, root)
+			if actual == nil {
+				t.Fatalf("validator %v at %v: expected %+v, got nil", index, root.String(), expected)
+			}
+			if actual == expected {
+				t.Fatalf("validator %v at %v: expected a copy of the cached validator", index, root.String())
+			}
+			if !validatorsEqual(actual, expected) {
+				t.Fatalf("validator %v at %v: expected %+v, got %+v", index, root.String(), expected, actual)
+			}
+		}
+	}
+
+	if validator := fixture.cache.getValidatorByIndexAndRoot(testValidatorCount, testRootC); validator != nil {
+		t.Errorf("expected nil for unknown validator index, got %+v", validator)
+	}
+}
+
+func TestValidatorCacheQueuePendingStates(t *testing.T) {
+	fixture := newTestValidatorCacheFixture()
+	cache := fixture.cache
+
+	for _, index := range []phase0.ValidatorIndex{4095, 4096} {
+		// collect the pending state like persistValidators does
+		segment, pos := getSegmentPosition(cache.segments, index)
+		collected := segment.getPendingValidator(pos)
+		if collected == nil {
+			t.Fatalf("validator %v: expected pending state", index)
+		}
+		pendingCount := segment.pending.count
+		snapshot := cache.segments
+
+		// a newer finalized state is queued before the persisted state is cleared
+		newer := newBenchValidator(int(index))
+		newer.EffectiveBalance = 30 * EtherGweiFactor
+		writer := cache.newSegmentWriter()
+		writer.setPending(index, newer, 2)
+		writer.commit()
+
+		segment, pos = getSegmentPosition(cache.segments, index)
+		if states := segment.pending.states[pos]; len(states) != 2 || states[0].validator != collected || states[1].validator != newer || states[1].epoch != 2 {
+			t.Fatalf("validator %v: expected collected & newer state to be queued, got %+v", index, states)
+		}
+		if segment.pending.count != pendingCount {
+			t.Errorf("validator %v: expected pending count %v, got %v", index, pendingCount, segment.pending.count)
+		}
+		if validator := cache.getValidatorByIndexAndRoot(index, testRootA); validator == nil || validator.EffectiveBalance != newer.EffectiveBalance {
+			t.Errorf("validator %v: expected newer state at A, got %+v", index, validator)
+		}
+
+		// the snapshot taken before the writes is not modified
+		if snapshotSegment, snapshotPos := getSegmentPosition(snapshot, index); len(snapshotSegment.pending.states[snapshotPos]) != 1 {
+			t.Errorf("validator %v: expected snapshot to keep the collected pending state only", index)
+		}
+
+		// a state of the same epoch replaces the queued state
+		replaced := newBenchValidator(int(index))
+		replaced.EffectiveBalance = 31 * EtherGweiFactor
+		writer = cache.newSegmentWriter()
+		writer.setPending(index, replaced, 2)
+		writer.clearPending(index, collected, 1)
+		writer.clearPending(index, newer, 2)
+		writer.commit()
+
+		segment, pos = getSegmentPosition(cache.segments, index)
+		if states := segment.pending.states[pos]; len(states) != 1 || states[0].validator != replaced {
+			t.Fatalf("validator %v: expected replaced state to be kept, got %+v", index, states)
+		}
+
+		// clearing the replaced state removes it
+		writer = cache.newSegmentWriter()
+		writer.clearPending(index, replaced, 2)
+		writer.commit()
+
+		segment, pos = getSegmentPosition(cache.segments, index)
+		if pending := segment.getPendingValidator(pos); pending != nil {
+			t.Errorf("validator %v: expected pending state to be cleared, got %+v", index, pending)
+		}
+	}
+}
+
+func TestValidatorCachePersistQueuedHistory(t *testing.T) {
+	initTestDb(t)
+
+	logger, _ := test.NewNullLogger()
+	cache := &validatorCache{
+		indexer: &Indexer{
+			logger: logrus.NewEntry(logger),
+		},
+		validatorDiffs: map[phase0.ValidatorIndex][]*validatorDiff{},
+	}
+
+	// validator 0 changes in three finalized epochs before the persist loop runs
+	for epoch := phase0.Epoch(1); epoch <= 5; epoch += 2 {
+		validator := newBenchValidator(0)
+		validator.EffectiveBalance = phase0.Gwei(32-(epoch-1)/2) * EtherGweiFactor
+		writer := cache.newSegmentWriter()
+		writer.setPending(0, validator, epoch)
+		writer.setPending(1, newBenchValidator(1), 3)
+		writer.commit()
+	}
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		_, err := cache.persistValidators(tx)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("unexpected persist error: %v", err)
+	}
+
+	history := db.GetValidatorHistory(0, 0)
+	if len(history) != 3 {
+		t.Fatalf("expected 3 history entries, got %v", len(history))
+	}
+	for i, expected := range []struct{ start, end, balance uint64 }{
+		{5, dbtypes.ValidatorHistoryOpenEpoch, 30},
+		{3, 5, 31},
+		{1, 3, 32},
+	} {
+		if entry := history[i]; entry.StartEpoch != expected.start || entry.EndEpoch != expected.end || entry.EffectiveBalance != expected.balance*uint64(EtherGweiFactor) {
+			t.Errorf("history entry %v: expected %+v, got %v-%v (%v)", i, expected, entry.StartEpoch, entry.EndEpoch, entry.EffectiveBalance)
+		}
+	}
+
+	if history := db.GetValidatorHistory(1, 0); len(history) != 1 || history[0].StartEpoch != 3 {
+		t.Errorf("expected a single history entry for validator 1, got %v", len(history))
+	}
+
+	if validator := db.GetValidatorByIndex(0); validator == nil || validator.EffectiveBalance != 30*uint64(EtherGweiFactor) {
+		t.Errorf("expected latest state in validators table, got %+v", validator)
+	}
+
+	if segment, _ := getSegmentPosition(cache.segments, 0); segment.pending != nil {
+		t.Errorf("expected all pending states to be cleared")
+	}
+}
+
+func ptrValidatorIndex(index phase0.ValidatorIndex) *phase0.ValidatorIndex {
+	return &index
+}
+
+func validatorsEqual(a, b *phase0.Validator) bool {
+	return a.PublicKey == b.PublicKey &&
+		string(a.WithdrawalCredentials) == string(b.WithdrawalCredentials) &&
+		a.EffectiveBalance == b.EffectiveBalance &&
+		a.Slashed == b.Slashed &&
+		a.ActivationEligibilityEpoch == b.ActivationEligibilityEpoch &&
+		a.ActivationEpoch == b.ActivationEpoch &&
+		a.ExitEpoch == b.ExitEpoch &&
+		a.WithdrawableEpoch == b.WithdrawableEpoch
+}
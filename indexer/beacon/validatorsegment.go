@@ -0,0 +1,291 @@
+package beacon
+
+import (
+	"math"
+	"slices"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+)
+
+// validatorSegmentSize is the number of validators stored in a single columnar segment
+const validatorSegmentSize = 4096
+
+// validator entry flags stored in the entryFlags column
+const (
+	validatorEntryPresent uint8 = 1 << iota // Validator is known to the cache
+	validatorEntryFinal                     // Validator has a finalized state in the columns
+	validatorEntryActive                    // Validator has active data (eligible and not exited since a few epochs)
+)
+
+// packedFarFutureEpoch is the packed representation of FarFutureEpoch in the epoch columns
+const packedFarFutureEpoch = math.MaxUint32
+
+// validatorSegment stores the finalized state of a consecutive range of validators in columnar layout.
+// Segments are immutable once published to the cache. Writers clone a segment before modifying it,
+// so streams and fork overlays can keep reading a consistent snapshot without holding the cache lock.
+type validatorSegment struct {
+	// pending must stay the first (and only) pointer field, so the GC only scans the first word of the segment.
+	pending *validatorSegmentPending
+
+	checksum                   [validatorSegmentSize]uint64
+	activationEligibilityEpoch [validatorSegmentSize]uint32
+	activationEpoch            [validatorSegmentSize]uint32
+	exitEpoch                  [validatorSegmentSize]uint32
+	withdrawableEpoch          [validatorSegmentSize]uint32
+	effectiveBalanceEth        [validatorSegmentSize]uint16
+	statusFlags                [validatorSegmentSize]uint16
+	entryFlags                 [validatorSegmentSize]uint8
+}
+
+// validatorSegmentPending holds finalized validator states of a segment that have not been persisted to the db yet.
+// Each validator keeps one state per finalized epoch (ordered by epoch), so the validator history records every
+// intermediate state even if the validator changed in several finalized epochs before the persist loop ran.
+type validatorSegmentPending struct {
+	count  int
+	states [validatorSegmentSize][]validatorPendingState
+}
+
+// validatorPendingState is a finalized validator state that is valid from the given epoch on
+type validatorPendingState struct {
+	validator *phase0.Validator
+	epoch     phase0.Epoch
+}
+
+// validatorSegmentWriter applies changes to the segment columns with copy-on-write semantics.
+// Changes become visible to readers when the writer is committed (caller must hold the cache write lock).
+type validatorSegmentWriter struct {
+	cache    *validatorCache
+	segments []*validatorSegment
+	cloned   []bool
+	count    uint64
+}
+
+// packEpoch converts an epoch to its packed column representation
+func packEpoch(epoch phase0.Epoch) uint32 {
+	if epoch >= packedFarFutureEpoch {
+		return packedFarFutureEpoch
+	}
+	return uint32(epoch)
+}
+
+// unpackEpoch converts a packed column epoch back to its epoch value
+func unpackEpoch(epoch uint32) phase0.Epoch {
+	if epoch == packedFarFutureEpoch {
+		return FarFutureEpoch
+	}
+	return phase0.Epoch(epoch)
+}
+
+// getValidatorData returns the active data columns for the validator at the given segment position
+func (segment *validatorSegment) getValidatorData(pos int, data *ValidatorData) {
+	data.ActivationEligibilityEpoch = unpackEpoch(segment.activationEligibilityEpoch[pos])
+	data.ActivationEpoch = unpackEpoch(segment.activationEpoch[pos])
+	data.ExitEpoch = unpackEpoch(segment.exitEpoch[pos])
+	data.EffectiveBalanceEth = segment.effectiveBalanceEth[pos]
+}
+
+// getValidator returns the columnar state of the validator at the given segment position.
+// Public key and withdrawal credentials are not part of the columns and are left untouched.
+func (segment *validatorSegment) getValidator(pos int, validator *phase0.Validator) {
+	validator.EffectiveBalance = phase0.Gwei(segment.effectiveBalanceEth[pos]) * EtherGweiFactor
+	validator.Slashed = segment.statusFlags[pos]&ValidatorStatusSlashed != 0
+	validator.ActivationEligibilityEpoch = unpackEpoch(segment.activationEligibilityEpoch[pos])
+	validator.ActivationEpoch = unpackEpoch(segment.activationEpoch[pos])
+	validator.ExitEpoch = unpackEpoch(segment.exitEpoch[pos])
+	validator.WithdrawableEpoch = unpackEpoch(segment.withdrawableEpoch[pos])
+}
+
+// getPendingValidator returns the latest unpersisted finalized validator state at the given segment position
+func (segment *validatorSegment) getPendingValidator(pos int) *phase0.Validator {
+	if segment.pending == nil {
+		return nil
+	}
+
+	states := segment.pending.states[pos]
+	if len(states) == 0 {
+		return nil
+	}
+	return states[len(states)-1].validator
+}
+
+// getSegmentPosition returns the segment and the position within the segment for a validator index
+func getSegmentPosition(segments []*validatorSegment, index phase0.ValidatorIndex) (*validatorSegment, int) {
+	segmentIdx := int(index / validatorSegmentSize)
+	if segmentIdx >= len(segments) {
+		return nil, 0
+	}
+	return segments[segmentIdx], int(index % validatorSegmentSize)
+}
+
+// newSegmentWriter creates a writer on top of the currently published segments
+func (cache *validatorCache) newSegmentWriter() *validatorSegmentWriter {
+	return &validatorSegmentWriter{
+		cache:    cache,
+		segments: slices.Clone(cache.segments),
+		cloned:   make([]bool, len(cache.segments)),
+		count:    cache.validatorCount,
+	}
+}
+
+// getSegment returns a writable segment and position for a validator index, cloning the published segment on first write
+func (writer *validatorSegmentWriter) getSegment(index phase0.ValidatorIndex) (*validatorSegment, int) {
+	segmentIdx := int(index / validatorSegmentSize)
+	for len(writer.segments) <= segmentIdx {
+		writer.segments = append(writer.segments, &validatorSegment{})
+		writer.cloned = append(writer.cloned, true)
+	}
+
+	if !writer.cloned[segmentIdx] {
+		segment := &validatorSegment{}
+		*segment = *writer.segments[segmentIdx]
+		if segment.pending != nil {
+			pending := &validatorSegmentPending{}
+			*pending = *segment.pending
+			segment.pending = pending
+		}
+
+		writer.segments[segmentIdx] = segment
+		writer.cloned[segmentIdx] = true
+	}
+
+	if uint64(index) >= writer.count {
+		writer.count = uint64(index) + 1
+	}
+
+	return writer.segments[segmentIdx], int(index % validatorSegmentSize)
+}
+
+// getChecksum returns the finalized checksum of a validator (0 if unknown)
+func (writer *validatorSegmentWriter) getChecksum(index phase0.ValidatorIndex) uint64 {
+	segment, pos := getSegmentPosition(writer.segments, index)
+	if segment == nil {
+		return 0
+	}
+	return segment.checksum[pos]
+}
+
+// isPresent returns true if the validator is known to the cache
+func (writer *validatorSegmentWriter) isPresent(index phase0.ValidatorIndex) bool {
+	segment, pos := getSegmentPosition(writer.segments, index)
+	return segment != nil && segment.entryFlags[pos]&validatorEntryPresent != 0
+}
+
+// setPresent marks a validator as known to the cache without setting any finalized state
+func (writer *validatorSegmentWriter) setPresent(index phase0.ValidatorIndex) {
+	segment, pos := writer.getSegment(index)
+	segment.entryFlags[pos] |= validatorEntryPresent
+}
+
+// setValidator writes the finalized state of a validator to the columns
+// Parameters:
+//   - index: The validator index
+//   - validator: The finalized validator state
+//   - checksum: The checksum of the validator state
+//   - active: Whether the validator has active data
+func (writer *validatorSegmentWriter) setValidator(index phase0.ValidatorIndex, validator *phase0.Validator, checksum uint64, active bool) {
+	segment, pos := writer.getSegment(index)
+
+	segment.checksum[pos] = checksum
+	segment.activationEligibilityEpoch[pos] = packEpoch(validator.ActivationEligibilityEpoch)
+	segment.activationEpoch[pos] = packEpoch(validator.ActivationEpoch)
+	segment.exitEpoch[pos] = packEpoch(validator.ExitEpoch)
+	segment.withdrawableEpoch[pos] = packEpoch(validator.WithdrawableEpoch)
+	segment.effectiveBalanceEth[pos] = uint16(validator.EffectiveBalance / EtherGweiFactor)
+	segment.statusFlags[pos] = GetValidatorStatusFlags(validator)
+
+	entryFlags := validatorEntryPresent | validatorEntryFinal
+	if active {
+		entryFlags |= validatorEntryActive
+	}
+	segment.entryFlags[pos] = entryFlags
+}
+
+// setPending queues a finalized validator state for persistence to the db
+// Queued states of the same or a later epoch are replaced, earlier states are kept for the validator history.
+// Parameters:
+//   - index: The validator index
+//   - validator: The finalized validator state
+//   - epoch: The epoch from which on the validator state is valid
+func (writer *validatorSegmentWriter) setPending(index phase0.ValidatorIndex, validator *phase0.Validator, epoch phase0.Epoch) {
+	segment, pos := writer.getSegment(index)
+
+	if segment.pending == nil {
+		segment.pending = &validatorSegmentPending{}
+	}
+
+	states := segment.pending.states[pos]
+	if len(states) == 0 {
+		segment.pending.count++
+	}
+
+	keepCount := len(states)
+	for keepCount > 0 && states[keepCount-1].epoch >= epoch {
+		keepCount--
+	}
+
+	// the state list may be shared with published segments, so it's never modified in place
+	newStates := make([]validatorPendingState, keepCount, keepCount+1)
+	copy(newStates, states[:keepCount])
+	segment.pending.states[pos] = append(newStates, validatorPendingState{
+		validator: validator,
+		epoch:     epoch,
+	})
+}
+
+// clearActive drops the active data flag of all validators that are no longer active
+// Returns the number of validators that remain active
+func (writer *validatorSegmentWriter) clearActive(isActive func(data *ValidatorData) bool) uint64 {
+	activeCount := uint64(0)
+	validatorData := ValidatorData{}
+
+	for segmentIdx, segment := range writer.segments {
+		for pos := 0; pos < validatorSegmentSize; pos++ {
+			if segment.entryFlags[pos]&validatorEntryActive == 0 {
+				continue
+			}
+
+			segment.getValidatorData(pos, &validatorData)
+			if isActive(&validatorData) {
+				activeCount++
+				continue
+			}
+
+			segment, _ = writer.getSegment(phase0.ValidatorIndex(segmentIdx*validatorSegmentSize + pos))
+			segment.entryFlags[pos] &^= validatorEntryActive
+		}
+	}
+
+	return activeCount
+}
+
+// clearPending removes a persisted validator state from the pending list if it hasn't been replaced in the meantime
+// Persisted states are cleared in the order they were collected, so the state is always the oldest queued state.
+func (writer *validatorSegmentWriter) clearPending(index phase0.ValidatorIndex, validator *phase0.Validator, epoch phase0.Epoch) {
+	segment, pos := getSegmentPosition(writer.segments, index)
+	if segment == nil || segment.pending == nil {
+		return
+	}
+
+	states := segment.pending.states[pos]
+	if len(states) == 0 || states[0].validator != validator || states[0].epoch != epoch {
+		return
+	}
+
+	segment, pos = writer.getSegment(index)
+	if len(states) > 1 {
+		segment.pending.states[pos] = states[1:]
+		return
+	}
+
+	segment.pending.states[pos] = nil
+	segment.pending.count--
+	if segment.pending.count <= 0 {
+		segment.pending = nil
+	}
+}
+
+// commit publishes the modified segments to the cache
+func (writer *validatorSegmentWriter) commit() {
+	writer.cache.segments = writer.segments
+	writer.cache.validatorCount = writer.count
+}
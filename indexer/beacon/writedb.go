@@ -3,9 +3,12 @@ package beacon
 import (
 	"fmt"
 	"math"
+	"math/big"
 
+	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/electra"
 	"github.com/attestantio/go-eth2-client/spec/phase0"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethpandaops/dora/clients/consensus"
 	"github.com/ethpandaops/dora/db"
 	"github.com/ethpandaops/dora/dbtypes"
@@ -125,6 +128,12 @@ func (dbw *dbWriter) persistBlockChildObjects(tx *sqlx.Tx, block *Block, deposit
 		return err
 	}
 
+	// insert blob submissions
+	err = dbw.persistBlockBlobSubmissions(tx, block, orphaned)
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -301,6 +310,36 @@ func (dbw *dbWriter) buildDbBlock(block *Block, epochStats *EpochStats, override
 		dbBlock.EthBlockHash = executionBlockHash[:]
 		dbBlock.EthBlockExtra = executionExtraData
 		dbBlock.EthBlockExtraText = utils.GraffitiToString(executionExtraData[:])
+		if feeRecipient, err := getBlockExecutionFeeRecipient(blockBody); err == nil {
+			dbBlock.EthFeeRecipient = feeRecipient[:]
+			if paymentTx := getBlockPaymentTransaction(feeRecipient, executionTransactions); paymentTx != nil {
+				paymentValue := new(big.Int).Div(paymentTx.Value(), utils.GWEI).Uint64()
+				dbBlock.EthPaymentRecipient = paymentTx.To().Bytes()
+				dbBlock.EthPaymentValue = &paymentValue
+			}
+		}
+		if gasLimit, gasUsed, baseFee, err := getBlockExecutionGasInfo(blockBody); err == nil {
+			dbBlock.EthGasLimit = &gasLimit
+			dbBlock.EthGasUsed = &gasUsed
+			if baseFee.IsInt64() {
+				baseFeeValue := baseFee.Uint64()
+				dbBlock.EthBaseFee = &baseFeeValue
+			}
+
+			burntFees := new(big.Int).Mul(baseFee, new(big.Int).SetUint64(gasUsed))
+			burntFeesGwei := burntFees.Div(burntFees, utils.GWEI).Uint64()
+			dbBlock.EthBurntFees = &burntFeesGwei
+		}
+		if blobGasUsed, excessBlobGas, err := getBlockExecutionBlobGas(blockBody); err == nil {
+			blobKzgCommitments, _ := blockBody.BlobKZGCommitments()
+			dbBlock.EthBlobCount = uint64(len(blobKzgCommitments))
+			dbBlock.EthBlobGasUsed = &blobGasUsed
+			dbBlock.EthExcessBlobGas = &excessBlobGas
+			if blobBaseFee := getBlobBaseFee(blockBody.Version, excessBlobGas); blobBaseFee.IsInt64() {
+				blobBaseFeeValue := blobBaseFee.Uint64()
+				dbBlock.EthBlobBaseFee = &blobBaseFeeValue
+			}
+		}
 		dbBlock.WithdrawCount = uint64(len(executionWithdrawals))
 		for _, withdrawal := range executionWithdrawals {
 			dbBlock.WithdrawAmount += uint64(withdrawal.Amount)
@@ -577,6 +616,62 @@ func (dbw *dbWriter) buildDbVoluntaryExits(block *Block, orphaned bool, override
 	return dbVoluntaryExits
 }
 
+func (dbw *dbWriter) persistBlockBlobSubmissions(tx *sqlx.Tx, block *Block, orphaned bool) error {
+	// insert blob submissions
+	dbBlobSubmissions := dbw.buildDbBlobSubmissions(block, orphaned)
+	if len(dbBlobSubmissions) > 0 {
+		err := db.InsertBlobSubmissions(dbBlobSubmissions, tx)
+		if err != nil {
+			return fmt.Errorf("error inserting blob submissions: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (dbw *dbWriter) buildDbBlobSubmissions(block *Block, orphaned bool) []*dbtypes.BlobSubmission {
+	blockBody := block.GetBlock()
+	if blockBody == nil || blockBody.Version < spec.DataVersionDeneb {
+		return nil
+	}
+
+	transactions, err := blockBody.ExecutionTransactions()
+	if err != nil {
+		return nil
+	}
+
+	dbBlobSubmissions := []*dbtypes.BlobSubmission{}
+	for idx, txBytes := range transactions {
+		// blob transactions are type 3, skip all other transactions without decoding them
+		if len(txBytes) == 0 || txBytes[0] != ethtypes.BlobTxType {
+			continue
+		}
+
+		var tx ethtypes.Transaction
+		if err := tx.UnmarshalBinary(txBytes); err != nil {
+			continue
+		}
+
+		txFrom, err := ethtypes.Sender(ethtypes.LatestSignerForChainID(tx.ChainId()), &tx)
+		if err != nil {
+			continue
+		}
+
+		txHash := tx.Hash()
+		dbBlobSubmissions = append(dbBlobSubmissions, &dbtypes.BlobSubmission{
+			SlotNumber: uint64(block.Slot),
+			SlotRoot:   block.Root[:],
+			TxIndex:    uint64(idx),
+			TxHash:     txHash[:],
+			TxFrom:     txFrom[:],
+			BlobCount:  uint64(len(tx.BlobHashes())),
+			Orphaned:   orphaned,
+		})
+	}
+
+	return dbBlobSubmissions
+}
+
 func (dbw *dbWriter) persistBlockSlashings(tx *sqlx.Tx, block *Block, orphaned bool, overrideForkId *ForkKey) error {
 	// insert slashings
 	dbSlashings := dbw.buildDbSlashings(block, orphaned, overrideForkId)
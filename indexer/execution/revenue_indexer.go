@@ -0,0 +1,203 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/clients/execution"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const revenueIndexerStateKey = "indexer.revenueindexer"
+
+// RevenueIndexer calculates the priority fees earned by each finalized block from the execution receipts
+type RevenueIndexer struct {
+	indexerCtx *IndexerCtx
+	logger     logrus.FieldLogger
+	state      *dbtypes.RevenueIndexerState
+	batchSize  uint64
+}
+
+// NewRevenueIndexer creates a new block revenue indexer
+func NewRevenueIndexer(indexer *IndexerCtx) *RevenueIndexer {
+	batchSize := uint64(utils.Config.ExecutionApi.RevenueBatchSize)
+	if batchSize == 0 {
+		batchSize = 100
+	}
+
+	ri := &RevenueIndexer{
+		indexerCtx: indexer,
+		logger:     indexer.logger.WithField("indexer", "revenue"),
+		state:      &dbtypes.RevenueIndexerState{},
+		batchSize:  batchSize,
+	}
+
+	go ri.runRevenueIndexerLoop()
+
+	return ri
+}
+
+// runRevenueIndexerLoop is the main loop for the revenue indexer
+func (ri *RevenueIndexer) runRevenueIndexerLoop() {
+	defer utils.HandleSubroutinePanic("RevenueIndexer.runRevenueIndexerLoop", ri.runRevenueIndexerLoop)
+
+	db.GetExplorerState(revenueIndexerStateKey, ri.state)
+
+	for {
+		time.Sleep(30 * time.Second)
+		ri.logger.Debugf("run revenue indexer logic")
+
+		err := ri.runRevenueIndexer()
+		if err != nil {
+			ri.logger.Errorf("indexer error: %v", err)
+		}
+	}
+}
+
+// runRevenueIndexer processes the newly finalized blocks first and walks back through the older blocks afterwards
+func (ri *RevenueIndexer) runRevenueIndexer() error {
+	finalizedEpoch, _ := ri.indexerCtx.chainState.GetFinalizedCheckpoint()
+	if finalizedEpoch == 0 {
+		return nil
+	}
+
+	finalizedSlot := uint64(ri.indexerCtx.chainState.EpochToSlot(finalizedEpoch))
+	if ri.state.HeadSlot == 0 {
+		ri.state.HeadSlot = finalizedSlot
+		ri.state.BackfillSlot = finalizedSlot
+	}
+
+	// forward: newly finalized blocks
+	for ri.state.HeadSlot < finalizedSlot {
+		lastSlot := min(ri.state.HeadSlot+ri.batchSize, finalizedSlot) - 1
+		err := ri.processSlotRange(ri.state.HeadSlot, lastSlot, func() {
+			ri.state.HeadSlot = lastSlot + 1
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// backward: historic blocks, one batch per run
+	if ri.state.BackfillSlot > 0 {
+		firstSlot := uint64(0)
+		if ri.state.BackfillSlot > ri.batchSize {
+			firstSlot = ri.state.BackfillSlot - ri.batchSize
+		}
+		err := ri.processSlotRange(firstSlot, ri.state.BackfillSlot-1, func() {
+			ri.state.BackfillSlot = firstSlot
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processSlotRange calculates the priority fees for all blocks in the slot range and persists them along with the updated state
+func (ri *RevenueIndexer) processSlotRange(firstSlot uint64, lastSlot uint64, updateState func()) error {
+	revenues, err := db.GetSlotRevenuesWithoutPriorityFees(firstSlot, lastSlot)
+	if err != nil {
+		return err
+	}
+
+	priorityFees := map[string]uint64{}
+	var loadErr error
+	if len(revenues) > 0 {
+		clients := ri.indexerCtx.getFinalizedClients(execution.AnyClient)
+		if len(clients) == 0 {
+			return fmt.Errorf("no ready execution client found")
+		}
+
+		sort.Slice(clients, func(a, b int) bool {
+			return ri.indexerCtx.sortClients(clients[a], clients[b], true)
+		})
+
+		for _, revenue := range revenues {
+			var fees uint64
+			var err error
+			for _, client := range clients {
+				fees, err = ri.loadPriorityFees(client, common.BytesToHash(revenue.EthBlockHash))
+				if err == nil {
+					break
+				}
+			}
+			if err != nil {
+				// stop at the first failed block, the range is retried on the next run
+				loadErr = fmt.Errorf("could not load receipts for slot %v: %v", revenue.Slot, err)
+				break
+			}
+
+			priorityFees[string(revenue.Root)] = fees
+		}
+	}
+
+	// the state is only advanced if all blocks of the range have been processed.
+	// fees of the blocks processed before a failure are saved anyway, so they're skipped on the retry.
+	if loadErr == nil {
+		updateState()
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		for root, fees := range priorityFees {
+			if err := db.UpdateSlotPriorityFees([]byte(root), fees, tx); err != nil {
+				return err
+			}
+		}
+
+		return db.SetExplorerState(revenueIndexerStateKey, ri.state, tx)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving priority fees: %v", err)
+	}
+
+	if loadErr != nil {
+		return loadErr
+	}
+
+	ri.logger.Debugf("calculated priority fees for %v blocks (slot %v - %v)", len(priorityFees), firstSlot, lastSlot)
+	return nil
+}
+
+// loadPriorityFees sums up the priority fees (in gwei) paid by all transactions of an execution block
+func (ri *RevenueIndexer) loadPriorityFees(client *execution.Client, blockHash common.Hash) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	header, err := client.GetRPCClient().GetHeaderByHash(ctx, blockHash)
+	if err != nil {
+		return 0, fmt.Errorf("could not load block header: %v", err)
+	}
+
+	receipts, err := client.GetRPCClient().GetBlockReceipts(ctx, blockHash)
+	if err != nil {
+		return 0, fmt.Errorf("could not load block receipts: %v", err)
+	}
+
+	baseFee := header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	priorityFees := big.NewInt(0)
+	for _, receipt := range receipts {
+		if receipt.EffectiveGasPrice == nil {
+			continue
+		}
+
+		tip := new(big.Int).Sub(receipt.EffectiveGasPrice, baseFee)
+		priorityFees.Add(priorityFees, tip.Mul(tip, new(big.Int).SetUint64(receipt.GasUsed)))
+	}
+
+	return priorityFees.Div(priorityFees, utils.GWEI).Uint64(), nil
+}
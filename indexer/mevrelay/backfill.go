@@ -0,0 +1,207 @@
+package mevrelay
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// number of payloads requested per backfill page
+const mevBackfillPageSize = 200
+
+// interval for checking for newly finalized slots that are not covered by the backfill yet
+const mevBackfillRewalkInterval = 10 * time.Minute
+
+func getMevBackfillStateKey(relay *types.MevRelayConfig) string {
+	return fmt.Sprintf("indexer.mevbackfill.%v", relay.Index)
+}
+
+func (mev *MevIndexer) startBackfill() {
+	if utils.Config.MevIndexer.BackfillInterval == 0 {
+		utils.Config.MevIndexer.BackfillInterval = 2 * time.Second
+	}
+
+	for idx := range utils.Config.MevIndexer.Relays {
+		go mev.runBackfillLoop(&utils.Config.MevIndexer.Relays[idx])
+	}
+}
+
+func (mev *MevIndexer) runBackfillLoop(relay *types.MevRelayConfig) {
+	defer utils.HandleSubroutinePanic("MevIndexer.runBackfillLoop", func() {
+		mev.runBackfillLoop(relay)
+	})
+
+	startSlot := utils.Config.MevIndexer.BackfillStartSlot
+	if relay.BackfillStartSlot > 0 {
+		startSlot = relay.BackfillStartSlot
+	}
+
+	backfillState := &dbtypes.MevRelayBackfillState{}
+	db.GetExplorerState(getMevBackfillStateKey(relay), backfillState)
+
+	for {
+		// backfill only covers finalized slots, the unfinalized range is handled by the regular refresh
+		finalizedEpoch, _ := mev.beaconIndexer.GetBlockCacheState()
+		if finalizedEpoch == 0 {
+			time.Sleep(1 * time.Minute)
+			continue
+		}
+		finalizedSlot := mev.chainState.EpochToSlot(finalizedEpoch) - 1
+
+		if backfillState.WalkEnd == 0 && !getNextMevBackfillWalk(backfillState, startSlot, uint64(finalizedSlot)) {
+			// covered range is up to date, wait for newly finalized slots
+			time.Sleep(mevBackfillRewalkInterval)
+			continue
+		}
+
+		complete, err := mev.backfillRelayPage(relay, backfillState, finalizedSlot)
+		if err != nil {
+			mev.logger.Errorf("mev relay backfill error (%v): %v, retrying in 1 min...", relay.Name, err)
+			time.Sleep(1 * time.Minute)
+			continue
+		}
+
+		if complete {
+			mev.logger.Infof("mev relay backfill for %v completed (covered slots %v - %v)", relay.Name, backfillState.StartSlot, backfillState.EndSlot)
+			continue
+		}
+
+		time.Sleep(utils.Config.MevIndexer.BackfillInterval)
+	}
+}
+
+// getNextMevBackfillWalk prepares the next backfill walk that extends the covered range of the backfill state.
+// The covered range is extended downwards if the start slot has been lowered and upwards for newly finalized slots,
+// so the covered range always stays contiguous. Returns false if the covered range is up to date.
+func getNextMevBackfillWalk(backfillState *dbtypes.MevRelayBackfillState, startSlot uint64, finalizedSlot uint64) bool {
+	switch {
+	case backfillState.EndSlot == 0:
+		// nothing covered yet
+		if finalizedSlot < startSlot {
+			return false
+		}
+		backfillState.WalkStart = startSlot
+		backfillState.WalkEnd = finalizedSlot
+	case startSlot < backfillState.StartSlot:
+		// start slot has been lowered, walk backwards from the previous start slot
+		backfillState.WalkStart = startSlot
+		backfillState.WalkEnd = backfillState.StartSlot - 1
+	case finalizedSlot > backfillState.EndSlot:
+		// newly finalized slots since the last walk
+		backfillState.WalkStart = backfillState.EndSlot + 1
+		backfillState.WalkEnd = finalizedSlot
+	default:
+		return false
+	}
+
+	backfillState.Cursor = backfillState.WalkEnd
+	return true
+}
+
+// completeMevBackfillWalk merges the range of the finished backfill walk into the covered range.
+func completeMevBackfillWalk(backfillState *dbtypes.MevRelayBackfillState) {
+	if backfillState.EndSlot == 0 {
+		backfillState.StartSlot = backfillState.WalkStart
+		backfillState.EndSlot = backfillState.WalkEnd
+	} else {
+		backfillState.StartSlot = min(backfillState.StartSlot, backfillState.WalkStart)
+		backfillState.EndSlot = max(backfillState.EndSlot, backfillState.WalkEnd)
+	}
+
+	backfillState.WalkStart = 0
+	backfillState.WalkEnd = 0
+	backfillState.Cursor = 0
+}
+
+// backfillRelayPage loads a single page of delivered payloads below the backfill cursor and persists them along with the updated cursor.
+// Returns true if the running backfill walk has been completed.
+func (mev *MevIndexer) backfillRelayPage(relay *types.MevRelayConfig, backfillState *dbtypes.MevRelayBackfillState, finalizedSlot phase0.Slot) (bool, error) {
+	blocksResponse, err := mev.fetchRelayPayloads(relay, mevBackfillPageSize, backfillState.Cursor)
+	if err != nil {
+		return false, err
+	}
+
+	// serialize with other relays & the regular refresh, as the relay flags are merged with the stored entries
+	mev.mevBlockCacheMutex.Lock()
+	defer mev.mevBlockCacheMutex.Unlock()
+
+	relayFlag := uint64(1) << relay.Index
+	lowestSlot := backfillState.Cursor
+	mevBlocks := []*dbtypes.MevBlock{}
+	mevBlockHashes := map[common.Hash]bool{}
+
+	for idx, blockData := range blocksResponse {
+		slot, err := strconv.ParseUint(blockData.Slot, 10, 64)
+		if err != nil {
+			mev.logger.Warnf("failed parsing mev block %v.Slot: %v", idx, err)
+			continue
+		}
+
+		if slot < lowestSlot {
+			lowestSlot = slot
+		}
+
+		if slot < backfillState.WalkStart || slot > backfillState.Cursor {
+			continue
+		}
+
+		blockHash := common.HexToHash(blockData.BlockHash)
+		if mevBlockHashes[blockHash] {
+			continue
+		}
+
+		mevBlock := db.GetMevBlockByBlockHash(blockHash[:])
+		if mevBlock != nil {
+			if mevBlock.SeenbyRelays&relayFlag > 0 {
+				continue
+			}
+			mevBlock.SeenbyRelays |= relayFlag
+		} else {
+			mevBlock, err = mev.parseRelayPayload(blockData, slot, relayFlag)
+			if err != nil {
+				mev.logger.Warnf("failed parsing mev block %v: %v", idx, err)
+				continue
+			}
+			mevBlock.Proposed = mev.getMevBlockProposedStatus(mevBlock, finalizedSlot)
+		}
+
+		mevBlocks = append(mevBlocks, mevBlock)
+		mevBlockHashes[blockHash] = true
+	}
+
+	// the relay returns payloads with slot <= cursor, so continue below the lowest returned slot
+	complete := len(blocksResponse) == 0 || lowestSlot <= backfillState.WalkStart || lowestSlot == 0
+	if complete {
+		completeMevBackfillWalk(backfillState)
+	} else if lowestSlot == backfillState.Cursor {
+		// whole page within a single slot, step over it
+		backfillState.Cursor = lowestSlot - 1
+	} else {
+		backfillState.Cursor = lowestSlot
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if len(mevBlocks) > 0 {
+			if err := db.InsertMevBlocks(mevBlocks, tx); err != nil {
+				return err
+			}
+		}
+
+		return db.SetExplorerState(getMevBackfillStateKey(relay), backfillState, tx)
+	})
+	if err != nil {
+		return false, fmt.Errorf("error saving backfilled mev blocks to db: %v", err)
+	}
+
+	mev.logger.Debugf("mev relay backfill for %v: loaded %v payloads, cursor %v", relay.Name, len(mevBlocks), backfillState.Cursor)
+	return complete, nil
+}
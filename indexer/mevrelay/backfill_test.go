@@ -0,0 +1,43 @@
+package mevrelay
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+func TestMevBackfillWalks(t *testing.T) {
+	state := &dbtypes.MevRelayBackfillState{}
+
+	// initial walk covers the start slot up to the finalized slot
+	if !getNextMevBackfillWalk(state, 100, 1000) || state.WalkStart != 100 || state.WalkEnd != 1000 || state.Cursor != 1000 {
+		t.Fatalf("unexpected initial walk: %+v", state)
+	}
+	completeMevBackfillWalk(state)
+	if state.StartSlot != 100 || state.EndSlot != 1000 || state.WalkEnd != 0 {
+		t.Fatalf("unexpected covered range after initial walk: %+v", state)
+	}
+
+	// no walk while the covered range is up to date
+	if getNextMevBackfillWalk(state, 100, 1000) {
+		t.Fatalf("unexpected walk for up to date range: %+v", state)
+	}
+
+	// newly finalized slots are walked again
+	if !getNextMevBackfillWalk(state, 100, 1064) || state.WalkStart != 1001 || state.WalkEnd != 1064 {
+		t.Fatalf("unexpected walk for newly finalized slots: %+v", state)
+	}
+	completeMevBackfillWalk(state)
+	if state.StartSlot != 100 || state.EndSlot != 1064 {
+		t.Fatalf("unexpected covered range after rewalk: %+v", state)
+	}
+
+	// lowered start slot extends the covered range downwards first
+	if !getNextMevBackfillWalk(state, 50, 2000) || state.WalkStart != 50 || state.WalkEnd != 99 {
+		t.Fatalf("unexpected walk for lowered start slot: %+v", state)
+	}
+	completeMevBackfillWalk(state)
+	if state.StartSlot != 50 || state.EndSlot != 1064 {
+		t.Fatalf("unexpected covered range after lowered start slot: %+v", state)
+	}
+}
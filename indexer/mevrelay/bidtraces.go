@@ -0,0 +1,166 @@
+package mevrelay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// max number of past slots to catch up with after a crawler interruption
+const mevBidTracesMaxCatchup = 32
+
+type mevIndexerRelayBidResponse struct {
+	Slot          string `json:"slot"`
+	BlockHash     string `json:"block_hash"`
+	BuilderPubkey string `json:"builder_pubkey"`
+	Value         string `json:"value"`
+	TimestampMs   string `json:"timestamp_ms"`
+}
+
+func (mev *MevIndexer) startBidTracesCrawler() {
+	go mev.runBidTracesLoop()
+}
+
+func (mev *MevIndexer) runBidTracesLoop() {
+	defer utils.HandleSubroutinePanic("MevIndexer.runBidTracesLoop", mev.runBidTracesLoop)
+
+	specs := mev.chainState.GetSpecs()
+	lastCrawledSlot := map[uint8]phase0.Slot{}
+	lastCleanup := time.Time{}
+
+	for {
+		// wait until the auction of the previous slot is surely over
+		currentSlot := mev.chainState.CurrentSlot()
+		nextSlotTime := mev.chainState.SlotToTime(currentSlot + 1)
+		time.Sleep(time.Until(nextSlotTime.Add(4 * time.Second)))
+
+		if mev.chainState.CurrentSlot() == 0 {
+			continue
+		}
+
+		crawlSlot := mev.chainState.CurrentSlot() - 1
+		for idx := range utils.Config.MevIndexer.Relays {
+			relay := &utils.Config.MevIndexer.Relays[idx]
+
+			firstSlot := crawlSlot
+			if lastSlot, ok := lastCrawledSlot[relay.Index]; ok && lastSlot < crawlSlot {
+				firstSlot = max(lastSlot+1, crawlSlot-min(crawlSlot, mevBidTracesMaxCatchup))
+			}
+
+			for slot := firstSlot; slot <= crawlSlot; slot++ {
+				err := mev.crawlBidTraces(relay, slot)
+				if err != nil {
+					mev.logger.Warnf("error loading bid traces for slot %v from relay %v: %v", slot, relay.Name, err)
+					break
+				}
+				lastCrawledSlot[relay.Index] = slot
+			}
+		}
+
+		if retention := utils.Config.MevIndexer.BidTracesRetention; retention > 0 && specs != nil && time.Since(lastCleanup) > 1*time.Hour {
+			retentionSlots := uint64(retention / specs.SecondsPerSlot)
+			if uint64(crawlSlot) > retentionSlots {
+				err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+					return db.DeleteMevBidsBefore(uint64(crawlSlot)-retentionSlots, tx)
+				})
+				if err != nil {
+					mev.logger.Warnf("error cleaning up old bid traces: %v", err)
+				}
+			}
+			lastCleanup = time.Now()
+		}
+	}
+}
+
+// crawlBidTraces loads all builder bids received by a relay for the given slot.
+func (mev *MevIndexer) crawlBidTraces(relay *types.MevRelayConfig, slot phase0.Slot) error {
+	relayUrl, err := url.Parse(relay.Url)
+	if err != nil {
+		return fmt.Errorf("invalid relay url: %v", err)
+	}
+
+	relayUrl.Path = path.Join(relayUrl.Path, "/relay/v1/data/bidtraces/builder_blocks_received")
+	apiUrl := fmt.Sprintf("%v?slot=%v", relayUrl.String(), slot)
+
+	client := &http.Client{Timeout: time.Second * 60}
+	resp, err := client.Get(apiUrl)
+	if err != nil {
+		return fmt.Errorf("could not fetch bid traces (%v): %v", utils.GetRedactedUrl(apiUrl), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
+	}
+
+	bidsResponse := []*mevIndexerRelayBidResponse{}
+	err = json.NewDecoder(resp.Body).Decode(&bidsResponse)
+	if err != nil {
+		return fmt.Errorf("error parsing bid traces response: %v", err)
+	}
+
+	mevBids := make([]*dbtypes.MevBid, 0, len(bidsResponse))
+	bidHashes := map[common.Hash]bool{}
+	for idx, bidData := range bidsResponse {
+		bidSlot, err := strconv.ParseUint(bidData.Slot, 10, 64)
+		if err != nil || bidSlot != uint64(slot) {
+			continue
+		}
+
+		blockHash := common.HexToHash(bidData.BlockHash)
+		if bidHashes[blockHash] {
+			continue
+		}
+
+		timestampMs, err := strconv.ParseInt(bidData.TimestampMs, 10, 64)
+		if err != nil {
+			mev.logger.Debugf("failed parsing bid %v.TimestampMs: %v", idx, err)
+			continue
+		}
+
+		bidValue, ok := big.NewInt(0).SetString(bidData.Value, 10)
+		if !ok {
+			mev.logger.Debugf("failed parsing bid %v.Value: big.Int.SetString failed", idx)
+			continue
+		}
+
+		bidHashes[blockHash] = true
+		mevBids = append(mevBids, &dbtypes.MevBid{
+			SlotNumber:    bidSlot,
+			RelayId:       relay.Index,
+			BlockHash:     blockHash[:],
+			BuilderPubkey: common.FromHex(bidData.BuilderPubkey),
+			ValueGwei:     big.NewInt(0).Div(bidValue, utils.GWEI).Uint64(),
+			TimestampMs:   timestampMs,
+		})
+	}
+
+	if len(mevBids) == 0 {
+		return nil
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertMevBids(mevBids, tx)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving bid traces to db: %v", err)
+	}
+
+	mev.logger.Debugf("loaded %v bid traces for slot %v from relay %v", len(mevBids), slot, relay.Name)
+	return nil
+}
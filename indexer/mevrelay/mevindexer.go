@@ -63,6 +63,18 @@ func (mev *MevIndexer) StartUpdater() {
 
 	mev.updaterRunning = true
 	go mev.runUpdaterLoop()
+
+	if utils.Config.MevIndexer.BackfillEnabled {
+		mev.startBackfill()
+	}
+
+	if utils.Config.MevIndexer.BidTracesEnabled {
+		mev.startBidTracesCrawler()
+	}
+
+	if utils.Config.MevIndexer.RegistrationsEnabled {
+		mev.startRegistrationSampler()
+	}
 }
 
 func (mev *MevIndexer) runUpdaterLoop() {
@@ -190,38 +202,14 @@ type mevIndexerRelayBlockResponse struct {
 }
 
 func (mev *MevIndexer) loadMevBlocksFromRelay(relay *types.MevRelayConfig) error {
-	relayUrl, err := url.Parse(relay.Url)
-	if err != nil {
-		return fmt.Errorf("invalid relay url: %v", err)
-	}
-
-	relayUrl.Path = path.Join(relayUrl.Path, "/relay/v1/data/bidtraces/proposer_payload_delivered")
 	blockLimit := relay.BlockLimit
 	if blockLimit == 0 {
 		blockLimit = 200
 	}
-	apiUrl := fmt.Sprintf("%v?limit=%v", relayUrl.String(), blockLimit)
 
-	mev.logger.Debugf("Loading mev blocks from relay %v: %v", relay.Name, utils.GetRedactedUrl(apiUrl))
-
-	client := &http.Client{Timeout: time.Second * 120}
-	resp, err := client.Get(apiUrl)
+	blocksResponse, err := mev.fetchRelayPayloads(relay, blockLimit, 0)
 	if err != nil {
-		return fmt.Errorf("could not fetch mev blocks (%v): %v", utils.GetRedactedUrl(apiUrl), err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		if resp.StatusCode == http.StatusNotFound {
-			return fmt.Errorf("could not fetch mev blocks (%v): not found", utils.GetRedactedUrl(apiUrl))
-		}
-		data, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
-	}
-	blocksResponse := []*mevIndexerRelayBlockResponse{}
-	dec := json.NewDecoder(resp.Body)
-	err = dec.Decode(&blocksResponse)
-	if err != nil {
-		return fmt.Errorf("error parsing mev blocks response: %v", err)
+		return err
 	}
 
 	if len(blocksResponse) == 0 {
@@ -277,53 +265,11 @@ func (mev *MevIndexer) loadMevBlocksFromRelay(relay *types.MevRelayConfig) error
 		}
 
 		if cachedBlock == nil {
-			blockNumber, err := strconv.ParseUint(blockData.BlockNumber, 10, 64)
+			mevBlock, err := mev.parseRelayPayload(blockData, slot, relayFlag)
 			if err != nil {
-				mev.logger.Warnf("failed parsing mev block %v.BlockNumber: %v", idx, err)
+				mev.logger.Warnf("failed parsing mev block %v: %v", idx, err)
 				continue
 			}
-
-			txCount, err := strconv.ParseUint(blockData.NumTx, 10, 64)
-			if err != nil {
-				mev.logger.Warnf("failed parsing mev block %v.NumTx: %v", idx, err)
-				continue
-			}
-
-			gasUsed, err := strconv.ParseUint(blockData.GasUsed, 10, 64)
-			if err != nil {
-				mev.logger.Warnf("failed parsing mev block %v.GasUsed: %v", idx, err)
-				continue
-			}
-
-			blockValue := big.NewInt(0)
-			blockValue, ok := blockValue.SetString(blockData.Value, 10)
-			if !ok {
-				mev.logger.Warnf("failed parsing mev block %v.Value: big.Int.SetString failed", idx)
-				continue
-			}
-			blockValueBytes := blockValue.Bytes()
-			blockValueGwei := big.NewInt(0).Div(blockValue, utils.GWEI)
-
-			validatorPubkey := phase0.BLSPubKey(common.FromHex(blockData.ProposerPubkey))
-			validatorIndex, found := mev.beaconIndexer.GetValidatorIndexByPubkey(validatorPubkey)
-			if !found {
-				mev.logger.Warnf("failed parsing mev block %v: ProposerPubkey (%v) not found in validator set", idx, validatorPubkey.String())
-				continue
-			}
-
-			mevBlock := &dbtypes.MevBlock{
-				SlotNumber:     slot,
-				BlockHash:      blockHash[:],
-				BlockNumber:    blockNumber,
-				BuilderPubkey:  common.FromHex(blockData.BuilderPubkey),
-				ProposerIndex:  uint64(validatorIndex),
-				SeenbyRelays:   relayFlag,
-				FeeRecipient:   common.FromHex(blockData.ProposerFeeRecipient),
-				TxCount:        txCount,
-				GasUsed:        gasUsed,
-				BlockValue:     blockValueBytes,
-				BlockValueGwei: blockValueGwei.Uint64(),
-			}
 			mevBlock.Proposed = mev.getMevBlockProposedStatus(mevBlock, finalizedSlot)
 
 			cachedBlock = &mevIndexerBlockCache{
@@ -353,6 +299,91 @@ func (mev *MevIndexer) loadMevBlocksFromRelay(relay *types.MevRelayConfig) error
 	return nil
 }
 
+// fetchRelayPayloads loads delivered payloads from the relays data api, starting at the cursor slot (0 = latest) and walking backwards.
+func (mev *MevIndexer) fetchRelayPayloads(relay *types.MevRelayConfig, limit int, cursor uint64) ([]*mevIndexerRelayBlockResponse, error) {
+	relayUrl, err := url.Parse(relay.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay url: %v", err)
+	}
+
+	relayUrl.Path = path.Join(relayUrl.Path, "/relay/v1/data/bidtraces/proposer_payload_delivered")
+	apiUrl := fmt.Sprintf("%v?limit=%v", relayUrl.String(), limit)
+	if cursor > 0 {
+		apiUrl = fmt.Sprintf("%v&cursor=%v", apiUrl, cursor)
+	}
+
+	mev.logger.Debugf("Loading mev blocks from relay %v: %v", relay.Name, utils.GetRedactedUrl(apiUrl))
+
+	client := &http.Client{Timeout: time.Second * 120}
+	resp, err := client.Get(apiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch mev blocks (%v): %v", utils.GetRedactedUrl(apiUrl), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("could not fetch mev blocks (%v): not found", utils.GetRedactedUrl(apiUrl))
+		}
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
+	}
+	blocksResponse := []*mevIndexerRelayBlockResponse{}
+	dec := json.NewDecoder(resp.Body)
+	err = dec.Decode(&blocksResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing mev blocks response: %v", err)
+	}
+
+	return blocksResponse, nil
+}
+
+// parseRelayPayload builds a new mev block entry from a relay payload response.
+func (mev *MevIndexer) parseRelayPayload(blockData *mevIndexerRelayBlockResponse, slot uint64, relayFlag uint64) (*dbtypes.MevBlock, error) {
+	blockNumber, err := strconv.ParseUint(blockData.BlockNumber, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing BlockNumber: %v", err)
+	}
+
+	txCount, err := strconv.ParseUint(blockData.NumTx, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing NumTx: %v", err)
+	}
+
+	gasUsed, err := strconv.ParseUint(blockData.GasUsed, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing GasUsed: %v", err)
+	}
+
+	blockValue := big.NewInt(0)
+	blockValue, ok := blockValue.SetString(blockData.Value, 10)
+	if !ok {
+		return nil, fmt.Errorf("failed parsing Value: big.Int.SetString failed")
+	}
+	blockValueBytes := blockValue.Bytes()
+	blockValueGwei := big.NewInt(0).Div(blockValue, utils.GWEI)
+
+	validatorPubkey := phase0.BLSPubKey(common.FromHex(blockData.ProposerPubkey))
+	validatorIndex, found := mev.beaconIndexer.GetValidatorIndexByPubkey(validatorPubkey)
+	if !found {
+		return nil, fmt.Errorf("ProposerPubkey (%v) not found in validator set", validatorPubkey.String())
+	}
+
+	blockHash := common.HexToHash(blockData.BlockHash)
+	return &dbtypes.MevBlock{
+		SlotNumber:     slot,
+		BlockHash:      blockHash[:],
+		BlockNumber:    blockNumber,
+		BuilderPubkey:  common.FromHex(blockData.BuilderPubkey),
+		ProposerIndex:  uint64(validatorIndex),
+		SeenbyRelays:   relayFlag,
+		FeeRecipient:   common.FromHex(blockData.ProposerFeeRecipient),
+		TxCount:        txCount,
+		GasUsed:        gasUsed,
+		BlockValue:     blockValueBytes,
+		BlockValueGwei: blockValueGwei.Uint64(),
+	}, nil
+}
+
 func (mev *MevIndexer) getMevBlockProposedStatus(mevBlock *dbtypes.MevBlock, finalizedSlot phase0.Slot) uint8 {
 	proposed := uint8(0)
 	if mevBlock.SlotNumber >= uint64(finalizedSlot) {
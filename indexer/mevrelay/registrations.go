@@ -0,0 +1,207 @@
+package mevrelay
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const mevRegistrationSamplerStateKey = "indexer.mevregistrations"
+
+type mevIndexerRelayRegistrationResponse struct {
+	Message struct {
+		FeeRecipient string `json:"fee_recipient"`
+		GasLimit     string `json:"gas_limit"`
+		Timestamp    string `json:"timestamp"`
+		Pubkey       string `json:"pubkey"`
+	} `json:"message"`
+}
+
+func (mev *MevIndexer) startRegistrationSampler() {
+	if utils.Config.MevIndexer.RegistrationsBatchSize == 0 {
+		utils.Config.MevIndexer.RegistrationsBatchSize = 100
+	}
+	if utils.Config.MevIndexer.RegistrationsInterval == 0 {
+		utils.Config.MevIndexer.RegistrationsInterval = 1 * time.Minute
+	}
+
+	go mev.runRegistrationSamplerLoop()
+}
+
+func (mev *MevIndexer) runRegistrationSamplerLoop() {
+	defer utils.HandleSubroutinePanic("MevIndexer.runRegistrationSamplerLoop", mev.runRegistrationSamplerLoop)
+
+	samplerState := &dbtypes.MevRegistrationSamplerState{}
+	db.GetExplorerState(mevRegistrationSamplerStateKey, samplerState)
+
+	for {
+		time.Sleep(utils.Config.MevIndexer.RegistrationsInterval)
+
+		err := mev.sampleRegistrations(samplerState)
+		if err != nil {
+			mev.logger.Errorf("mev registration sampler error: %v", err)
+		}
+	}
+}
+
+// sampleRegistrations loads the relay registrations for the next batch of active validators.
+// The validator set is walked round-robin, so every active validator gets sampled periodically.
+// Each relay keeps its own cursor, which is only advanced if all registrations of the batch have been loaded from the relay.
+func (mev *MevIndexer) sampleRegistrations(samplerState *dbtypes.MevRegistrationSamplerState) error {
+	validatorSetSize := mev.beaconIndexer.GetValidatorSetSize()
+	if validatorSetSize == 0 {
+		return nil
+	}
+
+	if samplerState.RelayCursors == nil {
+		samplerState.RelayCursors = map[uint8]uint64{}
+	}
+
+	registrations := []*dbtypes.MevValidatorRegistration{}
+	validatorCount := 0
+	now := uint64(time.Now().Unix())
+	for idx := range utils.Config.MevIndexer.Relays {
+		relay := &utils.Config.MevIndexer.Relays[idx]
+
+		cursor, hasCursor := samplerState.RelayCursors[relay.Index]
+		if !hasCursor {
+			cursor = samplerState.Cursor
+		}
+
+		validatorIndices, pubkeys, nextCursor := mev.getRegistrationSampleBatch(cursor, validatorSetSize)
+		validatorCount += len(validatorIndices)
+
+		batchComplete := true
+		for i, validatorIndex := range validatorIndices {
+			registration, err := mev.fetchValidatorRegistration(relay, pubkeys[i])
+			if err != nil {
+				mev.logger.Warnf("error loading validator registration for %v from relay %v: %v", validatorIndex, relay.Name, err)
+				batchComplete = false
+				break
+			}
+			if registration == nil {
+				continue
+			}
+
+			registration.ValidatorIndex = uint64(validatorIndex)
+			registration.FirstSeen = now
+			registrations = append(registrations, registration)
+		}
+
+		if batchComplete {
+			// the batch is retried on the next run if any registration failed to load
+			samplerState.RelayCursors[relay.Index] = nextCursor
+		} else {
+			samplerState.RelayCursors[relay.Index] = cursor
+		}
+	}
+
+	err := db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		if len(registrations) > 0 {
+			if err := db.InsertMevValidatorRegistrations(registrations, tx); err != nil {
+				return err
+			}
+		}
+
+		return db.SetExplorerState(mevRegistrationSamplerStateKey, samplerState, tx)
+	})
+	if err != nil {
+		return fmt.Errorf("error saving validator registrations to db: %v", err)
+	}
+
+	mev.logger.Debugf("sampled %v validator registrations for %v validators, cursors %v", len(registrations), validatorCount, samplerState.RelayCursors)
+	return nil
+}
+
+// getRegistrationSampleBatch returns the next batch of active validators starting at the given cursor, along with the cursor for the following batch.
+func (mev *MevIndexer) getRegistrationSampleBatch(cursor uint64, validatorSetSize uint64) ([]phase0.ValidatorIndex, []phase0.BLSPubKey, uint64) {
+	currentEpoch := mev.chainState.CurrentEpoch()
+	batchSize := utils.Config.MevIndexer.RegistrationsBatchSize
+	validatorIndices := make([]phase0.ValidatorIndex, 0, batchSize)
+	pubkeys := make([]phase0.BLSPubKey, 0, batchSize)
+
+	for checked := uint64(0); checked < validatorSetSize && uint64(len(validatorIndices)) < batchSize; checked++ {
+		if cursor >= validatorSetSize {
+			cursor = 0
+		}
+
+		validatorIndex := phase0.ValidatorIndex(cursor)
+		cursor++
+
+		validator := mev.beaconIndexer.GetValidatorByIndex(validatorIndex, nil)
+		if validator == nil || validator.ActivationEpoch > currentEpoch || validator.ExitEpoch <= currentEpoch {
+			continue
+		}
+
+		validatorIndices = append(validatorIndices, validatorIndex)
+		pubkeys = append(pubkeys, validator.PublicKey)
+	}
+
+	return validatorIndices, pubkeys, cursor
+}
+
+// fetchValidatorRegistration loads the latest registration of a validator from a relay.
+// Returns nil if the validator is not registered with the relay.
+func (mev *MevIndexer) fetchValidatorRegistration(relay *types.MevRelayConfig, pubkey phase0.BLSPubKey) (*dbtypes.MevValidatorRegistration, error) {
+	relayUrl, err := url.Parse(relay.Url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid relay url: %v", err)
+	}
+
+	relayUrl.Path = path.Join(relayUrl.Path, "/relay/v1/data/validator_registration")
+	apiUrl := fmt.Sprintf("%v?pubkey=0x%x", relayUrl.String(), pubkey[:])
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, err := client.Get(apiUrl)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch validator registration (%v): %v", utils.GetRedactedUrl(apiUrl), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusNotFound {
+		// relays respond with 400 if the validator is not registered
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("url: %v, error-response: %s", utils.GetRedactedUrl(apiUrl), data)
+	}
+
+	registrationResponse := &mevIndexerRelayRegistrationResponse{}
+	err = json.NewDecoder(resp.Body).Decode(registrationResponse)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing validator registration response: %v", err)
+	}
+
+	timestamp, err := strconv.ParseUint(registrationResponse.Message.Timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing registration timestamp: %v", err)
+	}
+
+	gasLimit, err := strconv.ParseUint(registrationResponse.Message.GasLimit, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed parsing registration gas limit: %v", err)
+	}
+
+	feeRecipient := common.HexToAddress(registrationResponse.Message.FeeRecipient)
+
+	return &dbtypes.MevValidatorRegistration{
+		RelayId:      relay.Index,
+		Timestamp:    timestamp,
+		FeeRecipient: feeRecipient[:],
+		GasLimit:     gasLimit,
+	}, nil
+}
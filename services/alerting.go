@@ -0,0 +1,635 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type AlertType string
+
+const (
+	AlertTypeMissedProposal   AlertType = "missed_proposal"
+	AlertTypeAttestationMiss  AlertType = "attestation_miss"
+	AlertTypeSlashing         AlertType = "slashing"
+	AlertTypeExit             AlertType = "exit"
+	AlertTypeBalanceDrop      AlertType = "balance_drop"
+	AlertTypeNewFork          AlertType = "new_fork"
+	AlertTypeFinalityStall    AlertType = "finality_stall"
+	AlertTypeClientDivergence AlertType = "client_divergence"
+)
+
+// Alert represents a fired alert that is delivered to the webhooks of the matching rule.
+type Alert struct {
+	Rule       string    `json:"rule"`
+	Type       AlertType `json:"type"`
+	Title      string    `json:"title"`
+	Message    string    `json:"message"`
+	Epoch      uint64    `json:"epoch"`
+	Slot       uint64    `json:"slot,omitempty"`
+	Validators []uint64  `json:"validators,omitempty"`
+	Link       string    `json:"link,omitempty"`
+	Time       time.Time `json:"time"`
+}
+
+// AlertManager matches indexer events against the configured alert rules and delivers fired alerts via webhooks.
+type AlertManager struct {
+	logger        logrus.FieldLogger
+	chainService  *ChainService
+	rules         []*alertRule
+	deliveryQueue chan *alertDelivery
+	webhookQueues map[string]chan *alertDelivery
+
+	firedMutex  sync.Mutex
+	firedAlerts map[string]time.Time
+
+	lastDivergence string
+}
+
+type alertRule struct {
+	config         *types.AlertRuleConfig
+	ruleType       AlertType
+	setFilter      *ValidatorSetFilter
+	webhooks       []*types.AlertWebhookConfig
+	validatorMutex sync.RWMutex
+	validators     map[phase0.ValidatorIndex]bool
+	lastBalances   map[phase0.ValidatorIndex]phase0.Gwei
+}
+
+// maximum number of validators a single alert rule can target
+const alertRuleMaxValidators = 100000
+
+func newAlertManager(logger logrus.FieldLogger, chainService *ChainService) (*AlertManager, error) {
+	am := &AlertManager{
+		logger:        logger,
+		chainService:  chainService,
+		deliveryQueue: make(chan *alertDelivery, 1000),
+		webhookQueues: map[string]chan *alertDelivery{},
+		firedAlerts:   map[string]time.Time{},
+	}
+
+	webhooks := map[string]*types.AlertWebhookConfig{}
+	for idx := range utils.Config.Alerting.Webhooks {
+		webhook := &utils.Config.Alerting.Webhooks[idx]
+		if webhook.Name == "" {
+			webhook.Name = fmt.Sprintf("webhook-%v", idx+1)
+		}
+		switch webhook.Format {
+		case "":
+			webhook.Format = "generic"
+		case "generic", "slack", "discord":
+		default:
+			return nil, fmt.Errorf("invalid format for webhook %v: %v", webhook.Name, webhook.Format)
+		}
+		webhooks[webhook.Name] = webhook
+	}
+
+	for idx := range utils.Config.Alerting.Rules {
+		ruleConfig := &utils.Config.Alerting.Rules[idx]
+		if ruleConfig.Name == "" {
+			ruleConfig.Name = fmt.Sprintf("rule-%v", idx+1)
+		}
+
+		rule := &alertRule{
+			config:   ruleConfig,
+			ruleType: AlertType(ruleConfig.Type),
+		}
+
+		switch rule.ruleType {
+		case AlertTypeMissedProposal, AlertTypeAttestationMiss, AlertTypeSlashing, AlertTypeExit, AlertTypeBalanceDrop:
+			if ruleConfig.Validators != "" || ruleConfig.ValidatorName != "" {
+				indices, pubkeys := ParseValidatorSetList(ruleConfig.Validators)
+				rule.setFilter = &ValidatorSetFilter{
+					Indices:     indices,
+					Pubkeys:     pubkeys,
+					NamePattern: ruleConfig.ValidatorName,
+				}
+			}
+		case AlertTypeNewFork, AlertTypeFinalityStall, AlertTypeClientDivergence:
+		default:
+			return nil, fmt.Errorf("invalid type for alert rule %v: %v", ruleConfig.Name, ruleConfig.Type)
+		}
+
+		if rule.ruleType == AlertTypeBalanceDrop && rule.setFilter == nil {
+			return nil, fmt.Errorf("alert rule %v requires a validator set", ruleConfig.Name)
+		}
+
+		if len(ruleConfig.Webhooks) == 0 {
+			for idx := range utils.Config.Alerting.Webhooks {
+				rule.webhooks = append(rule.webhooks, &utils.Config.Alerting.Webhooks[idx])
+			}
+		} else {
+			for _, webhookName := range ruleConfig.Webhooks {
+				webhook := webhooks[webhookName]
+				if webhook == nil {
+					return nil, fmt.Errorf("unknown webhook for alert rule %v: %v", ruleConfig.Name, webhookName)
+				}
+				rule.webhooks = append(rule.webhooks, webhook)
+			}
+		}
+
+		am.rules = append(am.rules, rule)
+	}
+
+	return am, nil
+}
+
+func (am *AlertManager) startAlertManager() {
+	go am.runDeliveryLoop()
+	go am.runEventLoop()
+}
+
+func (am *AlertManager) runEventLoop() {
+	defer utils.HandleSubroutinePanic("AlertManager.runEventLoop", am.runEventLoop)
+
+	beaconIndexer := am.chainService.beaconIndexer
+	blockSubscription := beaconIndexer.SubscribeBlockEvent(100)
+	forkSubscription := beaconIndexer.SubscribeForkEvent(10)
+	finalizedSubscription := beaconIndexer.SubscribeFinalizedEpochEvent(10)
+	epochSubscription := am.chainService.consensusPool.SubscribeWallclockEpochEvent(1)
+
+	defer func() {
+		blockSubscription.Unsubscribe()
+		forkSubscription.Unsubscribe()
+		finalizedSubscription.Unsubscribe()
+		epochSubscription.Unsubscribe()
+	}()
+
+	am.refreshRuleValidators()
+
+	for {
+		select {
+		case block := <-blockSubscription.Channel():
+			am.processBlock(block)
+		case fork := <-forkSubscription.Channel():
+			am.processFork(fork)
+		case finalizedEpoch := <-finalizedSubscription.Channel():
+			am.processFinalizedEpoch(finalizedEpoch)
+		case <-epochSubscription.Channel():
+			am.refreshRuleValidators()
+			am.checkFinality()
+			am.checkClientDivergence()
+			am.cleanupFiredAlerts()
+		}
+	}
+}
+
+// refreshRuleValidators resolves the validator sets of all rules, as name patterns might match new validators over time.
+func (am *AlertManager) refreshRuleValidators() {
+	for _, rule := range am.rules {
+		if rule.setFilter == nil {
+			continue
+		}
+
+		validatorSet := am.chainService.ResolveValidatorSet(rule.setFilter, alertRuleMaxValidators)
+		validators := make(map[phase0.ValidatorIndex]bool, len(validatorSet))
+		for _, index := range validatorSet {
+			validators[index] = true
+		}
+
+		rule.validatorMutex.Lock()
+		rule.validators = validators
+		rule.validatorMutex.Unlock()
+	}
+}
+
+// matchValidators returns the validators from the given list that are targeted by the rule.
+// rules without validator set target all validators.
+func (rule *alertRule) matchValidators(validators []phase0.ValidatorIndex) []uint64 {
+	matches := []uint64{}
+
+	rule.validatorMutex.RLock()
+	defer rule.validatorMutex.RUnlock()
+
+	for _, index := range validators {
+		if rule.setFilter == nil || rule.validators[index] {
+			matches = append(matches, uint64(index))
+		}
+	}
+
+	return matches
+}
+
+// fireAlert queues the alert for delivery, alerts with the same key are only fired once.
+func (am *AlertManager) fireAlert(rule *alertRule, key string, alert *Alert) {
+	firedKey := fmt.Sprintf("%v:%v", rule.config.Name, key)
+
+	am.firedMutex.Lock()
+	if _, fired := am.firedAlerts[firedKey]; fired {
+		am.firedMutex.Unlock()
+		return
+	}
+	am.firedAlerts[firedKey] = time.Now()
+	am.firedMutex.Unlock()
+
+	alert.Rule = rule.config.Name
+	alert.Type = rule.ruleType
+	alert.Time = time.Now()
+	if alert.Link != "" && utils.Config.Frontend.SiteDomain != "" {
+		alert.Link = fmt.Sprintf("https://%v%v", utils.Config.Frontend.SiteDomain, alert.Link)
+	}
+
+	am.logger.Infof("alert fired (%v): %v", rule.config.Name, alert.Title)
+
+	for _, webhook := range rule.webhooks {
+		delivery := &alertDelivery{
+			alert:   alert,
+			webhook: webhook,
+			created: time.Now(),
+		}
+
+		select {
+		case am.deliveryQueue <- delivery:
+		default:
+			am.logger.Warnf("alert delivery queue full, dropping alert %v for webhook %v", alert.Title, webhook.Name)
+		}
+	}
+}
+
+func (am *AlertManager) cleanupFiredAlerts() {
+	am.firedMutex.Lock()
+	defer am.firedMutex.Unlock()
+
+	for key, firedAt := range am.firedAlerts {
+		if time.Since(firedAt) > 24*time.Hour {
+			delete(am.firedAlerts, key)
+		}
+	}
+}
+
+// processBlock matches slashings & exits included in new unfinalized blocks.
+func (am *AlertManager) processBlock(block *beacon.Block) {
+	var slashings, exits []phase0.ValidatorIndex
+
+	beaconIndexer := am.chainService.beaconIndexer
+	for _, slashing := range block.GetDbSlashings(beaconIndexer, true) {
+		slashings = append(slashings, phase0.ValidatorIndex(slashing.ValidatorIndex))
+	}
+	for _, exit := range block.GetDbVoluntaryExits(beaconIndexer, true) {
+		exits = append(exits, phase0.ValidatorIndex(exit.ValidatorIndex))
+	}
+
+	if len(slashings) == 0 && len(exits) == 0 {
+		return
+	}
+
+	chainState := am.chainService.consensusPool.GetChainState()
+	epoch := uint64(chainState.EpochOfSlot(block.Slot))
+
+	for _, rule := range am.rules {
+		var matches []uint64
+		switch rule.ruleType {
+		case AlertTypeSlashing:
+			matches = rule.matchValidators(slashings)
+		case AlertTypeExit:
+			matches = rule.matchValidators(exits)
+		default:
+			continue
+		}
+
+		for _, index := range matches {
+			alert := &Alert{
+				Epoch:      epoch,
+				Slot:       uint64(block.Slot),
+				Validators: []uint64{index},
+				Link:       fmt.Sprintf("/slot/0x%x", block.Root[:]),
+			}
+
+			if rule.ruleType == AlertTypeSlashing {
+				alert.Title = fmt.Sprintf("Validator %v slashed", am.formatValidator(index))
+				alert.Message = fmt.Sprintf("Validator %v has been slashed in slot %v.", am.formatValidator(index), block.Slot)
+			} else {
+				alert.Title = fmt.Sprintf("Validator %v exiting", am.formatValidator(index))
+				alert.Message = fmt.Sprintf("Voluntary exit for validator %v included in slot %v.", am.formatValidator(index), block.Slot)
+			}
+
+			am.fireAlert(rule, fmt.Sprintf("%v:%v", rule.ruleType, index), alert)
+		}
+	}
+}
+
+// processFork fires alerts for newly detected forks.
+func (am *AlertManager) processFork(fork *beacon.Fork) {
+	chainState := am.chainService.consensusPool.GetChainState()
+	baseSlot, baseRoot := fork.GetBase()
+	leafSlot, leafRoot := fork.GetLeaf()
+
+	for _, rule := range am.rules {
+		if rule.ruleType != AlertTypeNewFork {
+			continue
+		}
+
+		am.fireAlert(rule, fmt.Sprintf("fork:%x", leafRoot[:]), &Alert{
+			Epoch:   uint64(chainState.EpochOfSlot(leafSlot)),
+			Slot:    uint64(leafSlot),
+			Title:   fmt.Sprintf("New fork detected at slot %v", leafSlot),
+			Message: fmt.Sprintf("A new fork starting at slot %v (0x%x) was detected, based on slot %v (0x%x).", leafSlot, leafRoot[:], baseSlot, baseRoot[:]),
+			Link:    fmt.Sprintf("/slot/0x%x", leafRoot[:]),
+		})
+	}
+}
+
+// processFinalizedEpoch matches missed proposals, attestation misses and balance drops of a finalized epoch.
+func (am *AlertManager) processFinalizedEpoch(event *beacon.FinalizedEpochEvent) {
+	chainState := am.chainService.consensusPool.GetChainState()
+	epoch := uint64(event.Epoch)
+
+	// missed proposals
+	missedProposals := map[phase0.ValidatorIndex][]phase0.Slot{}
+	if event.EpochStatsValues != nil {
+		proposedSlots := map[phase0.Slot]bool{}
+		for _, block := range event.CanonicalBlocks {
+			proposedSlots[block.Slot] = true
+		}
+
+		epochStartSlot := chainState.EpochStartSlot(event.Epoch)
+		for slotIdx, proposer := range event.EpochStatsValues.ProposerDuties {
+			slot := epochStartSlot + phase0.Slot(slotIdx)
+			if !proposedSlots[slot] && slot > 0 {
+				missedProposals[proposer] = append(missedProposals[proposer], slot)
+			}
+		}
+	}
+
+	missedProposers := make([]phase0.ValidatorIndex, 0, len(missedProposals))
+	for proposer := range missedProposals {
+		missedProposers = append(missedProposers, proposer)
+	}
+
+	var balances []phase0.Gwei
+	_, oldestActivityEpoch := am.chainService.beaconIndexer.GetValidatorActivity(0)
+
+	for _, rule := range am.rules {
+		switch rule.ruleType {
+		case AlertTypeMissedProposal:
+			for _, index := range rule.matchValidators(missedProposers) {
+				for _, slot := range missedProposals[phase0.ValidatorIndex(index)] {
+					am.fireAlert(rule, fmt.Sprintf("proposal:%v", slot), &Alert{
+						Epoch:      epoch,
+						Slot:       uint64(slot),
+						Validators: []uint64{index},
+						Title:      fmt.Sprintf("Validator %v missed proposal in slot %v", am.formatValidator(index), slot),
+						Message:    fmt.Sprintf("Validator %v missed its block proposal in slot %v (epoch %v).", am.formatValidator(index), slot, epoch),
+						Link:       fmt.Sprintf("/slot/%v", slot),
+					})
+				}
+			}
+
+		case AlertTypeAttestationMiss:
+			if event.EpochStatsValues == nil || rule.setFilter == nil || event.Epoch < oldestActivityEpoch {
+				continue
+			}
+
+			missed := am.getMissedAttestations(rule, event)
+			if float64(len(missed)) <= rule.config.Threshold {
+				continue
+			}
+
+			am.fireAlert(rule, fmt.Sprintf("attestations:%v", epoch), &Alert{
+				Epoch:      epoch,
+				Validators: missed,
+				Title:      fmt.Sprintf("%v validators missed attestations in epoch %v", len(missed), epoch),
+				Message:    fmt.Sprintf("%v validators of rule %v missed their attestation in epoch %v: %v", len(missed), rule.config.Name, epoch, am.formatValidatorList(missed)),
+				Link:       fmt.Sprintf("/epoch/%v", epoch),
+			})
+
+		case AlertTypeBalanceDrop:
+			if balances == nil {
+				balances = am.chainService.beaconIndexer.GetRecentValidatorBalances(nil)
+				if balances == nil {
+					continue
+				}
+			}
+
+			am.checkBalanceDrops(rule, epoch, balances)
+		}
+	}
+}
+
+func (am *AlertManager) getMissedAttestations(rule *alertRule, event *beacon.FinalizedEpochEvent) []uint64 {
+	chainState := am.chainService.consensusPool.GetChainState()
+	epochStartSlot := chainState.EpochStartSlot(event.Epoch)
+	epochEndSlot := chainState.EpochStartSlot(event.Epoch + 1)
+
+	rule.validatorMutex.RLock()
+	defer rule.validatorMutex.RUnlock()
+
+	missed := []uint64{}
+	for _, index := range event.EpochStatsValues.ActiveIndices {
+		if !rule.validators[index] {
+			continue
+		}
+
+		hasVoted := false
+		activity, _ := am.chainService.beaconIndexer.GetValidatorActivity(index)
+		for _, vote := range activity {
+			dutySlot := vote.VoteBlock.Slot - phase0.Slot(vote.VoteDelay)
+			if dutySlot >= epochStartSlot && dutySlot < epochEndSlot {
+				hasVoted = true
+				break
+			}
+		}
+
+		if !hasVoted {
+			missed = append(missed, uint64(index))
+		}
+	}
+
+	return missed
+}
+
+func (am *AlertManager) checkBalanceDrops(rule *alertRule, epoch uint64, balances []phase0.Gwei) {
+	threshold := phase0.Gwei(rule.config.Threshold * beacon.EtherGweiFactor)
+
+	rule.validatorMutex.RLock()
+	lastBalances := make(map[phase0.ValidatorIndex]phase0.Gwei, len(rule.validators))
+	dropped := []uint64{}
+	totalDrop := phase0.Gwei(0)
+
+	for index := range rule.validators {
+		if int(index) >= len(balances) {
+			continue
+		}
+
+		balance := balances[index]
+		lastBalances[index] = balance
+
+		if lastBalance, ok := rule.lastBalances[index]; ok && balance < lastBalance && lastBalance-balance > threshold {
+			dropped = append(dropped, uint64(index))
+			totalDrop += lastBalance - balance
+		}
+	}
+	rule.validatorMutex.RUnlock()
+
+	rule.lastBalances = lastBalances
+
+	if len(dropped) == 0 {
+		return
+	}
+
+	sort.Slice(dropped, func(a, b int) bool {
+		return dropped[a] < dropped[b]
+	})
+
+	am.fireAlert(rule, fmt.Sprintf("balance:%v", epoch), &Alert{
+		Epoch:      epoch,
+		Validators: dropped,
+		Title:      fmt.Sprintf("Balance of %v validators dropped", len(dropped)),
+		Message:    fmt.Sprintf("The balance of %v validators dropped by %v ETH in total since the last finalized epoch: %v", len(dropped), utils.FormatFloat(float64(totalDrop)/beacon.EtherGweiFactor, 4), am.formatValidatorList(dropped)),
+		Link:       fmt.Sprintf("/epoch/%v", epoch),
+	})
+}
+
+// checkFinality fires a finality stall alert once the chain has not finalized for the configured number of epochs.
+func (am *AlertManager) checkFinality() {
+	chainState := am.chainService.consensusPool.GetChainState()
+	finalizedEpoch, _ := chainState.GetFinalizedCheckpoint()
+	currentEpoch := chainState.CurrentEpoch()
+
+	stallEpochs := uint64(0)
+	if currentEpoch > finalizedEpoch {
+		stallEpochs = uint64(currentEpoch - finalizedEpoch)
+	}
+
+	for _, rule := range am.rules {
+		if rule.ruleType != AlertTypeFinalityStall {
+			continue
+		}
+
+		threshold := uint64(rule.config.Threshold)
+		if threshold == 0 {
+			threshold = 4
+		}
+
+		if stallEpochs < threshold {
+			continue
+		}
+
+		am.fireAlert(rule, fmt.Sprintf("finality:%v", finalizedEpoch), &Alert{
+			Epoch:   uint64(currentEpoch),
+			Title:   fmt.Sprintf("Chain has not finalized for %v epochs", stallEpochs),
+			Message: fmt.Sprintf("The last finalized epoch is %v, the chain is at epoch %v.", finalizedEpoch, currentEpoch),
+			Link:    "/epochs",
+		})
+	}
+}
+
+// checkClientDivergence fires an alert when the connected consensus clients follow different heads.
+func (am *AlertManager) checkClientDivergence() {
+	chainState := am.chainService.consensusPool.GetChainState()
+	clientForks := am.chainService.GetConsensusClientForks()
+
+	divergenceKey := ""
+	if len(clientForks) > 1 {
+		forkKeys := make([]string, len(clientForks))
+		for i, fork := range clientForks {
+			forkKeys[i] = fmt.Sprintf("%x", fork.Root[:])
+		}
+		sort.Strings(forkKeys)
+		divergenceKey = strings.Join(forkKeys, ",")
+	}
+
+	if divergenceKey == am.lastDivergence {
+		return
+	}
+	am.lastDivergence = divergenceKey
+
+	if divergenceKey == "" {
+		return
+	}
+
+	forkDescriptions := make([]string, len(clientForks))
+	for i, fork := range clientForks {
+		clientNames := make([]string, len(fork.AllClients))
+		for j, client := range fork.AllClients {
+			clientNames[j] = client.GetClient().GetName()
+		}
+		forkDescriptions[i] = fmt.Sprintf("slot %v (0x%x): %v", fork.Slot, fork.Root[:], strings.Join(clientNames, ", "))
+	}
+
+	for _, rule := range am.rules {
+		if rule.ruleType != AlertTypeClientDivergence {
+			continue
+		}
+
+		am.fireAlert(rule, fmt.Sprintf("divergence:%v", divergenceKey), &Alert{
+			Epoch:   uint64(chainState.CurrentEpoch()),
+			Title:   fmt.Sprintf("Consensus clients diverged into %v heads", len(clientForks)),
+			Message: fmt.Sprintf("The connected consensus clients follow different heads:\n%v", strings.Join(forkDescriptions, "\n")),
+			Link:    "/forks",
+		})
+	}
+}
+
+func (am *AlertManager) formatValidator(index uint64) string {
+	name := am.chainService.GetValidatorName(index)
+	if name == "" {
+		return fmt.Sprintf("%v", index)
+	}
+	return fmt.Sprintf("%v (%v)", index, name)
+}
+
+func (am *AlertManager) formatValidatorList(indices []uint64) string {
+	maxShown := 20
+	parts := make([]string, 0, maxShown+1)
+	for i, index := range indices {
+		if i >= maxShown {
+			parts = append(parts, fmt.Sprintf("and %v more", len(indices)-maxShown))
+			break
+		}
+		parts = append(parts, am.formatValidator(index))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// AlertRuleInfo describes a configured alert rule.
+type AlertRuleInfo struct {
+	Name           string
+	Type           AlertType
+	Webhooks       []string
+	ValidatorCount uint64
+	Target         string
+	Threshold      float64
+}
+
+// GetRules returns descriptions of all configured alert rules.
+func (am *AlertManager) GetRules() []*AlertRuleInfo {
+	rules := make([]*AlertRuleInfo, 0, len(am.rules))
+	for _, rule := range am.rules {
+		ruleInfo := &AlertRuleInfo{
+			Name:      rule.config.Name,
+			Type:      rule.ruleType,
+			Threshold: rule.config.Threshold,
+		}
+
+		for _, webhook := range rule.webhooks {
+			ruleInfo.Webhooks = append(ruleInfo.Webhooks, webhook.Name)
+		}
+
+		targets := []string{}
+		if rule.config.Validators != "" {
+			targets = append(targets, fmt.Sprintf("validators: %v", rule.config.Validators))
+		}
+		if rule.config.ValidatorName != "" {
+			targets = append(targets, fmt.Sprintf("name: %v", rule.config.ValidatorName))
+		}
+		ruleInfo.Target = strings.Join(targets, ", ")
+
+		rule.validatorMutex.RLock()
+		ruleInfo.ValidatorCount = uint64(len(rule.validators))
+		rule.validatorMutex.RUnlock()
+
+		rules = append(rules, ruleInfo)
+	}
+
+	return rules
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type alertDelivery struct {
+	alert   *Alert
+	webhook *types.AlertWebhookConfig
+	created time.Time
+}
+
+// webhookQueueSize is the number of deliveries that can be queued per webhook
+const webhookQueueSize = 200
+
+// runDeliveryLoop dispatches queued deliveries to a dedicated worker per webhook,
+// so retries of a failing webhook don't delay deliveries to the other webhooks.
+func (am *AlertManager) runDeliveryLoop() {
+	defer utils.HandleSubroutinePanic("AlertManager.runDeliveryLoop", am.runDeliveryLoop)
+
+	for delivery := range am.deliveryQueue {
+		webhookQueue := am.webhookQueues[delivery.webhook.Name]
+		if webhookQueue == nil {
+			webhookQueue = make(chan *alertDelivery, webhookQueueSize)
+			am.webhookQueues[delivery.webhook.Name] = webhookQueue
+			go am.runWebhookWorker(delivery.webhook.Name, webhookQueue)
+		}
+
+		select {
+		case webhookQueue <- delivery:
+		default:
+			am.logger.Warnf("delivery queue for webhook %v full, dropping alert %v", delivery.webhook.Name, delivery.alert.Title)
+		}
+	}
+}
+
+// runWebhookWorker delivers the queued alerts of a single webhook in order.
+func (am *AlertManager) runWebhookWorker(webhookName string, webhookQueue chan *alertDelivery) {
+	defer utils.HandleSubroutinePanic(fmt.Sprintf("AlertManager.runWebhookWorker(%v)", webhookName), func() {
+		am.runWebhookWorker(webhookName, webhookQueue)
+	})
+
+	client := &http.Client{Timeout: time.Second * 10}
+
+	for delivery := range webhookQueue {
+		am.deliverAlert(client, delivery)
+	}
+}
+
+// deliverAlert sends the alert to the webhook, retrying failed calls with exponential backoff.
+func (am *AlertManager) deliverAlert(client *http.Client, delivery *alertDelivery) {
+	payload, err := buildAlertPayload(delivery.alert, delivery.webhook.Format)
+	if err != nil {
+		am.logger.Errorf("failed building alert payload for webhook %v: %v", delivery.webhook.Name, err)
+		return
+	}
+
+	maxRetries := utils.Config.Alerting.MaxRetries
+	retryInterval := utils.Config.Alerting.RetryInterval
+	if retryInterval == 0 {
+		retryInterval = 10 * time.Second
+	}
+
+	attempts := uint32(0)
+	for {
+		attempts++
+		err = postAlertPayload(client, delivery.webhook, payload)
+		if err == nil || int(attempts) > maxRetries {
+			break
+		}
+
+		am.logger.Warnf("alert delivery to webhook %v failed (attempt %v): %v", delivery.webhook.Name, attempts, err)
+		time.Sleep(retryInterval * time.Duration(1<<(attempts-1)))
+	}
+
+	dbDelivery := &dbtypes.AlertDelivery{
+		RuleName:    delivery.alert.Rule,
+		WebhookName: delivery.webhook.Name,
+		AlertType:   string(delivery.alert.Type),
+		Title:       delivery.alert.Title,
+		Status:      dbtypes.AlertDeliveryStatusDelivered,
+		Attempts:    attempts,
+		CreatedAt:   delivery.created.Unix(),
+		DeliveredAt: time.Now().Unix(),
+	}
+	if err != nil {
+		am.logger.Errorf("alert delivery to webhook %v failed after %v attempts: %v", delivery.webhook.Name, attempts, err)
+		errStr := err.Error()
+		dbDelivery.Status = dbtypes.AlertDeliveryStatusFailed
+		dbDelivery.Error = &errStr
+	}
+
+	err = db.RunDBTransaction(func(tx *sqlx.Tx) error {
+		return db.InsertAlertDelivery(dbDelivery, tx)
+	})
+	if err != nil {
+		am.logger.Errorf("failed saving alert delivery: %v", err)
+	}
+}
+
+func postAlertPayload(client *http.Client, webhook *types.AlertWebhookConfig, payload []byte) error {
+	req, err := http.NewRequest("POST", webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for headerKey, headerVal := range webhook.Headers {
+		req.Header.Set(headerKey, headerVal)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("url: %v, status code: %v, body: %v", webhook.Url, resp.StatusCode, string(body))
+	}
+
+	return nil
+}
+
+// buildAlertPayload encodes the alert in the message format expected by the webhook.
+func buildAlertPayload(alert *Alert, format string) ([]byte, error) {
+	switch format {
+	case "slack":
+		text := fmt.Sprintf("*%v*\n%v", alert.Title, alert.Message)
+		if alert.Link != "" {
+			text += fmt.Sprintf("\n<%v|Show details>", alert.Link)
+		}
+		return json.Marshal(map[string]interface{}{
+			"text": text,
+		})
+	case "discord":
+		embed := map[string]interface{}{
+			"title":       alert.Title,
+			"description": alert.Message,
+			"timestamp":   alert.Time.UTC().Format(time.RFC3339),
+			"footer": map[string]interface{}{
+				"text": fmt.Sprintf("%v (%v)", alert.Rule, alert.Type),
+			},
+		}
+		if alert.Link != "" {
+			embed["url"] = alert.Link
+		}
+		return json.Marshal(map[string]interface{}{
+			"content": "",
+			"embeds":  []interface{}{embed},
+		})
+	default:
+		return json.Marshal(alert)
+	}
+}
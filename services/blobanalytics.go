@@ -0,0 +1,169 @@
+package services
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/utils"
+)
+
+var blobSubmitterLabels map[common.Address]string
+var blobSubmitterLabelsOnce sync.Once
+
+// GetBlobSubmitterLabel returns the configured label (e.g. rollup name) for a blob transaction sender.
+func GetBlobSubmitterLabel(address common.Address) string {
+	blobSubmitterLabelsOnce.Do(func() {
+		blobSubmitterLabels = map[common.Address]string{}
+		for _, submitter := range utils.Config.BlobAnalytics.Submitters {
+			for _, address := range submitter.Addresses {
+				if !common.IsHexAddress(address) {
+					logrus.Warnf("invalid blob submitter address for %v: %v", submitter.Name, address)
+					continue
+				}
+				blobSubmitterLabels[common.HexToAddress(address)] = submitter.Name
+			}
+		}
+	})
+	return blobSubmitterLabels[address]
+}
+
+type BlobAnalyticsBucket struct {
+	StartEpoch     phase0.Epoch
+	EndEpoch       phase0.Epoch
+	BlockCount     uint64
+	BlobCount      uint64
+	BlobGasUsed    uint64
+	BlobBaseFeeSum float64 // wei, sum over all blocks with known blob base fee
+	BlobBaseFeeMax uint64  // wei
+	BaseFeeCount   uint64
+}
+
+type BlobSubmitterStats struct {
+	Address   common.Address
+	Name      string
+	TxCount   uint64
+	BlobCount uint64
+	BlobFees  float64 // wei
+}
+
+type BlobAnalyticsResult struct {
+	StartEpoch     phase0.Epoch
+	EndEpoch       phase0.Epoch
+	BlockCount     uint64
+	BlobBlockCount uint64
+	BlobCount      uint64
+	BlobGasUsed    uint64
+	BlobFees       float64                // wei
+	Submitters     []*BlobSubmitterStats  // sorted by blob count
+	Buckets        []*BlobAnalyticsBucket // in epoch order
+	BucketSize     uint64
+}
+
+// GetBlobAnalytics aggregates the blob usage & blob fees of finalized canonical blocks in the given epoch range.
+func (bs *ChainService) GetBlobAnalytics(startEpoch phase0.Epoch, endEpoch phase0.Epoch, bucketCount uint64) (*BlobAnalyticsResult, error) {
+	chainState := bs.consensusPool.GetChainState()
+
+	if bucketCount == 0 {
+		bucketCount = 1
+	}
+	epochCount := uint64(endEpoch-startEpoch) + 1
+	bucketSize := epochCount / bucketCount
+	if epochCount%bucketCount > 0 {
+		bucketSize++
+	}
+
+	result := &BlobAnalyticsResult{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		Submitters: []*BlobSubmitterStats{},
+		Buckets:    []*BlobAnalyticsBucket{},
+		BucketSize: bucketSize,
+	}
+
+	for bucketStart := uint64(startEpoch); bucketStart <= uint64(endEpoch); bucketStart += bucketSize {
+		result.Buckets = append(result.Buckets, &BlobAnalyticsBucket{
+			StartEpoch: phase0.Epoch(bucketStart),
+			EndEpoch:   phase0.Epoch(min(bucketStart+bucketSize-1, uint64(endEpoch))),
+		})
+	}
+
+	firstSlot := uint64(chainState.EpochToSlot(startEpoch))
+	lastSlot := uint64(chainState.EpochToSlot(endEpoch+1)) - 1
+
+	blobInfos, err := db.GetSlotBlobInfos(firstSlot, lastSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, blobInfo := range blobInfos {
+		result.BlockCount++
+		result.BlobCount += blobInfo.EthBlobCount
+		if blobInfo.EthBlobCount > 0 {
+			result.BlobBlockCount++
+		}
+		if blobInfo.EthBlobGasUsed != nil {
+			result.BlobGasUsed += *blobInfo.EthBlobGasUsed
+		}
+
+		bucketIdx := uint64(chainState.EpochOfSlot(phase0.Slot(blobInfo.Slot))-startEpoch) / bucketSize
+		if bucketIdx >= uint64(len(result.Buckets)) {
+			continue
+		}
+
+		bucket := result.Buckets[bucketIdx]
+		bucket.BlockCount++
+		bucket.BlobCount += blobInfo.EthBlobCount
+		if blobInfo.EthBlobGasUsed != nil {
+			bucket.BlobGasUsed += *blobInfo.EthBlobGasUsed
+		}
+		if blobInfo.EthBlobBaseFee != nil {
+			bucket.BaseFeeCount++
+			bucket.BlobBaseFeeSum += float64(*blobInfo.EthBlobBaseFee)
+			if *blobInfo.EthBlobBaseFee > bucket.BlobBaseFeeMax {
+				bucket.BlobBaseFeeMax = *blobInfo.EthBlobBaseFee
+			}
+		}
+	}
+
+	submissionInfos, err := db.GetBlobSubmissionInfos(firstSlot, lastSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	submitterMap := map[common.Address]*BlobSubmitterStats{}
+	for _, submissionInfo := range submissionInfos {
+		address := common.BytesToAddress(submissionInfo.TxFrom)
+		submitter := submitterMap[address]
+		if submitter == nil {
+			submitter = &BlobSubmitterStats{
+				Address: address,
+				Name:    GetBlobSubmitterLabel(address),
+			}
+			submitterMap[address] = submitter
+			result.Submitters = append(result.Submitters, submitter)
+		}
+
+		submitter.TxCount++
+		submitter.BlobCount += submissionInfo.BlobCount
+		if submissionInfo.EthBlobBaseFee != nil {
+			blobFees := float64(submissionInfo.BlobCount*params.BlobTxBlobGasPerBlob) * float64(*submissionInfo.EthBlobBaseFee)
+			submitter.BlobFees += blobFees
+			result.BlobFees += blobFees
+		}
+	}
+
+	sort.Slice(result.Submitters, func(a, b int) bool {
+		if result.Submitters[a].BlobCount != result.Submitters[b].BlobCount {
+			return result.Submitters[a].BlobCount > result.Submitters[b].BlobCount
+		}
+		return result.Submitters[a].TxCount > result.Submitters[b].TxCount
+	})
+
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"github.com/ethpandaops/dora/dbtypes"
+)
+
+// BlockRevenueSource describes where the proposer revenue of a block was derived from.
+type BlockRevenueSource uint8
+
+const (
+	BlockRevenueUnknown  BlockRevenueSource = iota
+	BlockRevenuePayment                     // builder payment transaction to the proposer
+	BlockRevenueReceipts                    // priority fees from the execution receipts
+	BlockRevenueRelay                       // block value reported by the mev relay
+)
+
+var blockRevenueSourceNames = map[BlockRevenueSource]string{
+	BlockRevenueUnknown:  "Unknown",
+	BlockRevenuePayment:  "Builder payment",
+	BlockRevenueReceipts: "Priority fees",
+	BlockRevenueRelay:    "Relay block value",
+}
+
+func (source BlockRevenueSource) String() string {
+	return blockRevenueSourceNames[source]
+}
+
+// GetBlockProposerRevenue returns what the proposer of a block earned on the execution layer (in gwei).
+// MEV-Boost blocks pay the proposer via the last payload transaction, while the priority fees go to the builder.
+// For locally built blocks the proposer receives the priority fees, which fall back to the relay reported value if receipts are unavailable.
+func GetBlockProposerRevenue(revenue *dbtypes.SlotRevenue) (uint64, BlockRevenueSource) {
+	switch {
+	case revenue.EthPaymentValue != nil:
+		return *revenue.EthPaymentValue, BlockRevenuePayment
+	case revenue.EthPriorityFees != nil:
+		return *revenue.EthPriorityFees, BlockRevenueReceipts
+	case revenue.MevBlockValue != nil:
+		return *revenue.MevBlockValue, BlockRevenueRelay
+	default:
+		return 0, BlockRevenueUnknown
+	}
+}
@@ -32,7 +32,10 @@ type ChainService struct {
 	depositIndexer       *execindexer.DepositIndexer
 	consolidationIndexer *execindexer.ConsolidationIndexer
 	withdrawalIndexer    *execindexer.WithdrawalIndexer
+	revenueIndexer       *execindexer.RevenueIndexer
 	mevRelayIndexer      *mevrelay.MevIndexer
+	alertManager         *AlertManager
+	consistencyChecker   *ConsistencyChecker
 	started              bool
 }
 
@@ -185,10 +188,29 @@ func (cs *ChainService) StartService() error {
 	cs.depositIndexer = execindexer.NewDepositIndexer(executionIndexerCtx)
 	cs.consolidationIndexer = execindexer.NewConsolidationIndexer(executionIndexerCtx)
 	cs.withdrawalIndexer = execindexer.NewWithdrawalIndexer(executionIndexerCtx)
+	if !utils.Config.ExecutionApi.DisableRevenueIndexer {
+		cs.revenueIndexer = execindexer.NewRevenueIndexer(executionIndexerCtx)
+	}
 
 	// start MEV relay indexer
 	cs.mevRelayIndexer.StartUpdater()
 
+	// start alert manager
+	if utils.Config.Alerting.Enabled {
+		alertManager, err := newAlertManager(cs.logger.WithField("service", "alerting"), cs)
+		if err != nil {
+			return fmt.Errorf("failed initializing alert manager: %v", err)
+		}
+		cs.alertManager = alertManager
+		cs.alertManager.startAlertManager()
+	}
+
+	// start db consistency checker
+	if utils.Config.ConsistencyChecker.Enabled {
+		cs.consistencyChecker = newConsistencyChecker(cs.logger.WithField("service", "consistency-checker"), cs)
+		cs.consistencyChecker.startConsistencyChecker()
+	}
+
 	return nil
 }
 
@@ -207,6 +229,14 @@ func (bs *ChainService) GetBeaconIndexer() *beacon.Indexer {
 	return bs.beaconIndexer
 }
 
+func (bs *ChainService) GetAlertManager() *AlertManager {
+	return bs.alertManager
+}
+
+func (bs *ChainService) GetConsistencyChecker() *ConsistencyChecker {
+	return bs.consistencyChecker
+}
+
 func (bs *ChainService) GetConsolidationIndexer() *execindexer.ConsolidationIndexer {
 	return bs.consolidationIndexer
 }
@@ -5,7 +5,9 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/attestantio/go-eth2-client/spec"
 	"github.com/attestantio/go-eth2-client/spec/deneb"
@@ -22,6 +24,7 @@ type CombinedBlockResponse struct {
 	Header   *phase0.SignedBeaconBlockHeader
 	Block    *spec.VersionedSignedBeaconBlock
 	Orphaned bool
+	RecvTime time.Time
 }
 
 // GetBlockBlob retrieves the blob sidecar for a given block root and commitment.
@@ -68,6 +71,7 @@ func (bs *ChainService) GetSlotDetailsByBlockroot(ctx context.Context, blockroot
 			Header:   blockInfo.GetHeader(),
 			Block:    blockInfo.GetBlock(),
 			Orphaned: !bs.beaconIndexer.IsCanonicalBlock(blockInfo, nil),
+			RecvTime: blockInfo.GetRecvTime(),
 		}
 	} else if blockInfo, err := bs.beaconIndexer.GetOrphanedBlockByRoot(blockroot); blockInfo != nil || err != nil {
 		if err != nil {
@@ -78,6 +82,7 @@ func (bs *ChainService) GetSlotDetailsByBlockroot(ctx context.Context, blockroot
 			Header:   blockInfo.GetHeader(),
 			Block:    blockInfo.GetBlock(),
 			Orphaned: true,
+			RecvTime: blockInfo.GetRecvTime(),
 		}
 	} else {
 		var header *phase0.SignedBeaconBlockHeader
@@ -161,6 +166,7 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 			Header:   cachedBlock.GetHeader(),
 			Block:    cachedBlock.GetBlock(),
 			Orphaned: isOrphaned,
+			RecvTime: cachedBlock.GetRecvTime(),
 		}
 	} else {
 
@@ -221,6 +227,59 @@ func (bs *ChainService) GetSlotDetailsBySlot(ctx context.Context, slot phase0.Sl
 	return result, nil
 }
 
+// GetSlotBlockVariants retrieves all known blocks (canonical & orphaned) for a given slot.
+// The canonical block is returned first, followed by the orphaned blocks.
+func (bs *ChainService) GetSlotBlockVariants(ctx context.Context, slot phase0.Slot) ([]*CombinedBlockResponse, error) {
+	results := []*CombinedBlockResponse{}
+	knownRoots := map[phase0.Root]bool{}
+
+	for _, block := range bs.beaconIndexer.GetBlocksBySlot(slot) {
+		header := block.GetHeader()
+		blockBody := block.GetBlock()
+		if header == nil || blockBody == nil {
+			continue
+		}
+
+		knownRoots[block.Root] = true
+		results = append(results, &CombinedBlockResponse{
+			Root:     block.Root,
+			Header:   header,
+			Block:    blockBody,
+			Orphaned: !bs.beaconIndexer.IsCanonicalBlock(block, nil),
+			RecvTime: block.GetRecvTime(),
+		})
+	}
+
+	for _, dbSlot := range db.GetSlotsRange(uint64(slot), uint64(slot), false, true) {
+		if dbSlot.Block == nil {
+			continue
+		}
+
+		blockRoot := phase0.Root(dbSlot.Block.Root)
+		if knownRoots[blockRoot] {
+			continue
+		}
+
+		blockData, err := bs.GetSlotDetailsByBlockroot(ctx, blockRoot)
+		if err != nil {
+			return nil, err
+		}
+		if blockData == nil || blockData.Header == nil || blockData.Block == nil {
+			continue
+		}
+
+		blockData.Orphaned = dbSlot.Block.Status == dbtypes.Orphaned
+		knownRoots[blockRoot] = true
+		results = append(results, blockData)
+	}
+
+	sort.SliceStable(results, func(a, b int) bool {
+		return !results[a].Orphaned && results[b].Orphaned
+	})
+
+	return results, nil
+}
+
 // GetBlobSidecarsByBlockRoot retrieves the blob sidecars for a given block root.
 // It first tries to find a client that has the block root in its cache, and if not found,
 // it falls back to a random ready client. It then retrieves the blob sidecars for the block root
@@ -499,6 +558,17 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 	if withScheduledCount > 0 {
 		startSlot += phase0.Slot(withScheduledCount)
 	}
+	if filter.MaxSlot > 0 && startSlot > phase0.Slot(filter.MaxSlot) {
+		startSlot = phase0.Slot(filter.MaxSlot)
+	}
+
+	var proposerIndexMap map[uint64]bool
+	if len(filter.ProposerIndexes) > 0 {
+		proposerIndexMap = make(map[uint64]bool, len(filter.ProposerIndexes))
+		for _, proposerIndex := range filter.ProposerIndexes {
+			proposerIndexMap[proposerIndex] = true
+		}
+	}
 
 	// getCanonicalProposer is a local helper function to get the canonical proposer for a given slot
 	var proposerAssignments map[phase0.Slot]phase0.ValidatorIndex
@@ -587,6 +657,9 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 					continue
 				}
 			}
+			if proposerIndexMap != nil && !proposerIndexMap[proposer] {
+				continue
+			}
 			if filter.ProposerName != "" {
 				proposerName := bs.validatorNames.GetValidatorName(proposer)
 				if !strings.Contains(proposerName, filter.ProposerName) {
@@ -637,6 +710,9 @@ func (bs *ChainService) GetDbBlocksByFilter(filter *dbtypes.BlockFilter, pageIdx
 						continue
 					}
 				}
+				if proposerIndexMap != nil && !proposerIndexMap[uint64(canonicalProposer)] {
+					continue
+				}
 				if filter.ProposerName != "" {
 					assignedName := bs.validatorNames.GetValidatorName(uint64(canonicalProposer))
 					if assignedName == "" || !strings.Contains(assignedName, filter.ProposerName) {
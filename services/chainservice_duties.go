@@ -0,0 +1,262 @@
+package services
+
+import (
+	"encoding/hex"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+)
+
+// ValidatorSetFilter describes a user-defined set of validators.
+// All criteria are combined, so a validator matching any of them is part of the set.
+type ValidatorSetFilter struct {
+	Indices           []uint64
+	Pubkeys           [][]byte
+	WithdrawalAddress []byte
+	NamePattern       string
+}
+
+// ResolveValidatorSet returns the sorted validator indices matching the given set filter.
+// The limit parameter caps the number of returned indices (0 = no limit).
+func (bs *ChainService) ResolveValidatorSet(filter *ValidatorSetFilter, limit uint64) []phase0.ValidatorIndex {
+	indexMap := map[phase0.ValidatorIndex]bool{}
+	validatorSetSize := bs.beaconIndexer.GetValidatorSetSize()
+
+	for _, index := range filter.Indices {
+		if index < validatorSetSize {
+			indexMap[phase0.ValidatorIndex(index)] = true
+		}
+	}
+
+	for _, pubkey := range filter.Pubkeys {
+		if len(pubkey) != 48 {
+			continue
+		}
+
+		index, found := bs.beaconIndexer.GetValidatorIndexByPubkey(phase0.BLSPubKey(pubkey))
+		if found {
+			indexMap[index] = true
+		}
+	}
+
+	if len(filter.WithdrawalAddress) == 20 {
+		validators, _ := bs.GetFilteredValidatorSet(&dbtypes.ValidatorFilter{
+			WithdrawalAddress: filter.WithdrawalAddress,
+		}, false)
+		for _, validator := range validators {
+			indexMap[validator.Index] = true
+		}
+	}
+
+	if filter.NamePattern != "" {
+		namePattern := strings.ToLower(filter.NamePattern)
+		bs.StreamActiveValidatorData(false, func(index phase0.ValidatorIndex, flags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
+			vname := bs.validatorNames.GetValidatorName(uint64(index))
+			if vname != "" && strings.Contains(strings.ToLower(vname), namePattern) {
+				indexMap[index] = true
+			}
+			return nil
+		})
+	}
+
+	indices := make([]phase0.ValidatorIndex, 0, len(indexMap))
+	for index := range indexMap {
+		indices = append(indices, index)
+	}
+
+	slices.Sort(indices)
+
+	if limit > 0 && uint64(len(indices)) > limit {
+		indices = indices[:limit]
+	}
+
+	return indices
+}
+
+type ValidatorDutyType uint8
+
+const (
+	ValidatorDutyProposal ValidatorDutyType = iota + 1
+	ValidatorDutySyncCommittee
+)
+
+// ValidatorDuty represents an upcoming duty of a validator.
+// For sync committee duties Slot and EndSlot describe the first and last slot of the sync period.
+type ValidatorDuty struct {
+	Type           ValidatorDutyType
+	ValidatorIndex phase0.ValidatorIndex
+	Slot           phase0.Slot
+	EndSlot        phase0.Slot
+	SyncPeriod     uint64
+	Predicted      bool
+}
+
+// GetUpcomingValidatorDuties returns the upcoming proposer & sync committee duties for the given validator indices.
+// Proposer duties are returned for the current and next epoch, sync committee duties for the current and next sync period.
+// Duties that might still change due to a reorg or missing dependent state are flagged as predicted.
+func (bs *ChainService) GetUpcomingValidatorDuties(validatorIndices []phase0.ValidatorIndex) []*ValidatorDuty {
+	chainState := bs.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	currentSlot := chainState.CurrentSlot()
+	currentEpoch := chainState.EpochOfSlot(currentSlot)
+
+	validatorMap := make(map[phase0.ValidatorIndex]bool, len(validatorIndices))
+	for _, index := range validatorIndices {
+		validatorMap[index] = true
+	}
+
+	result := []*ValidatorDuty{}
+
+	// proposer duties for the current & next epoch
+	for epoch := currentEpoch; epoch <= currentEpoch+1; epoch++ {
+		epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil)
+		if epochStats == nil {
+			continue
+		}
+
+		epochStatsValues := epochStats.GetValues(true)
+		if epochStatsValues == nil {
+			continue
+		}
+
+		epochStartSlot := chainState.EpochStartSlot(epoch)
+		for slotIdx, proposer := range epochStatsValues.ProposerDuties {
+			slot := epochStartSlot + phase0.Slot(slotIdx)
+			if slot < currentSlot || proposer == math.MaxInt64 || !validatorMap[proposer] {
+				continue
+			}
+
+			result = append(result, &ValidatorDuty{
+				Type:           ValidatorDutyProposal,
+				ValidatorIndex: proposer,
+				Slot:           slot,
+				EndSlot:        slot,
+				Predicted:      epoch > currentEpoch,
+			})
+		}
+	}
+
+	// sync committee duties for the current & next sync period
+	if specs.AltairForkEpoch != nil && currentEpoch >= phase0.Epoch(*specs.AltairForkEpoch) {
+		currentPeriod := uint64(currentEpoch) / specs.EpochsPerSyncCommitteePeriod
+
+		var currentCommittee, nextCommittee []phase0.ValidatorIndex
+		nextCommitteePredicted := false
+
+		epochStats := bs.beaconIndexer.GetEpochStats(currentEpoch, nil)
+		if epochStatsValues := epochStats.GetValues(true); epochStatsValues != nil {
+			currentCommittee = epochStatsValues.SyncCommitteeDuties
+			nextCommittee = epochStatsValues.NextSyncCommittee
+		}
+
+		if len(currentCommittee) == 0 {
+			currentCommittee = bs.getDbSyncCommittee(currentPeriod)
+		}
+
+		if len(nextCommittee) == 0 {
+			// the next committee is only known from a loaded beacon state. fall back to the db or
+			// to the (precomputed) epoch stats of the next epoch if it's the first epoch of the next period.
+			nextCommittee = bs.getDbSyncCommittee(currentPeriod + 1)
+
+			nextPeriodEpoch := phase0.Epoch((currentPeriod + 1) * specs.EpochsPerSyncCommitteePeriod)
+			if len(nextCommittee) == 0 && nextPeriodEpoch == currentEpoch+1 {
+				nextEpochStats := bs.beaconIndexer.GetEpochStats(nextPeriodEpoch, nil)
+				if nextEpochStatsValues := nextEpochStats.GetValues(true); nextEpochStatsValues != nil {
+					nextCommittee = nextEpochStatsValues.SyncCommitteeDuties
+					nextCommitteePredicted = true
+				}
+			}
+		}
+
+		addSyncDuties := func(period uint64, committee []phase0.ValidatorIndex, predicted bool) {
+			periodStartSlot := chainState.EpochToSlot(phase0.Epoch(period * specs.EpochsPerSyncCommitteePeriod))
+			periodEndSlot := chainState.EpochToSlot(phase0.Epoch((period+1)*specs.EpochsPerSyncCommitteePeriod)) - 1
+
+			addedMap := map[phase0.ValidatorIndex]bool{}
+			for _, index := range committee {
+				if !validatorMap[index] || addedMap[index] {
+					continue
+				}
+				addedMap[index] = true
+
+				result = append(result, &ValidatorDuty{
+					Type:           ValidatorDutySyncCommittee,
+					ValidatorIndex: index,
+					Slot:           periodStartSlot,
+					EndSlot:        periodEndSlot,
+					SyncPeriod:     period,
+					Predicted:      predicted,
+				})
+			}
+		}
+
+		addSyncDuties(currentPeriod, currentCommittee, false)
+		addSyncDuties(currentPeriod+1, nextCommittee, nextCommitteePredicted)
+	}
+
+	sort.Slice(result, func(a, b int) bool {
+		if result[a].Slot != result[b].Slot {
+			return result[a].Slot < result[b].Slot
+		}
+		if result[a].Type != result[b].Type {
+			return result[a].Type < result[b].Type
+		}
+		return result[a].ValidatorIndex < result[b].ValidatorIndex
+	})
+
+	return result
+}
+
+func (bs *ChainService) getDbSyncCommittee(period uint64) []phase0.ValidatorIndex {
+	assignments := db.GetSyncAssignmentsForPeriod(period)
+	committee := make([]phase0.ValidatorIndex, len(assignments))
+	for i, index := range assignments {
+		committee[i] = phase0.ValidatorIndex(index)
+	}
+	return committee
+}
+
+// maxValidatorSetListEntries is the maximum number of entries parsed from a validator set list
+const maxValidatorSetListEntries = 10000
+
+// ParseValidatorSetList parses a list of validator indices and pubkeys separated by spaces, commas or line breaks.
+// Duplicate entries are skipped and at most maxValidatorSetListEntries entries are parsed.
+func ParseValidatorSetList(list string) (indices []uint64, pubkeys [][]byte) {
+	fields := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ';' || unicode.IsSpace(r)
+	})
+	if len(fields) > maxValidatorSetListEntries {
+		fields = fields[:maxValidatorSetListEntries]
+	}
+
+	indiceMap := make(map[uint64]bool, len(fields))
+	pubkeyMap := make(map[string]bool)
+
+	for _, field := range fields {
+		field = strings.TrimPrefix(field, "0x")
+		if len(field) == 96 {
+			pubkey, err := hex.DecodeString(field)
+			if err == nil && !pubkeyMap[string(pubkey)] {
+				pubkeyMap[string(pubkey)] = true
+				pubkeys = append(pubkeys, pubkey)
+			}
+			continue
+		}
+
+		index, err := strconv.ParseUint(field, 10, 64)
+		if err == nil && !indiceMap[index] {
+			indiceMap[index] = true
+			indices = append(indices, index)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type OperatorPerformanceFilter struct {
+	StartEpoch    phase0.Epoch
+	EndEpoch      phase0.Epoch
+	GroupByPrefix bool
+	NameFilter    string
+}
+
+type OperatorPerformance struct {
+	Name              string
+	ValidatorCount    uint64
+	ProposalsHit      uint64
+	ProposalsMissed   uint64
+	ProposalsOrphaned uint64
+	MissedOffline     uint64
+	MissedRelay       uint64
+	MissedLate        uint64
+	MissedOrphaned    uint64
+	MissedUnknown     uint64
+	AttestationsDue   uint64
+	AttestationsHit   uint64
+	SyncDue           uint64
+	SyncHit           uint64
+	Slashings         uint64
+}
+
+type OperatorPerformanceResult struct {
+	Operators []*OperatorPerformance
+
+	// attestation & sync participation is only available for epochs that are still held in the indexer cache
+	HasActivity        bool
+	ActivityStartEpoch phase0.Epoch
+	ActivityEndEpoch   phase0.Epoch
+}
+
+// GetOperatorGroupName returns the group name of a validator name.
+// With prefix grouping, a trailing numeric segment is stripped (e.g. "lighthouse-geth-1" -> "lighthouse-geth").
+func GetOperatorGroupName(name string, groupByPrefix bool) string {
+	if !groupByPrefix {
+		return name
+	}
+
+	sepIdx := strings.LastIndexAny(name, "-_ ")
+	if sepIdx <= 0 || sepIdx == len(name)-1 {
+		return name
+	}
+
+	for _, char := range name[sepIdx+1:] {
+		if char < '0' || char > '9' {
+			return name
+		}
+	}
+
+	return name[:sepIdx]
+}
+
+// GetOperatorPerformance aggregates the validator performance by validator name or name prefix over the given epoch range.
+func (bs *ChainService) GetOperatorPerformance(filter *OperatorPerformanceFilter) *OperatorPerformanceResult {
+	chainState := bs.consensusPool.GetChainState()
+	result := &OperatorPerformanceResult{
+		Operators: []*OperatorPerformance{},
+	}
+
+	// group named validators
+	operatorMap := map[string]*OperatorPerformance{}
+	validatorOperators := map[phase0.ValidatorIndex]*OperatorPerformance{}
+	for index, name := range bs.validatorNames.GetNamedValidators() {
+		groupName := GetOperatorGroupName(name, filter.GroupByPrefix)
+		if filter.NameFilter != "" && !strings.Contains(groupName, filter.NameFilter) {
+			continue
+		}
+
+		operator := operatorMap[groupName]
+		if operator == nil {
+			operator = &OperatorPerformance{
+				Name: groupName,
+			}
+			operatorMap[groupName] = operator
+			result.Operators = append(result.Operators, operator)
+		}
+
+		operator.ValidatorCount++
+		validatorOperators[phase0.ValidatorIndex(index)] = operator
+	}
+
+	if len(result.Operators) == 0 {
+		return result
+	}
+
+	startSlot := chainState.EpochToSlot(filter.StartEpoch)
+	endSlot := chainState.EpochToSlot(filter.EndEpoch+1) - 1
+	if currentSlot := chainState.CurrentSlot(); endSlot >= currentSlot {
+		if currentSlot == 0 {
+			return result
+		}
+		endSlot = currentSlot - 1
+	}
+	if endSlot < startSlot {
+		return result
+	}
+
+	bs.aggregateOperatorProposals(validatorOperators, startSlot, endSlot)
+	bs.aggregateOperatorSlashings(validatorOperators, startSlot, endSlot)
+
+	// attestation & sync participation from recent validator activity
+	currentEpoch := chainState.CurrentEpoch()
+	_, oldestActivityEpoch := bs.beaconIndexer.GetValidatorActivity(0)
+	activityStartEpoch := max(filter.StartEpoch, oldestActivityEpoch)
+	activityEndEpoch := filter.EndEpoch
+	if currentEpoch < 2 {
+		return result
+	}
+	if activityEndEpoch > currentEpoch-2 {
+		// votes for an epoch can be included until the end of the next epoch
+		activityEndEpoch = currentEpoch - 2
+	}
+
+	if activityStartEpoch <= activityEndEpoch {
+		result.HasActivity = true
+		result.ActivityStartEpoch = activityStartEpoch
+		result.ActivityEndEpoch = activityEndEpoch
+
+		bs.aggregateOperatorAttestations(validatorOperators, activityStartEpoch, activityEndEpoch)
+		bs.aggregateOperatorSyncParticipation(validatorOperators, activityStartEpoch, activityEndEpoch)
+	}
+
+	return result
+}
+
+func (bs *ChainService) aggregateOperatorProposals(validatorOperators map[phase0.ValidatorIndex]*OperatorPerformance, startSlot phase0.Slot, endSlot phase0.Slot) {
+	chainState := bs.consensusPool.GetChainState()
+	finalizedEpoch, _ := bs.beaconIndexer.GetBlockCacheState()
+	finalizedSlot := chainState.EpochToSlot(finalizedEpoch)
+
+	addSlot := func(proposer uint64, status dbtypes.SlotStatus, count uint64) {
+		operator := validatorOperators[phase0.ValidatorIndex(proposer)]
+		if operator == nil {
+			return
+		}
+
+		switch status {
+		case dbtypes.Canonical:
+			operator.ProposalsHit += count
+		case dbtypes.Orphaned:
+			operator.ProposalsOrphaned += count
+		case dbtypes.Missing:
+			operator.ProposalsMissed += count
+		}
+	}
+
+	missedSlots := []MissedSlotRef{}
+
+	// finalized slots
+	if startSlot < finalizedSlot {
+		slotCounts, err := db.GetProposerSlotCounts(uint64(startSlot), uint64(min(endSlot, finalizedSlot-1)))
+		if err == nil {
+			for _, slotCount := range slotCounts {
+				addSlot(slotCount.Proposer, slotCount.Status, slotCount.Count)
+			}
+		}
+
+		dbMissedSlots, err := db.GetMissedSlots(uint64(startSlot), uint64(min(endSlot, finalizedSlot-1)))
+		if err == nil {
+			for _, missedSlot := range dbMissedSlots {
+				if validatorOperators[phase0.ValidatorIndex(missedSlot.Proposer)] != nil {
+					missedSlots = append(missedSlots, MissedSlotRef{Slot: phase0.Slot(missedSlot.Slot), Proposer: phase0.ValidatorIndex(missedSlot.Proposer)})
+				}
+			}
+		}
+	}
+
+	// unfinalized slots
+	if endSlot >= finalizedSlot {
+		firstSlot := max(startSlot, finalizedSlot)
+		for _, slot := range bs.GetDbBlocksForSlots(uint64(endSlot), uint32(endSlot-firstSlot+1), true, true) {
+			if slot.Slot < uint64(firstSlot) {
+				continue
+			}
+			addSlot(slot.Proposer, slot.Status, 1)
+
+			if slot.Status == dbtypes.Missing && validatorOperators[phase0.ValidatorIndex(slot.Proposer)] != nil {
+				missedSlots = append(missedSlots, MissedSlotRef{Slot: phase0.Slot(slot.Slot), Proposer: phase0.ValidatorIndex(slot.Proposer)})
+			}
+		}
+	}
+
+	// classify missed slots by root cause
+	missedCauses := bs.ClassifyMissedSlots(missedSlots)
+	for _, missedSlot := range missedSlots {
+		operator := validatorOperators[missedSlot.Proposer]
+		switch missedCauses[missedSlot.Slot] {
+		case MissedSlotCauseProposerOffline:
+			operator.MissedOffline++
+		case MissedSlotCauseRelayFailure:
+			operator.MissedRelay++
+		case MissedSlotCauseLateBlock:
+			operator.MissedLate++
+		case MissedSlotCauseOrphanedBlock:
+			operator.MissedOrphaned++
+		default:
+			operator.MissedUnknown++
+		}
+	}
+}
+
+func (bs *ChainService) aggregateOperatorSlashings(validatorOperators map[phase0.ValidatorIndex]*OperatorPerformance, startSlot phase0.Slot, endSlot phase0.Slot) {
+	pageSize := uint32(1000)
+	for pageIdx := uint64(0); ; pageIdx++ {
+		slashings, _ := bs.GetSlashingsByFilter(&dbtypes.SlashingFilter{
+			MinSlot: uint64(startSlot),
+			MaxSlot: uint64(endSlot),
+		}, pageIdx, pageSize)
+
+		for _, slashing := range slashings {
+			if operator := validatorOperators[phase0.ValidatorIndex(slashing.ValidatorIndex)]; operator != nil {
+				operator.Slashings++
+			}
+		}
+
+		if len(slashings) < int(pageSize) {
+			break
+		}
+	}
+}
+
+func (bs *ChainService) aggregateOperatorAttestations(validatorOperators map[phase0.ValidatorIndex]*OperatorPerformance, startEpoch phase0.Epoch, endEpoch phase0.Epoch) {
+	chainState := bs.consensusPool.GetChainState()
+
+	for index, operator := range validatorOperators {
+		validator := bs.beaconIndexer.GetValidatorByIndex(index, nil)
+		if validator == nil {
+			continue
+		}
+
+		activeStart := max(startEpoch, validator.ActivationEpoch)
+		activeEnd := endEpoch
+		if validator.ExitEpoch <= activeEnd {
+			if validator.ExitEpoch == 0 {
+				continue
+			}
+			activeEnd = validator.ExitEpoch - 1
+		}
+		if activeStart > activeEnd {
+			continue
+		}
+
+		operator.AttestationsDue += uint64(activeEnd - activeStart + 1)
+
+		votedEpochs := map[phase0.Epoch]bool{}
+		activity, _ := bs.beaconIndexer.GetValidatorActivity(index)
+		for _, vote := range activity {
+			dutyEpoch := chainState.EpochOfSlot(vote.VoteBlock.Slot - phase0.Slot(vote.VoteDelay))
+			if dutyEpoch >= activeStart && dutyEpoch <= activeEnd {
+				votedEpochs[dutyEpoch] = true
+			}
+		}
+
+		operator.AttestationsHit += uint64(len(votedEpochs))
+	}
+}
+
+func (bs *ChainService) aggregateOperatorSyncParticipation(validatorOperators map[phase0.ValidatorIndex]*OperatorPerformance, startEpoch phase0.Epoch, endEpoch phase0.Epoch) {
+	chainState := bs.consensusPool.GetChainState()
+	canonicalForkIds := bs.GetCanonicalForkKeys()
+
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		epochStats := bs.beaconIndexer.GetEpochStats(epoch, nil)
+		if epochStats == nil {
+			continue
+		}
+
+		epochStatsValues := epochStats.GetValues(false)
+		if epochStatsValues == nil || len(epochStatsValues.SyncCommitteeDuties) == 0 {
+			continue
+		}
+
+		firstSlot := chainState.EpochToSlot(epoch)
+		lastSlot := chainState.EpochToSlot(epoch + 1)
+		for slot := firstSlot; slot < lastSlot; slot++ {
+			for _, block := range bs.beaconIndexer.GetBlocksBySlot(slot) {
+				if !slices.Contains(canonicalForkIds, block.GetForkId()) {
+					continue
+				}
+
+				blockBody := block.GetBlock()
+				if blockBody == nil {
+					continue
+				}
+
+				syncAggregate, err := blockBody.SyncAggregate()
+				if err != nil || syncAggregate == nil {
+					continue
+				}
+
+				for i, validatorIndex := range epochStatsValues.SyncCommitteeDuties {
+					operator := validatorOperators[validatorIndex]
+					if operator == nil {
+						continue
+					}
+
+					operator.SyncDue++
+					if utils.BitAtVector(syncAggregate.SyncCommitteeBits, i) {
+						operator.SyncHit++
+					}
+				}
+			}
+		}
+	}
+}
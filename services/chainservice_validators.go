@@ -2,7 +2,6 @@ package services
 
 import (
 	"bytes"
-	"slices"
 	"sort"
 	"strings"
 
@@ -69,6 +68,10 @@ type ValidatorWithIndex struct {
 
 // GetFilteredValidatorSet returns validators with a specific withdrawal address for a given blockRoot
 func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter, withBalance bool) ([]v1.Validator, uint64) {
+	if filter.AsOfEpoch != nil {
+		return bs.getFilteredValidatorSetAtEpoch(filter)
+	}
+
 	var overrideForkId *beacon.ForkKey
 
 	canonicalHead := bs.beaconIndexer.GetCanonicalHead(overrideForkId)
@@ -86,24 +89,29 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 	cachedIndexes := map[uint64]bool{}
 
 	// get matching entries from cached validators
-	bs.beaconIndexer.StreamActiveValidatorDataForRoot(canonicalHead.Root, false, &currentEpoch, func(index phase0.ValidatorIndex, flags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
-		if validator == nil {
-			return nil
-		}
-		if filter.MinIndex != nil && index < phase0.ValidatorIndex(*filter.MinIndex) {
-			return nil
-		}
-		if filter.MaxIndex != nil && index > phase0.ValidatorIndex(*filter.MaxIndex) {
+	// index range, withdrawal credential type & status are pre-filtered on the columnar validator set
+	cacheFilter := &beacon.ValidatorSetFilter{
+		OnlyInMemory: true,
+		Status:       filter.Status,
+		Epoch:        &currentEpoch,
+		Balances:     balances,
+	}
+	if filter.MinIndex != nil {
+		minIndex := phase0.ValidatorIndex(*filter.MinIndex)
+		cacheFilter.MinIndex = &minIndex
+	}
+	if filter.MaxIndex != nil {
+		maxIndex := phase0.ValidatorIndex(*filter.MaxIndex)
+		cacheFilter.MaxIndex = &maxIndex
+	}
+	if filter.WithdrawalAddress != nil {
+		cacheFilter.StatusFlags = beacon.ValidatorStatusHasAddress
+	}
+
+	bs.beaconIndexer.StreamFilteredValidatorSetForRoot(canonicalHead.Root, cacheFilter, func(index phase0.ValidatorIndex, flags uint16, activeData *beacon.ValidatorData, validator *phase0.Validator) error {
+		if filter.WithdrawalAddress != nil && !bytes.Equal(validator.WithdrawalCredentials[12:], filter.WithdrawalAddress[:]) {
 			return nil
 		}
-		if filter.WithdrawalAddress != nil {
-			if validator.WithdrawalCredentials[0] != 0x01 && validator.WithdrawalCredentials[0] != 0x02 {
-				return nil
-			}
-			if !bytes.Equal(validator.WithdrawalCredentials[12:], filter.WithdrawalAddress[:]) {
-				return nil
-			}
-		}
 		if filter.ValidatorName != "" {
 			vname := bs.validatorNames.GetValidatorName(uint64(index))
 			if !strings.Contains(vname, filter.ValidatorName) {
@@ -111,17 +119,6 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 			}
 		}
 
-		if len(filter.Status) > 0 {
-			var balancePtr *phase0.Gwei
-			if balances != nil {
-				balancePtr = &balances[index]
-			}
-			validatorState := v1.ValidatorToState(validator, balancePtr, currentEpoch, beacon.FarFutureEpoch)
-			if !slices.Contains(filter.Status, validatorState) {
-				return nil
-			}
-		}
-
 		cachedResults = append(cachedResults, ValidatorWithIndex{
 			Index:     index,
 			Validator: validator,
@@ -319,3 +316,77 @@ func (bs *ChainService) GetFilteredValidatorSet(filter *dbtypes.ValidatorFilter,
 
 	return result, matchingCount
 }
+
+// getFilteredValidatorSetAtEpoch returns the validators matching the filter as of filter.AsOfEpoch from the validator history.
+// Balances are not tracked historically, so the effective balance is returned as balance.
+func (bs *ChainService) getFilteredValidatorSetAtEpoch(filter *dbtypes.ValidatorFilter) ([]v1.Validator, uint64) {
+	asOfEpoch := *filter.AsOfEpoch
+	if !bs.IsValidatorHistoryAvailable(phase0.Epoch(asOfEpoch)) {
+		return []v1.Validator{}, 0
+	}
+
+	dbIndexes, err := db.GetValidatorIndexesByFilter(*filter, asOfEpoch)
+	if err != nil {
+		bs.logger.Warnf("error getting validator indexes by filter at epoch %v: %v", asOfEpoch, err)
+		return nil, 0
+	}
+
+	matchingCount := uint64(len(dbIndexes))
+	if filter.Offset >= matchingCount {
+		return []v1.Validator{}, matchingCount
+	}
+
+	dbIndexes = dbIndexes[filter.Offset:]
+	if filter.Limit > 0 && uint64(len(dbIndexes)) > filter.Limit {
+		dbIndexes = dbIndexes[:filter.Limit]
+	}
+
+	result := make([]v1.Validator, 0, len(dbIndexes))
+	err = db.StreamValidatorsByIndexesAtEpoch(dbIndexes, asOfEpoch, func(validator *dbtypes.Validator) bool {
+		validatorData := beacon.UnwrapDbValidator(validator)
+		result = append(result, v1.Validator{
+			Index:     phase0.ValidatorIndex(validator.ValidatorIndex),
+			Balance:   validatorData.EffectiveBalance,
+			Status:    v1.ValidatorToState(validatorData, nil, phase0.Epoch(asOfEpoch), beacon.FarFutureEpoch),
+			Validator: validatorData,
+		})
+		return true
+	})
+	if err != nil {
+		bs.logger.Warnf("error streaming validators at epoch %v: %v", asOfEpoch, err)
+		return nil, 0
+	}
+
+	return result, matchingCount
+}
+
+// GetValidatorStatusMapAtEpoch returns the number of validators per status at a past epoch from the validator history.
+func (bs *ChainService) GetValidatorStatusMapAtEpoch(epoch phase0.Epoch) map[v1.ValidatorState]uint64 {
+	if !bs.IsValidatorHistoryAvailable(epoch) {
+		return nil
+	}
+
+	statusMap, err := db.GetValidatorStatusCountsAtEpoch(uint64(epoch))
+	if err != nil {
+		return nil
+	}
+
+	return statusMap
+}
+
+// GetValidatorHistoryStartEpoch returns the first epoch that is covered by the validator history.
+// Returns false if no validator history has been recorded yet.
+func (bs *ChainService) GetValidatorHistoryStartEpoch() (phase0.Epoch, bool) {
+	historyState := &dbtypes.ValidatorHistoryState{}
+	if _, err := db.GetExplorerState("indexer.validatorhistory", historyState); err != nil {
+		return 0, false
+	}
+
+	return phase0.Epoch(historyState.StartEpoch), true
+}
+
+// IsValidatorHistoryAvailable checks if the validator set at the given epoch can be loaded from the validator history.
+func (bs *ChainService) IsValidatorHistoryAvailable(epoch phase0.Epoch) bool {
+	startEpoch, found := bs.GetValidatorHistoryStartEpoch()
+	return found && epoch >= startEpoch
+}
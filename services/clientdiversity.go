@@ -0,0 +1,254 @@
+package services
+
+import (
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/types"
+	"github.com/ethpandaops/dora/utils"
+)
+
+const ClientDiversityUnknown = "Unknown"
+
+// client codes as used in the client version graffiti convention (see engine_getClientVersionV1)
+var clientVersionCodes = map[string]struct {
+	name        string
+	isConsensus bool
+}{
+	"BU": {"Besu", false},
+	"EJ": {"EthereumJS", false},
+	"EG": {"Erigon", false},
+	"GE": {"Geth", false},
+	"NM": {"Nethermind", false},
+	"RH": {"Reth", false},
+	"GR": {"Grandine", true},
+	"LH": {"Lighthouse", true},
+	"LS": {"Lodestar", true},
+	"NB": {"Nimbus", true},
+	"PM": {"Prysm", true},
+	"TK": {"Teku", true},
+}
+
+var clientVersionGraffitiPattern = regexp.MustCompile(`^([A-Z]{2})(?:[0-9a-f]{2,4})?([A-Z]{2})(?:[0-9a-f]{2,4})?`)
+
+var defaultClClientPatterns = []types.ClientPatternConfig{
+	{Name: "Lighthouse", Pattern: `lighthouse`},
+	{Name: "Prysm", Pattern: `prysm`},
+	{Name: "Teku", Pattern: `teku`},
+	{Name: "Nimbus", Pattern: `nimbus`},
+	{Name: "Lodestar", Pattern: `lodestar`},
+	{Name: "Grandine", Pattern: `grandine`},
+}
+
+var defaultElClientPatterns = []types.ClientPatternConfig{
+	{Name: "Geth", Pattern: `geth`},
+	{Name: "Nethermind", Pattern: `nethermind`},
+	{Name: "Besu", Pattern: `besu`},
+	{Name: "Erigon", Pattern: `erigon`},
+	{Name: "Reth", Pattern: `reth`},
+	{Name: "EthereumJS", Pattern: `ethereumjs`},
+}
+
+type clientPattern struct {
+	name    string
+	pattern *regexp.Regexp
+}
+
+// ClientDiversityParser detects the consensus & execution client of a block from its graffiti and execution extra data.
+type ClientDiversityParser struct {
+	clPatterns []*clientPattern
+	elPatterns []*clientPattern
+}
+
+var clientDiversityParser *ClientDiversityParser
+var clientDiversityParserOnce sync.Once
+
+// GetClientDiversityParser returns the client parser with the configured and built-in client patterns.
+func GetClientDiversityParser() *ClientDiversityParser {
+	clientDiversityParserOnce.Do(func() {
+		clientDiversityParser = &ClientDiversityParser{
+			clPatterns: compileClientPatterns(slices.Concat(utils.Config.ClientDiversity.ClPatterns, defaultClClientPatterns)),
+			elPatterns: compileClientPatterns(slices.Concat(utils.Config.ClientDiversity.ElPatterns, defaultElClientPatterns)),
+		}
+	})
+	return clientDiversityParser
+}
+
+func compileClientPatterns(patternConfigs []types.ClientPatternConfig) []*clientPattern {
+	patterns := make([]*clientPattern, 0, len(patternConfigs))
+	for _, patternConfig := range patternConfigs {
+		pattern, err := regexp.Compile("(?i)" + patternConfig.Pattern)
+		if err != nil {
+			logrus.Warnf("invalid client diversity pattern for %v: %v", patternConfig.Name, err)
+			continue
+		}
+
+		patterns = append(patterns, &clientPattern{
+			name:    patternConfig.Name,
+			pattern: pattern,
+		})
+	}
+	return patterns
+}
+
+func matchClientPatterns(patterns []*clientPattern, text string) string {
+	if text == "" {
+		return ""
+	}
+
+	for _, pattern := range patterns {
+		if pattern.pattern.MatchString(text) {
+			return pattern.name
+		}
+	}
+	return ""
+}
+
+// ParseBlockClients returns the consensus & execution client names for a block.
+// Clients that cannot be detected are returned as ClientDiversityUnknown.
+func (p *ClientDiversityParser) ParseBlockClients(graffiti string, extraData string) (clClient string, elClient string) {
+	graffiti = strings.TrimSpace(graffiti)
+
+	// client version graffiti (e.g. "GE1a2bLH3c4d")
+	if match := clientVersionGraffitiPattern.FindStringSubmatch(graffiti); match != nil {
+		for _, code := range match[1:] {
+			client, found := clientVersionCodes[code]
+			if !found {
+				continue
+			}
+			if client.isConsensus && clClient == "" {
+				clClient = client.name
+			} else if !client.isConsensus && elClient == "" {
+				elClient = client.name
+			}
+		}
+	}
+
+	if clClient == "" {
+		clClient = matchClientPatterns(p.clPatterns, graffiti)
+	}
+	if elClient == "" {
+		elClient = matchClientPatterns(p.elPatterns, extraData)
+	}
+	if elClient == "" {
+		elClient = matchClientPatterns(p.elPatterns, graffiti)
+	}
+
+	if clClient == "" {
+		clClient = ClientDiversityUnknown
+	}
+	if elClient == "" {
+		elClient = ClientDiversityUnknown
+	}
+
+	return
+}
+
+type ClientDiversityBucket struct {
+	StartEpoch phase0.Epoch
+	EndEpoch   phase0.Epoch
+	BlockCount uint64
+	ClCounts   map[string]uint64
+	ElCounts   map[string]uint64
+}
+
+type ClientDiversityResult struct {
+	StartEpoch phase0.Epoch
+	EndEpoch   phase0.Epoch
+	BlockCount uint64
+	ClCounts   map[string]uint64
+	ElCounts   map[string]uint64
+	PairCounts map[string]uint64
+	ClClients  []string // sorted by block count
+	ElClients  []string // sorted by block count
+	Buckets    []*ClientDiversityBucket
+	BucketSize uint64
+}
+
+// GetClientDiversity estimates the client diversity of finalized canonical blocks in the given epoch range.
+func (bs *ChainService) GetClientDiversity(startEpoch phase0.Epoch, endEpoch phase0.Epoch, bucketCount uint64) (*ClientDiversityResult, error) {
+	chainState := bs.consensusPool.GetChainState()
+	parser := GetClientDiversityParser()
+
+	if bucketCount == 0 {
+		bucketCount = 1
+	}
+	epochCount := uint64(endEpoch-startEpoch) + 1
+	bucketSize := epochCount / bucketCount
+	if epochCount%bucketCount > 0 {
+		bucketSize++
+	}
+
+	result := &ClientDiversityResult{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		ClCounts:   map[string]uint64{},
+		ElCounts:   map[string]uint64{},
+		PairCounts: map[string]uint64{},
+		Buckets:    []*ClientDiversityBucket{},
+		BucketSize: bucketSize,
+	}
+
+	for bucketStart := uint64(startEpoch); bucketStart <= uint64(endEpoch); bucketStart += bucketSize {
+		result.Buckets = append(result.Buckets, &ClientDiversityBucket{
+			StartEpoch: phase0.Epoch(bucketStart),
+			EndEpoch:   phase0.Epoch(min(bucketStart+bucketSize-1, uint64(endEpoch))),
+			ClCounts:   map[string]uint64{},
+			ElCounts:   map[string]uint64{},
+		})
+	}
+
+	slotInfos, err := db.GetSlotClientInfos(uint64(chainState.EpochToSlot(startEpoch)), uint64(chainState.EpochToSlot(endEpoch+1))-1)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slotInfo := range slotInfos {
+		clClient, elClient := parser.ParseBlockClients(slotInfo.GraffitiText, slotInfo.EthBlockExtraText)
+
+		result.BlockCount++
+		result.ClCounts[clClient]++
+		result.ElCounts[elClient]++
+		result.PairCounts[clClient+"/"+elClient]++
+
+		bucketIdx := uint64(chainState.EpochOfSlot(phase0.Slot(slotInfo.Slot))-startEpoch) / bucketSize
+		if bucketIdx < uint64(len(result.Buckets)) {
+			bucket := result.Buckets[bucketIdx]
+			bucket.BlockCount++
+			bucket.ClCounts[clClient]++
+			bucket.ElCounts[elClient]++
+		}
+	}
+
+	result.ClClients = sortClientsByCount(result.ClCounts)
+	result.ElClients = sortClientsByCount(result.ElCounts)
+
+	return result, nil
+}
+
+func sortClientsByCount(counts map[string]uint64) []string {
+	clients := make([]string, 0, len(counts))
+	for client := range counts {
+		clients = append(clients, client)
+	}
+
+	sort.Slice(clients, func(a, b int) bool {
+		// unknown clients are always sorted last
+		if (clients[a] == ClientDiversityUnknown) != (clients[b] == ClientDiversityUnknown) {
+			return clients[b] == ClientDiversityUnknown
+		}
+		if counts[clients[a]] != counts[clients[b]] {
+			return counts[clients[a]] > counts[clients[b]]
+		}
+		return clients[a] < clients[b]
+	})
+
+	return clients
+}
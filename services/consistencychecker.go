@@ -0,0 +1,383 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/indexer/beacon"
+	"github.com/ethpandaops/dora/utils"
+)
+
+type ConsistencyIssueType string
+
+const (
+	ConsistencyIssueMissingEpoch       ConsistencyIssueType = "missing_epoch"
+	ConsistencyIssueBrokenParentLink   ConsistencyIssueType = "broken_parent_link"
+	ConsistencyIssueDuplicateCanonical ConsistencyIssueType = "duplicate_canonical"
+	ConsistencyIssueAggregateMismatch  ConsistencyIssueType = "aggregate_mismatch"
+	ConsistencyIssueStatusConflict     ConsistencyIssueType = "status_conflict"
+)
+
+// maximum number of issues kept in a report, further issues are only counted
+const consistencyReportMaxIssues = 1000
+
+// ConsistencyIssue is a single inconsistency found in the db.
+type ConsistencyIssue struct {
+	Type    ConsistencyIssueType
+	Epoch   phase0.Epoch
+	Slot    *phase0.Slot
+	Details string
+}
+
+// ConsistencyReport is the result of a consistency check run.
+type ConsistencyReport struct {
+	StartTime      time.Time
+	EndTime        time.Time
+	FirstEpoch     phase0.Epoch
+	LastEpoch      phase0.Epoch
+	Error          string
+	IssueCounts    map[ConsistencyIssueType]uint64
+	Issues         []*ConsistencyIssue
+	AffectedEpochs []phase0.Epoch
+	RepairTime     time.Time
+	RepairError    string
+}
+
+// ConsistencyChecker periodically checks the synchronized epochs & slots in the db for gaps and inconsistencies
+// and schedules a resynchronization of the affected epochs.
+type ConsistencyChecker struct {
+	logger       logrus.FieldLogger
+	chainService *ChainService
+
+	runMutex    sync.Mutex
+	reportMutex sync.Mutex
+	running     bool
+	lastReport  *ConsistencyReport
+}
+
+func newConsistencyChecker(logger logrus.FieldLogger, chainService *ChainService) *ConsistencyChecker {
+	return &ConsistencyChecker{
+		logger:       logger,
+		chainService: chainService,
+	}
+}
+
+func (cc *ConsistencyChecker) startConsistencyChecker() {
+	go cc.runCheckLoop()
+}
+
+func (cc *ConsistencyChecker) runCheckLoop() {
+	defer utils.HandleSubroutinePanic("ConsistencyChecker.runCheckLoop", cc.runCheckLoop)
+
+	interval := utils.Config.ConsistencyChecker.Interval
+	if interval == 0 {
+		interval = 6 * time.Hour
+	}
+
+	for {
+		time.Sleep(interval)
+
+		report := cc.RunCheck()
+		if report != nil && utils.Config.ConsistencyChecker.AutoRepair && len(report.AffectedEpochs) > 0 {
+			cc.RepairAffectedEpochs()
+		}
+	}
+}
+
+// IsRunning returns true if a consistency check is currently running.
+func (cc *ConsistencyChecker) IsRunning() bool {
+	cc.reportMutex.Lock()
+	defer cc.reportMutex.Unlock()
+	return cc.running
+}
+
+// GetReport returns a copy of the report of the last completed consistency check, or nil if no check completed yet.
+func (cc *ConsistencyChecker) GetReport() *ConsistencyReport {
+	cc.reportMutex.Lock()
+	defer cc.reportMutex.Unlock()
+	if cc.lastReport == nil {
+		return nil
+	}
+
+	report := *cc.lastReport
+	return &report
+}
+
+// TriggerCheck starts a consistency check in background, unless a check is already running.
+func (cc *ConsistencyChecker) TriggerCheck() {
+	go func() {
+		defer utils.HandleSubroutinePanic("ConsistencyChecker.TriggerCheck", nil)
+		cc.RunCheck()
+	}()
+}
+
+// RunCheck checks all synchronized epochs in the db and returns the new report.
+// Returns nil if another check is already running.
+func (cc *ConsistencyChecker) RunCheck() *ConsistencyReport {
+	if !cc.runMutex.TryLock() {
+		return nil
+	}
+	defer cc.runMutex.Unlock()
+
+	cc.reportMutex.Lock()
+	cc.running = true
+	cc.reportMutex.Unlock()
+
+	report := &ConsistencyReport{
+		StartTime:   time.Now(),
+		IssueCounts: map[ConsistencyIssueType]uint64{},
+		Issues:      []*ConsistencyIssue{},
+	}
+
+	err := cc.checkConsistency(report)
+	if err != nil {
+		report.Error = err.Error()
+		cc.logger.Errorf("consistency check failed: %v", err)
+	}
+	report.EndTime = time.Now()
+
+	issueCount := uint64(0)
+	for _, count := range report.IssueCounts {
+		issueCount += count
+	}
+	if issueCount > 0 {
+		cc.logger.Warnf("consistency check found %v issues in %v epochs (epoch %v - %v)", issueCount, len(report.AffectedEpochs), report.FirstEpoch, report.LastEpoch)
+	} else if err == nil {
+		cc.logger.Infof("consistency check found no issues (epoch %v - %v)", report.FirstEpoch, report.LastEpoch)
+	}
+
+	cc.reportMutex.Lock()
+	cc.running = false
+	cc.lastReport = report
+	cc.reportMutex.Unlock()
+
+	return report
+}
+
+// RepairAffectedEpochs schedules a resynchronization of all affected epochs from the last report.
+func (cc *ConsistencyChecker) RepairAffectedEpochs() (int, error) {
+	cc.reportMutex.Lock()
+	report := cc.lastReport
+	cc.reportMutex.Unlock()
+
+	if report == nil || len(report.AffectedEpochs) == 0 {
+		return 0, nil
+	}
+
+	err := cc.chainService.beaconIndexer.ResyncEpochs(report.AffectedEpochs)
+
+	cc.reportMutex.Lock()
+	report.RepairTime = time.Now()
+	if err != nil {
+		report.RepairError = err.Error()
+	} else {
+		report.RepairError = ""
+	}
+	cc.reportMutex.Unlock()
+
+	if err != nil {
+		return 0, err
+	}
+
+	cc.logger.Infof("scheduled resynchronization of %v epochs", len(report.AffectedEpochs))
+	return len(report.AffectedEpochs), nil
+}
+
+func (cc *ConsistencyChecker) checkConsistency(report *ConsistencyReport) error {
+	beaconIndexer := cc.chainService.beaconIndexer
+	chainState := cc.chainService.consensusPool.GetChainState()
+	specs := chainState.GetSpecs()
+	if specs == nil {
+		return fmt.Errorf("chain specs not loaded")
+	}
+
+	lowestEpoch, found, err := db.GetLowestEpoch()
+	if err != nil {
+		return fmt.Errorf("error loading lowest epoch: %v", err)
+	}
+	if !found {
+		return nil
+	}
+
+	// only check epochs that have been finalized and passed by the synchronizer
+	headEpoch, _ := beaconIndexer.GetBlockCacheState()
+	if _, syncHead := beaconIndexer.GetSynchronizerState(); syncHead < headEpoch {
+		headEpoch = syncHead
+	}
+	if headEpoch == 0 || phase0.Epoch(lowestEpoch) >= headEpoch {
+		return nil
+	}
+
+	report.FirstEpoch = phase0.Epoch(lowestEpoch)
+	report.LastEpoch = headEpoch - 1
+
+	batchEpochs := utils.Config.ConsistencyChecker.BatchEpochs
+	if batchEpochs == 0 {
+		batchEpochs = 1000
+	}
+
+	// epochs in gaps of the recent-first synchronization are not synchronized yet
+	syncGaps := beaconIndexer.GetSyncGaps()
+
+	affectedEpochs := map[phase0.Epoch]bool{}
+	addIssue := func(issueType ConsistencyIssueType, epoch phase0.Epoch, slot *phase0.Slot, details string) {
+		for _, syncGap := range syncGaps {
+			if epoch >= syncGap.FromEpoch && epoch < syncGap.ToEpoch {
+				return
+			}
+		}
+
+		report.IssueCounts[issueType]++
+		affectedEpochs[epoch] = true
+		if len(report.Issues) < consistencyReportMaxIssues {
+			report.Issues = append(report.Issues, &ConsistencyIssue{
+				Type:    issueType,
+				Epoch:   epoch,
+				Slot:    slot,
+				Details: details,
+			})
+		}
+	}
+
+	var lastCanonical *dbtypes.SlotLinkInfo
+	for batchStart := report.FirstEpoch; batchStart <= report.LastEpoch; batchStart += phase0.Epoch(batchEpochs) {
+		batchEnd := batchStart + phase0.Epoch(batchEpochs) - 1
+		if batchEnd > report.LastEpoch {
+			batchEnd = report.LastEpoch
+		}
+
+		firstSlot := uint64(chainState.EpochStartSlot(batchStart))
+		lastSlot := uint64(chainState.EpochStartSlot(batchEnd+1)) - 1
+
+		// missing epochs & aggregate mismatches
+		epochAggregates, err := db.GetEpochAggregatesRange(uint64(batchStart), uint64(batchEnd))
+		if err != nil {
+			return fmt.Errorf("error loading epochs %v - %v: %v", batchStart, batchEnd, err)
+		}
+		slotAggregates, err := db.GetCanonicalSlotAggregates(firstSlot, lastSlot, specs.SlotsPerEpoch)
+		if err != nil {
+			return fmt.Errorf("error loading slot aggregates %v - %v: %v", firstSlot, lastSlot, err)
+		}
+
+		slotAggregateMap := make(map[uint64]*dbtypes.EpochBlockAggregate, len(slotAggregates))
+		for _, slotAggregate := range slotAggregates {
+			slotAggregateMap[slotAggregate.Epoch] = slotAggregate
+		}
+
+		epochIdx := 0
+		for epoch := batchStart; epoch <= batchEnd; epoch++ {
+			if epochIdx >= len(epochAggregates) || epochAggregates[epochIdx].Epoch != uint64(epoch) {
+				addIssue(ConsistencyIssueMissingEpoch, epoch, nil, "epoch not found in db")
+				continue
+			}
+
+			epochAggregate := epochAggregates[epochIdx]
+			epochIdx++
+
+			slotAggregate := slotAggregateMap[uint64(epoch)]
+			if slotAggregate == nil {
+				slotAggregate = &dbtypes.EpochBlockAggregate{Epoch: uint64(epoch)}
+			}
+			if mismatch := getEpochAggregateMismatch(epochAggregate, slotAggregate); mismatch != "" {
+				addIssue(ConsistencyIssueAggregateMismatch, epoch, nil, mismatch)
+			}
+		}
+
+		// canonical chain links
+		slotLinks, err := db.GetCanonicalSlotLinks(firstSlot, lastSlot)
+		if err != nil {
+			return fmt.Errorf("error loading canonical slots %v - %v: %v", firstSlot, lastSlot, err)
+		}
+
+		for _, slotLink := range slotLinks {
+			slot := phase0.Slot(slotLink.Slot)
+			epoch := chainState.EpochOfSlot(slot)
+
+			if lastCanonical != nil {
+				switch {
+				case lastCanonical.Slot == slotLink.Slot:
+					addIssue(ConsistencyIssueDuplicateCanonical, epoch, &slot, fmt.Sprintf("multiple canonical blocks: 0x%x, 0x%x", lastCanonical.Root, slotLink.Root))
+				case !bytes.Equal(lastCanonical.Root, slotLink.ParentRoot) && !isGapBetween(syncGaps, chainState.EpochOfSlot(phase0.Slot(lastCanonical.Slot)), epoch):
+					addIssue(ConsistencyIssueBrokenParentLink, epoch, &slot, fmt.Sprintf("parent root 0x%x does not match previous canonical block 0x%x (slot %v)", slotLink.ParentRoot, lastCanonical.Root, lastCanonical.Slot))
+				}
+			}
+
+			lastCanonical = slotLink
+		}
+
+		// orphaned / canonical status conflicts
+		orphanedCanonicals, err := db.GetCanonicalSlotsWithOrphanedBlock(firstSlot, lastSlot)
+		if err != nil {
+			return fmt.Errorf("error loading status conflicts %v - %v: %v", firstSlot, lastSlot, err)
+		}
+		for _, slotLink := range orphanedCanonicals {
+			slot := phase0.Slot(slotLink.Slot)
+			addIssue(ConsistencyIssueStatusConflict, chainState.EpochOfSlot(slot), &slot, fmt.Sprintf("canonical block 0x%x has an orphaned block entry", slotLink.Root))
+		}
+
+		canonicalParents, err := db.GetOrphanedSlotsWithCanonicalChild(firstSlot, lastSlot)
+		if err != nil {
+			return fmt.Errorf("error loading status conflicts %v - %v: %v", firstSlot, lastSlot, err)
+		}
+		for _, slotLink := range canonicalParents {
+			slot := phase0.Slot(slotLink.Slot)
+			addIssue(ConsistencyIssueStatusConflict, chainState.EpochOfSlot(slot), &slot, fmt.Sprintf("orphaned block 0x%x is the parent of a canonical block", slotLink.Root))
+		}
+	}
+
+	report.AffectedEpochs = make([]phase0.Epoch, 0, len(affectedEpochs))
+	for epoch := range affectedEpochs {
+		report.AffectedEpochs = append(report.AffectedEpochs, epoch)
+	}
+	sort.Slice(report.AffectedEpochs, func(a, b int) bool {
+		return report.AffectedEpochs[a] < report.AffectedEpochs[b]
+	})
+
+	return nil
+}
+
+// isGapBetween checks if a gap of the recent-first synchronization lies between the two epochs.
+func isGapBetween(syncGaps []beacon.SyncGap, fromEpoch phase0.Epoch, toEpoch phase0.Epoch) bool {
+	for _, syncGap := range syncGaps {
+		if syncGap.ToEpoch > fromEpoch && syncGap.FromEpoch <= toEpoch {
+			return true
+		}
+	}
+	return false
+}
+
+// getEpochAggregateMismatch compares the epoch aggregations with the sums of its canonical blocks and describes the first difference.
+func getEpochAggregateMismatch(epoch *dbtypes.EpochBlockAggregate, blocks *dbtypes.EpochBlockAggregate) string {
+	fields := []struct {
+		name   string
+		epoch  uint64
+		blocks uint64
+	}{
+		{"block count", epoch.BlockCount, blocks.BlockCount},
+		{"attestation count", epoch.AttestationCount, blocks.AttestationCount},
+		{"deposit count", epoch.DepositCount, blocks.DepositCount},
+		{"exit count", epoch.ExitCount, blocks.ExitCount},
+		{"withdrawal count", epoch.WithdrawCount, blocks.WithdrawCount},
+		{"withdrawal amount", epoch.WithdrawAmount, blocks.WithdrawAmount},
+		{"attester slashing count", epoch.AttesterSlashingCount, blocks.AttesterSlashingCount},
+		{"proposer slashing count", epoch.ProposerSlashingCount, blocks.ProposerSlashingCount},
+		{"bls change count", epoch.BLSChangeCount, blocks.BLSChangeCount},
+		{"transaction count", epoch.EthTransactionCount, blocks.EthTransactionCount},
+	}
+
+	for _, field := range fields {
+		if field.epoch != field.blocks {
+			return fmt.Sprintf("%v: epoch %v, blocks %v", field.name, field.epoch, field.blocks)
+		}
+	}
+
+	return ""
+}
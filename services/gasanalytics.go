@@ -0,0 +1,193 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+// number of slots before the requested range that are checked for the parent gas limit of the first block
+const gasAnalyticsParentLookback = 64
+
+type GasAnalyticsBucket struct {
+	StartEpoch   phase0.Epoch
+	EndEpoch     phase0.Epoch
+	BlockCount   uint64
+	GasUsed      uint64
+	GasLimit     uint64
+	BaseFeeSum   float64 // wei, sum over all blocks with known base fee
+	BaseFeeCount uint64
+	LastGasLimit uint64
+}
+
+// GasLimitVoter holds the gas limit votes of a validator (unnamed validators) or a group of validators with the same name.
+type GasLimitVoter struct {
+	Key            string
+	Name           string
+	ValidatorIndex uint64 // last proposer, only meaningful for unnamed validators
+	BlockCount     uint64
+	UpCount        uint64
+	DownCount      uint64
+	SameCount      uint64
+	LastSlot       phase0.Slot
+	LastGasLimit   uint64
+}
+
+type GasAnalyticsResult struct {
+	StartEpoch      phase0.Epoch
+	EndEpoch        phase0.Epoch
+	BlockCount      uint64
+	GasUsed         uint64
+	GasLimit        uint64
+	FirstGasLimit   uint64
+	LastGasLimit    uint64
+	UpCount         uint64
+	DownCount       uint64
+	SameCount       uint64
+	Buckets         []*GasAnalyticsBucket // in epoch order
+	BucketSize      uint64
+	Voters          []*GasLimitVoter // sorted by number of up & down votes
+	ChangingVoters  uint64           // number of voters that moved the gas limit at least once
+	UnchangedVoters uint64
+}
+
+// GetGasAnalytics aggregates the gas usage, base fee & gas limit votes of finalized canonical blocks in the given epoch range.
+// A proposer votes for a gas limit change by moving the limit of its block up or down compared to the parent block.
+func (bs *ChainService) GetGasAnalytics(startEpoch phase0.Epoch, endEpoch phase0.Epoch, bucketCount uint64) (*GasAnalyticsResult, error) {
+	chainState := bs.consensusPool.GetChainState()
+
+	if bucketCount == 0 {
+		bucketCount = 1
+	}
+	epochCount := uint64(endEpoch-startEpoch) + 1
+	bucketSize := epochCount / bucketCount
+	if epochCount%bucketCount > 0 {
+		bucketSize++
+	}
+
+	result := &GasAnalyticsResult{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		Buckets:    []*GasAnalyticsBucket{},
+		BucketSize: bucketSize,
+		Voters:     []*GasLimitVoter{},
+	}
+
+	for bucketStart := uint64(startEpoch); bucketStart <= uint64(endEpoch); bucketStart += bucketSize {
+		result.Buckets = append(result.Buckets, &GasAnalyticsBucket{
+			StartEpoch: phase0.Epoch(bucketStart),
+			EndEpoch:   phase0.Epoch(min(bucketStart+bucketSize-1, uint64(endEpoch))),
+		})
+	}
+
+	firstSlot := uint64(chainState.EpochToSlot(startEpoch))
+	lastSlot := uint64(chainState.EpochToSlot(endEpoch+1)) - 1
+	queryFirstSlot := uint64(0)
+	if firstSlot > gasAnalyticsParentLookback {
+		queryFirstSlot = firstSlot - gasAnalyticsParentLookback
+	}
+
+	gasInfos, err := db.GetSlotGasInfos(queryFirstSlot, lastSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	voterMap := map[string]*GasLimitVoter{}
+	parentGasLimit := uint64(0)
+
+	for _, gasInfo := range gasInfos {
+		gasLimit := *gasInfo.EthGasLimit
+		if gasInfo.Slot < firstSlot {
+			parentGasLimit = gasLimit
+			continue
+		}
+
+		gasUsed := uint64(0)
+		if gasInfo.EthGasUsed != nil {
+			gasUsed = *gasInfo.EthGasUsed
+		}
+
+		if result.BlockCount == 0 {
+			result.FirstGasLimit = gasLimit
+		}
+		result.BlockCount++
+		result.GasUsed += gasUsed
+		result.GasLimit += gasLimit
+		result.LastGasLimit = gasLimit
+
+		bucketIdx := uint64(chainState.EpochOfSlot(phase0.Slot(gasInfo.Slot))-startEpoch) / bucketSize
+		if bucketIdx < uint64(len(result.Buckets)) {
+			bucket := result.Buckets[bucketIdx]
+			bucket.BlockCount++
+			bucket.GasUsed += gasUsed
+			bucket.GasLimit += gasLimit
+			bucket.LastGasLimit = gasLimit
+			if gasInfo.EthBaseFee != nil {
+				bucket.BaseFeeCount++
+				bucket.BaseFeeSum += float64(*gasInfo.EthBaseFee)
+			}
+		}
+
+		if parentGasLimit > 0 {
+			name := bs.validatorNames.GetValidatorName(gasInfo.Proposer)
+			voterKey := name
+			if voterKey == "" {
+				voterKey = fmt.Sprintf("#%v", gasInfo.Proposer)
+			}
+
+			voter := voterMap[voterKey]
+			if voter == nil {
+				voter = &GasLimitVoter{
+					Key:  voterKey,
+					Name: name,
+				}
+				voterMap[voterKey] = voter
+				result.Voters = append(result.Voters, voter)
+			}
+
+			voter.BlockCount++
+			voter.ValidatorIndex = gasInfo.Proposer
+			voter.LastSlot = phase0.Slot(gasInfo.Slot)
+			voter.LastGasLimit = gasLimit
+
+			switch {
+			case gasLimit > parentGasLimit:
+				voter.UpCount++
+				result.UpCount++
+			case gasLimit < parentGasLimit:
+				voter.DownCount++
+				result.DownCount++
+			default:
+				voter.SameCount++
+				result.SameCount++
+			}
+		}
+
+		parentGasLimit = gasLimit
+	}
+
+	for _, voter := range result.Voters {
+		if voter.UpCount > 0 || voter.DownCount > 0 {
+			result.ChangingVoters++
+		} else {
+			result.UnchangedVoters++
+		}
+	}
+
+	sort.Slice(result.Voters, func(a, b int) bool {
+		changesA := result.Voters[a].UpCount + result.Voters[a].DownCount
+		changesB := result.Voters[b].UpCount + result.Voters[b].DownCount
+		if changesA != changesB {
+			return changesA > changesB
+		}
+		if result.Voters[a].BlockCount != result.Voters[b].BlockCount {
+			return result.Voters[a].BlockCount > result.Voters[b].BlockCount
+		}
+		return result.Voters[a].Key < result.Voters[b].Key
+	})
+
+	return result, nil
+}
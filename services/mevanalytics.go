@@ -0,0 +1,168 @@
+package services
+
+import (
+	"sort"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// MevAnalyticsResult holds the aggregated builder & relay statistics of the canonical payloads within an epoch range.
+type MevAnalyticsResult struct {
+	StartEpoch phase0.Epoch
+	EndEpoch   phase0.Epoch
+
+	PayloadCount    uint64 // canonical blocks with execution payload
+	MevBlockCount   uint64 // payloads delivered by any of the tracked relays
+	LocalBlockCount uint64 // payloads without relay match (locally built)
+	MevValue        uint64 // total value of mev payloads in gwei
+
+	Builders   []*MevBuilderStats // sorted by block count
+	Relays     []*MevRelayStats   // in order of relay config
+	BucketSize uint64
+	Buckets    []*MevAnalyticsBucket
+}
+
+type MevBuilderStats struct {
+	Pubkey     []byte
+	BlockCount uint64
+	TotalValue uint64 // gwei
+	MaxValue   uint64 // gwei
+	LastSlot   phase0.Slot
+	RelayCount map[uint8]uint64
+}
+
+type MevRelayStats struct {
+	Index          uint8
+	Name           string
+	BlockCount     uint64 // payloads delivered by this relay
+	ExclusiveCount uint64 // payloads delivered by this relay only
+	TotalValue     uint64 // gwei
+	BuilderCount   uint64 // distinct builders
+}
+
+type MevAnalyticsBucket struct {
+	StartEpoch    phase0.Epoch
+	EndEpoch      phase0.Epoch
+	PayloadCount  uint64
+	MevBlockCount uint64
+	MevValue      uint64
+}
+
+// GetMevAnalytics aggregates builder & relay statistics for the finalized canonical payloads in the given epoch range.
+// A payload is considered locally built when none of the tracked relays reported it as delivered.
+func (bs *ChainService) GetMevAnalytics(startEpoch phase0.Epoch, endEpoch phase0.Epoch, bucketCount uint64) (*MevAnalyticsResult, error) {
+	chainState := bs.consensusPool.GetChainState()
+
+	if bucketCount == 0 {
+		bucketCount = 1
+	}
+	epochCount := uint64(endEpoch-startEpoch) + 1
+	bucketSize := epochCount / bucketCount
+	if epochCount%bucketCount > 0 {
+		bucketSize++
+	}
+
+	result := &MevAnalyticsResult{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		Builders:   []*MevBuilderStats{},
+		Relays:     []*MevRelayStats{},
+		BucketSize: bucketSize,
+		Buckets:    []*MevAnalyticsBucket{},
+	}
+
+	for bucketStart := uint64(startEpoch); bucketStart <= uint64(endEpoch); bucketStart += bucketSize {
+		result.Buckets = append(result.Buckets, &MevAnalyticsBucket{
+			StartEpoch: phase0.Epoch(bucketStart),
+			EndEpoch:   phase0.Epoch(min(bucketStart+bucketSize-1, uint64(endEpoch))),
+		})
+	}
+
+	relayStats := map[uint8]*MevRelayStats{}
+	relayBuilders := map[uint8]map[string]bool{}
+	for _, relay := range utils.Config.MevIndexer.Relays {
+		stats := &MevRelayStats{
+			Index: relay.Index,
+			Name:  relay.Name,
+		}
+		relayStats[relay.Index] = stats
+		relayBuilders[relay.Index] = map[string]bool{}
+		result.Relays = append(result.Relays, stats)
+	}
+
+	payloadInfos, err := db.GetCanonicalPayloadMevInfos(uint64(chainState.EpochToSlot(startEpoch)), uint64(chainState.EpochToSlot(endEpoch+1))-1)
+	if err != nil {
+		return nil, err
+	}
+
+	builderStats := map[string]*MevBuilderStats{}
+	for _, payloadInfo := range payloadInfos {
+		var bucket *MevAnalyticsBucket
+		bucketIdx := uint64(chainState.EpochOfSlot(phase0.Slot(payloadInfo.Slot))-startEpoch) / bucketSize
+		if bucketIdx < uint64(len(result.Buckets)) {
+			bucket = result.Buckets[bucketIdx]
+			bucket.PayloadCount++
+		}
+
+		result.PayloadCount++
+		if payloadInfo.BuilderPubkey == nil {
+			result.LocalBlockCount++
+			continue
+		}
+
+		result.MevBlockCount++
+		result.MevValue += payloadInfo.BlockValueGwei
+		if bucket != nil {
+			bucket.MevBlockCount++
+			bucket.MevValue += payloadInfo.BlockValueGwei
+		}
+
+		builderKey := string(payloadInfo.BuilderPubkey)
+		builder := builderStats[builderKey]
+		if builder == nil {
+			builder = &MevBuilderStats{
+				Pubkey:     payloadInfo.BuilderPubkey,
+				RelayCount: map[uint8]uint64{},
+			}
+			builderStats[builderKey] = builder
+		}
+		builder.BlockCount++
+		builder.TotalValue += payloadInfo.BlockValueGwei
+		builder.MaxValue = max(builder.MaxValue, payloadInfo.BlockValueGwei)
+		builder.LastSlot = max(builder.LastSlot, phase0.Slot(payloadInfo.Slot))
+
+		for relayIdx, relay := range relayStats {
+			relayFlag := uint64(1) << uint64(relayIdx)
+			if payloadInfo.SeenbyRelays&relayFlag == 0 {
+				continue
+			}
+
+			relay.BlockCount++
+			relay.TotalValue += payloadInfo.BlockValueGwei
+			if payloadInfo.SeenbyRelays == relayFlag {
+				relay.ExclusiveCount++
+			}
+			relayBuilders[relayIdx][builderKey] = true
+			builder.RelayCount[relayIdx]++
+		}
+	}
+
+	for relayIdx, relay := range relayStats {
+		relay.BuilderCount = uint64(len(relayBuilders[relayIdx]))
+	}
+
+	for _, builder := range builderStats {
+		result.Builders = append(result.Builders, builder)
+	}
+	sort.Slice(result.Builders, func(a, b int) bool {
+		if result.Builders[a].BlockCount != result.Builders[b].BlockCount {
+			return result.Builders[a].BlockCount > result.Builders[b].BlockCount
+		}
+		return result.Builders[a].TotalValue > result.Builders[b].TotalValue
+	})
+
+	return result, nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"bytes"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+	"github.com/ethpandaops/dora/dbtypes"
+	"github.com/ethpandaops/dora/utils"
+)
+
+// MevRelayFaultType is the type of a relay delivery fault.
+type MevRelayFaultType uint8
+
+const (
+	MevRelayFaultMissedSlot MevRelayFaultType = iota + 1
+	MevRelayFaultReplaced
+	MevRelayFaultOrphaned
+	MevRelayFaultFeeRecipient
+)
+
+var mevRelayFaultTypeNames = map[MevRelayFaultType]string{
+	MevRelayFaultMissedSlot:   "Missed Slot",
+	MevRelayFaultReplaced:     "Replaced",
+	MevRelayFaultOrphaned:     "Orphaned",
+	MevRelayFaultFeeRecipient: "Fee Recipient Mismatch",
+}
+
+func (faultType MevRelayFaultType) String() string {
+	return mevRelayFaultTypeNames[faultType]
+}
+
+// MevRelayFaultReport holds the delivery faults of all tracked relays within an epoch range.
+type MevRelayFaultReport struct {
+	StartEpoch phase0.Epoch
+	EndEpoch   phase0.Epoch
+	Relays     []*MevRelayFaultStats // in order of relay config
+	Faults     []*MevRelayFault      // newest first
+}
+
+type MevRelayFaultStats struct {
+	Index              uint8
+	Name               string
+	DeliveredCount     uint64
+	MissedSlotCount    uint64 // delivered, but the slot has no canonical block
+	ReplacedCount      uint64 // delivered, but a different payload got proposed
+	OrphanedCount      uint64 // delivered, but the block got orphaned
+	FeeRecipientCount  uint64 // delivered & proposed, but the fee recipient does not match
+	FeeRecipientChecks uint64 // proposed payloads with known fee recipient
+}
+
+type MevRelayFault struct {
+	Slot                phase0.Slot
+	BlockHash           []byte
+	Proposer            phase0.ValidatorIndex
+	SeenbyRelays        uint64
+	Type                MevRelayFaultType
+	Value               uint64 // gwei
+	FeeRecipient        []byte // fee recipient reported by the relay
+	PayloadFeeRecipient []byte // fee recipient of the proposed payload
+}
+
+// GetMevRelayFaults compares the payloads delivered by the tracked relays with the finalized chain.
+func (bs *ChainService) GetMevRelayFaults(startEpoch phase0.Epoch, endEpoch phase0.Epoch) (*MevRelayFaultReport, error) {
+	chainState := bs.consensusPool.GetChainState()
+
+	report := &MevRelayFaultReport{
+		StartEpoch: startEpoch,
+		EndEpoch:   endEpoch,
+		Relays:     []*MevRelayFaultStats{},
+		Faults:     []*MevRelayFault{},
+	}
+
+	relayStats := map[uint8]*MevRelayFaultStats{}
+	for _, relay := range utils.Config.MevIndexer.Relays {
+		stats := &MevRelayFaultStats{
+			Index: relay.Index,
+			Name:  relay.Name,
+		}
+		relayStats[relay.Index] = stats
+		report.Relays = append(report.Relays, stats)
+	}
+
+	payloadInfos, err := db.GetMevBlockPayloadInfos(uint64(chainState.EpochToSlot(startEpoch)), uint64(chainState.EpochToSlot(endEpoch+1))-1)
+	if err != nil {
+		return nil, err
+	}
+
+	// a payload might be included in multiple blocks (canonical & orphaned), prefer the canonical one
+	payloadMap := map[string]*dbtypes.MevBlockPayloadInfo{}
+	payloadList := []*dbtypes.MevBlockPayloadInfo{}
+	for _, payloadInfo := range payloadInfos {
+		if existing := payloadMap[string(payloadInfo.BlockHash)]; existing != nil {
+			if payloadInfo.PayloadStatus == dbtypes.Canonical {
+				*existing = *payloadInfo
+			}
+			continue
+		}
+		payloadMap[string(payloadInfo.BlockHash)] = payloadInfo
+		payloadList = append(payloadList, payloadInfo)
+	}
+
+	for _, payloadInfo := range payloadList {
+		var faultType MevRelayFaultType
+		checkedFeeRecipient := false
+
+		switch payloadInfo.PayloadStatus {
+		case dbtypes.Canonical:
+			if payloadInfo.EthFeeRecipient != nil {
+				checkedFeeRecipient = true
+				if !bytes.Equal(payloadInfo.FeeRecipient, payloadInfo.EthFeeRecipient) && !bytes.Equal(payloadInfo.FeeRecipient, payloadInfo.EthPaymentRecipient) {
+					faultType = MevRelayFaultFeeRecipient
+				}
+			}
+		case dbtypes.Orphaned:
+			faultType = MevRelayFaultOrphaned
+		default:
+			if payloadInfo.CanonicalCount > 0 {
+				faultType = MevRelayFaultReplaced
+			} else {
+				faultType = MevRelayFaultMissedSlot
+			}
+		}
+
+		for relayIdx, stats := range relayStats {
+			if payloadInfo.SeenbyRelays&(uint64(1)<<uint64(relayIdx)) == 0 {
+				continue
+			}
+
+			stats.DeliveredCount++
+			if checkedFeeRecipient {
+				stats.FeeRecipientChecks++
+			}
+
+			switch faultType {
+			case MevRelayFaultMissedSlot:
+				stats.MissedSlotCount++
+			case MevRelayFaultReplaced:
+				stats.ReplacedCount++
+			case MevRelayFaultOrphaned:
+				stats.OrphanedCount++
+			case MevRelayFaultFeeRecipient:
+				stats.FeeRecipientCount++
+			}
+		}
+
+		if faultType != 0 {
+			report.Faults = append(report.Faults, &MevRelayFault{
+				Slot:                phase0.Slot(payloadInfo.SlotNumber),
+				BlockHash:           payloadInfo.BlockHash,
+				Proposer:            phase0.ValidatorIndex(payloadInfo.ProposerIndex),
+				SeenbyRelays:        payloadInfo.SeenbyRelays,
+				Type:                faultType,
+				Value:               payloadInfo.BlockValueGwei,
+				FeeRecipient:        payloadInfo.FeeRecipient,
+				PayloadFeeRecipient: payloadInfo.EthFeeRecipient,
+			})
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"time"
+
+	"github.com/attestantio/go-eth2-client/spec/phase0"
+
+	"github.com/ethpandaops/dora/db"
+)
+
+// MissedSlotCause is the estimated root cause of a missed slot.
+type MissedSlotCause uint8
+
+const (
+	MissedSlotCauseUnknown MissedSlotCause = iota
+	MissedSlotCauseProposerOffline
+	MissedSlotCauseRelayFailure
+	MissedSlotCauseLateBlock
+	MissedSlotCauseOrphanedBlock
+)
+
+var missedSlotCauseNames = map[MissedSlotCause]string{
+	MissedSlotCauseUnknown:         "Unknown",
+	MissedSlotCauseProposerOffline: "Proposer Offline",
+	MissedSlotCauseRelayFailure:    "Relay/Builder Failure",
+	MissedSlotCauseLateBlock:       "Late Block",
+	MissedSlotCauseOrphanedBlock:   "Orphaned Block",
+}
+
+var missedSlotCauseDescriptions = map[MissedSlotCause]string{
+	MissedSlotCauseUnknown:         "No indication for the cause of the missed slot was found",
+	MissedSlotCauseProposerOffline: "The proposer did not attest around the missed slot",
+	MissedSlotCauseRelayFailure:    "A relay delivered a payload for this slot, but the block was never seen",
+	MissedSlotCauseLateBlock:       "A block was proposed for this slot, but it was received after the attestation deadline and got orphaned",
+	MissedSlotCauseOrphanedBlock:   "A block was proposed for this slot, but it got orphaned (received in time or receive time unknown)",
+}
+
+func (cause MissedSlotCause) String() string {
+	return missedSlotCauseNames[cause]
+}
+
+// Description returns a short explanation of the missed slot cause.
+func (cause MissedSlotCause) Description() string {
+	return missedSlotCauseDescriptions[cause]
+}
+
+// MissedSlotRef references a missed slot and its assigned proposer.
+type MissedSlotRef struct {
+	Slot     phase0.Slot
+	Proposer phase0.ValidatorIndex
+}
+
+// getLateBlockThreshold returns the delay after the slot start after which a block is considered late.
+// This is the attestation deadline (1/3 of the slot), as blocks received later are usually not attested by the slot committee.
+func getLateBlockThreshold(secondsPerSlot time.Duration) time.Duration {
+	return secondsPerSlot / 3
+}
+
+// classifyOrphanedMissedSlot classifies a missed slot with an orphaned block by comparing the earliest receive time
+// of the orphaned block with the slot start time. A zero receive time means the receive time is unknown.
+func classifyOrphanedMissedSlot(slotTime time.Time, recvTime time.Time, lateThreshold time.Duration) MissedSlotCause {
+	if !recvTime.IsZero() && recvTime.Sub(slotTime) > lateThreshold {
+		return MissedSlotCauseLateBlock
+	}
+	return MissedSlotCauseOrphanedBlock
+}
+
+// ClassifyMissedSlots estimates the root cause for each of the given missed slots.
+// The classification combines orphaned blocks (and their receive times), relay payload deliveries and the recent attestation activity of the proposer.
+func (bs *ChainService) ClassifyMissedSlots(missedSlots []MissedSlotRef) map[phase0.Slot]MissedSlotCause {
+	causes := make(map[phase0.Slot]MissedSlotCause, len(missedSlots))
+	if len(missedSlots) == 0 {
+		return causes
+	}
+
+	chainState := bs.consensusPool.GetChainState()
+	currentEpoch := chainState.CurrentEpoch()
+	finalizedEpoch, _ := bs.beaconIndexer.GetBlockCacheState()
+	finalizedSlot := chainState.EpochToSlot(finalizedEpoch)
+
+	minSlot, maxSlot := missedSlots[0].Slot, missedSlots[0].Slot
+	for _, missedSlot := range missedSlots {
+		minSlot = min(minSlot, missedSlot.Slot)
+		maxSlot = max(maxSlot, missedSlot.Slot)
+	}
+
+	// slots with an orphaned block (db & cache) with the earliest known receive time of the orphaned blocks
+	orphanedSlots := map[phase0.Slot]time.Time{}
+	if recvTimes, err := db.GetOrphanedSlotRecvTimes(uint64(minSlot), uint64(maxSlot)); err == nil {
+		for slot, recvTs := range recvTimes {
+			recvTime := time.Time{}
+			if recvTs > 0 {
+				recvTime = time.UnixMilli(recvTs)
+			}
+			orphanedSlots[phase0.Slot(slot)] = recvTime
+		}
+	}
+	lateThreshold := getLateBlockThreshold(chainState.GetSpecs().SecondsPerSlot)
+
+	// slots with a payload delivered by any relay
+	mevSlots := map[phase0.Slot]bool{}
+	if slotNumbers, err := db.GetMevBlockSlotNumbers(uint64(minSlot), uint64(maxSlot)); err == nil {
+		for _, slot := range slotNumbers {
+			mevSlots[phase0.Slot(slot)] = true
+		}
+	}
+
+	_, oldestActivityEpoch := bs.beaconIndexer.GetValidatorActivity(0)
+
+	for _, missedSlot := range missedSlots {
+		if missedSlot.Slot >= finalizedSlot {
+			for _, block := range bs.beaconIndexer.GetBlocksBySlot(missedSlot.Slot) {
+				recvTime, isOrphaned := orphanedSlots[missedSlot.Slot]
+				blockRecvTime := block.GetRecvTime()
+				if !isOrphaned || recvTime.IsZero() || (!blockRecvTime.IsZero() && blockRecvTime.Before(recvTime)) {
+					orphanedSlots[missedSlot.Slot] = blockRecvTime
+				}
+			}
+		}
+
+		cause := MissedSlotCauseUnknown
+		orphanedRecvTime, isOrphaned := orphanedSlots[missedSlot.Slot]
+		switch {
+		case isOrphaned:
+			cause = classifyOrphanedMissedSlot(chainState.SlotToTime(missedSlot.Slot), orphanedRecvTime, lateThreshold)
+		case mevSlots[missedSlot.Slot]:
+			cause = MissedSlotCauseRelayFailure
+		default:
+			// check proposer liveness (attestations in the epoch before, the epoch of and the epoch after the missed slot)
+			slotEpoch := chainState.EpochOfSlot(missedSlot.Slot)
+			checkStartEpoch := slotEpoch
+			if checkStartEpoch > 0 {
+				checkStartEpoch--
+			}
+			checkEndEpoch := min(slotEpoch+1, currentEpoch)
+			if checkStartEpoch < oldestActivityEpoch || checkEndEpoch+1 > currentEpoch {
+				// activity for these epochs is not (or not completely) available
+				break
+			}
+
+			hasVoted := false
+			activity, _ := bs.beaconIndexer.GetValidatorActivity(missedSlot.Proposer)
+			for _, vote := range activity {
+				dutyEpoch := chainState.EpochOfSlot(vote.VoteBlock.Slot - phase0.Slot(vote.VoteDelay))
+				if dutyEpoch >= checkStartEpoch && dutyEpoch <= checkEndEpoch {
+					hasVoted = true
+					break
+				}
+			}
+
+			if !hasVoted {
+				cause = MissedSlotCauseProposerOffline
+			}
+		}
+
+		causes[missedSlot.Slot] = cause
+	}
+
+	return causes
+}
@@ -0,0 +1,46 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClassifyOrphanedMissedSlot(t *testing.T) {
+	slotTime := time.Unix(1700000000, 0)
+	lateThreshold := getLateBlockThreshold(12 * time.Second)
+
+	tests := []struct {
+		name     string
+		recvTime time.Time
+		expected MissedSlotCause
+	}{
+		{
+			name:     "unknown receive time",
+			recvTime: time.Time{},
+			expected: MissedSlotCauseOrphanedBlock,
+		},
+		{
+			name:     "received in time",
+			recvTime: slotTime.Add(2 * time.Second),
+			expected: MissedSlotCauseOrphanedBlock,
+		},
+		{
+			name:     "received at the attestation deadline",
+			recvTime: slotTime.Add(4 * time.Second),
+			expected: MissedSlotCauseOrphanedBlock,
+		},
+		{
+			name:     "received after the attestation deadline",
+			recvTime: slotTime.Add(4*time.Second + time.Millisecond),
+			expected: MissedSlotCauseLateBlock,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cause := classifyOrphanedMissedSlot(slotTime, tt.recvTime, lateThreshold); cause != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, cause)
+			}
+		})
+	}
+}
@@ -250,6 +250,22 @@ func (vn *ValidatorNames) GetValidatorName(index uint64) string {
 	return ""
 }
 
+// GetNamedValidators returns the names of all validators that have a name assigned.
+func (vn *ValidatorNames) GetNamedValidators() map[uint64]string {
+	vn.namesMutex.RLock()
+	defer vn.namesMutex.RUnlock()
+
+	namedValidators := make(map[uint64]string, len(vn.namesByIndex)+len(vn.resolvedNamesByIndex))
+	for index, name := range vn.resolvedNamesByIndex {
+		namedValidators[index] = name.name
+	}
+	for index, name := range vn.namesByIndex {
+		namedValidators[index] = name.name
+	}
+
+	return namedValidators
+}
+
 func (vn *ValidatorNames) GetValidatorNameByPubkey(pubkey []byte) string {
 	validatorIndex, found := vn.beaconIndexer.GetValidatorIndexByPubkey(phase0.BLSPubKey(pubkey))
 	if !found {
@@ -55,6 +55,7 @@ type Config struct {
 		ShowPeerDASInfos       bool `yaml:"showPeerDASInfos" envconfig:"FRONTEND_SHOW_PEER_DAS_INFOS"`
 		ShowSubmitDeposit      bool `yaml:"showSubmitDeposit" envconfig:"FRONTEND_SHOW_SUBMIT_DEPOSIT"`
 		ShowSubmitElRequests   bool `yaml:"showSubmitElRequests" envconfig:"FRONTEND_SHOW_SUBMIT_EL_REQUESTS"`
+		AllowSavedDashboards   bool `yaml:"allowSavedDashboards" envconfig:"FRONTEND_ALLOW_SAVED_DASHBOARDS"`
 	} `yaml:"frontend"`
 
 	RateLimit struct {
@@ -82,6 +83,9 @@ type Config struct {
 		LogBatchSize       int `yaml:"logBatchSize" envconfig:"EXECUTIONAPI_LOG_BATCH_SIZE"`
 		DepositDeployBlock int `yaml:"depositDeployBlock" envconfig:"EXECUTIONAPI_DEPOSIT_DEPLOY_BLOCK"` // el block number from where to crawl the deposit system contract (should be <=, but close to deposit contract deployment)
 		ElectraDeployBlock int `yaml:"electraDeployBlock" envconfig:"EXECUTIONAPI_ELECTRA_DEPLOY_BLOCK"` // el block number from where to crawl the electra system contracts (should be <=, but close to electra fork activation block)
+
+		DisableRevenueIndexer bool `yaml:"disableRevenueIndexer" envconfig:"EXECUTIONAPI_DISABLE_REVENUE_INDEXER"` // disable the calculation of block priority fees from execution receipts
+		RevenueBatchSize      int  `yaml:"revenueBatchSize" envconfig:"EXECUTIONAPI_REVENUE_BATCH_SIZE"`           // number of slots processed per batch by the revenue indexer
 	} `yaml:"executionapi"`
 
 	Indexer struct {
@@ -93,7 +97,13 @@ type Config struct {
 		DisableSynchronizer             bool   `yaml:"disableSynchronizer" envconfig:"INDEXER_DISABLE_SYNCHRONIZER"`
 		SyncEpochCooldown               uint   `yaml:"syncEpochCooldown" envconfig:"INDEXER_SYNC_EPOCH_COOLDOWN"`
 		MaxParallelValidatorSetRequests uint   `yaml:"maxParallelValidatorSetRequests" envconfig:"INDEXER_MAX_PARALLEL_VALIDATOR_SET_REQUESTS"`
+		SyncMaxParallelEpochs           uint   `yaml:"syncMaxParallelEpochs" envconfig:"INDEXER_SYNC_MAX_PARALLEL_EPOCHS"`
+		SyncMaxHeldValidatorSets        uint   `yaml:"syncMaxHeldValidatorSets" envconfig:"INDEXER_SYNC_MAX_HELD_VALIDATOR_SETS"`
+		SyncRecentFirst                 bool   `yaml:"syncRecentFirst" envconfig:"INDEXER_SYNC_RECENT_FIRST"`
+		SyncFloorEpoch                  uint64 `yaml:"syncFloorEpoch" envconfig:"INDEXER_SYNC_FLOOR_EPOCH"`
 		PubkeyCachePath                 string `yaml:"pubkeyCachePath" envconfig:"INDEXER_PUBKEY_CACHE_PATH"`
+		SpillCachePath                  string `yaml:"spillCachePath" envconfig:"INDEXER_SPILL_CACHE_PATH"`
+		SpillAfterEpochs                uint16 `yaml:"spillAfterEpochs" envconfig:"INDEXER_SPILL_AFTER_EPOCHS"`
 
 		BadChainRoots []string `yaml:"badChainRoots" envconfig:"INDEXER_BAD_CHAIN_ROOTS"`
 	} `yaml:"indexer"`
@@ -110,8 +120,49 @@ type Config struct {
 	MevIndexer struct {
 		Relays          []MevRelayConfig `yaml:"relays"`
 		RefreshInterval time.Duration    `yaml:"refreshInterval" envconfig:"MEVINDEXER_REFRESH_INTERVAL"`
+
+		BackfillEnabled   bool          `yaml:"backfillEnabled" envconfig:"MEVINDEXER_BACKFILL_ENABLED"`
+		BackfillStartSlot uint64        `yaml:"backfillStartSlot" envconfig:"MEVINDEXER_BACKFILL_START_SLOT"`
+		BackfillInterval  time.Duration `yaml:"backfillInterval" envconfig:"MEVINDEXER_BACKFILL_INTERVAL"`
+
+		BidTracesEnabled   bool          `yaml:"bidTracesEnabled" envconfig:"MEVINDEXER_BIDTRACES_ENABLED"`
+		BidTracesRetention time.Duration `yaml:"bidTracesRetention" envconfig:"MEVINDEXER_BIDTRACES_RETENTION"`
+
+		RegistrationsEnabled   bool          `yaml:"registrationsEnabled" envconfig:"MEVINDEXER_REGISTRATIONS_ENABLED"`
+		RegistrationsBatchSize uint64        `yaml:"registrationsBatchSize" envconfig:"MEVINDEXER_REGISTRATIONS_BATCH_SIZE"`
+		RegistrationsInterval  time.Duration `yaml:"registrationsInterval" envconfig:"MEVINDEXER_REGISTRATIONS_INTERVAL"`
 	} `yaml:"mevIndexer"`
 
+	Alerting struct {
+		Enabled       bool                 `yaml:"enabled" envconfig:"ALERTING_ENABLED"`
+		MaxRetries    int                  `yaml:"maxRetries" envconfig:"ALERTING_MAX_RETRIES"`
+		RetryInterval time.Duration        `yaml:"retryInterval" envconfig:"ALERTING_RETRY_INTERVAL"`
+		Webhooks      []AlertWebhookConfig `yaml:"webhooks"`
+		Rules         []AlertRuleConfig    `yaml:"rules"`
+	} `yaml:"alerting"`
+
+	ClientDiversity struct {
+		MaxEpochRange uint64                `yaml:"maxEpochRange" envconfig:"CLIENT_DIVERSITY_MAX_EPOCH_RANGE"`
+		ClPatterns    []ClientPatternConfig `yaml:"clPatterns"`
+		ElPatterns    []ClientPatternConfig `yaml:"elPatterns"`
+	} `yaml:"clientDiversity"`
+
+	BlobAnalytics struct {
+		MaxEpochRange uint64                `yaml:"maxEpochRange" envconfig:"BLOB_ANALYTICS_MAX_EPOCH_RANGE"`
+		Submitters    []BlobSubmitterConfig `yaml:"submitters"`
+	} `yaml:"blobAnalytics"`
+
+	GasAnalytics struct {
+		MaxEpochRange uint64 `yaml:"maxEpochRange" envconfig:"GAS_ANALYTICS_MAX_EPOCH_RANGE"`
+	} `yaml:"gasAnalytics"`
+
+	ConsistencyChecker struct {
+		Enabled     bool          `yaml:"enabled" envconfig:"CONSISTENCY_CHECKER_ENABLED"`
+		Interval    time.Duration `yaml:"interval" envconfig:"CONSISTENCY_CHECKER_INTERVAL"`
+		BatchEpochs uint64        `yaml:"batchEpochs" envconfig:"CONSISTENCY_CHECKER_BATCH_EPOCHS"`
+		AutoRepair  bool          `yaml:"autoRepair" envconfig:"CONSISTENCY_CHECKER_AUTO_REPAIR"`
+	} `yaml:"consistencyChecker"`
+
 	Database struct {
 		Engine string `yaml:"engine" envconfig:"DATABASE_ENGINE"`
 		Sqlite struct {
@@ -169,6 +220,34 @@ type MevRelayConfig struct {
 	Name       string `yaml:"name"`
 	Url        string `yaml:"url"`
 	BlockLimit int    `yaml:"blockLimit"`
+
+	BackfillStartSlot uint64 `yaml:"backfillStartSlot"` // overrides the global backfill start slot if set
+}
+
+type AlertWebhookConfig struct {
+	Name    string            `yaml:"name"`
+	Url     string            `yaml:"url"`
+	Format  string            `yaml:"format"` // generic, slack or discord
+	Headers map[string]string `yaml:"headers"`
+}
+
+type AlertRuleConfig struct {
+	Name          string   `yaml:"name"`
+	Type          string   `yaml:"type"`
+	Webhooks      []string `yaml:"webhooks"`      // webhook names, all webhooks if empty
+	Validators    string   `yaml:"validators"`    // list of validator indices or pubkeys
+	ValidatorName string   `yaml:"validatorName"` // validator name pattern
+	Threshold     float64  `yaml:"threshold"`
+}
+
+type ClientPatternConfig struct {
+	Name    string `yaml:"name"`
+	Pattern string `yaml:"pattern"` // case-insensitive regular expression
+}
+
+type BlobSubmitterConfig struct {
+	Name      string   `yaml:"name"`
+	Addresses []string `yaml:"addresses"` // blob transaction sender addresses
 }
 
 type SqliteDatabaseConfig struct {
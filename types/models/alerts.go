@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+)
+
+// AlertsPageData is a struct to hold info for the alerts page
+type AlertsPageData struct {
+	Rules     []*AlertsPageDataRule `json:"rules"`
+	RuleCount uint64                `json:"rule_count"`
+
+	Deliveries    []*AlertsPageDataDelivery `json:"deliveries"`
+	DeliveryCount uint64                    `json:"delivery_count"`
+
+	IsDefaultPage    bool   `json:"default_page"`
+	TotalPages       uint64 `json:"total_pages"`
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+	LastPageIndex    uint64 `json:"last_page_index"`
+
+	FirstPageLink string `json:"first_page_link"`
+	PrevPageLink  string `json:"prev_page_link"`
+	NextPageLink  string `json:"next_page_link"`
+	LastPageLink  string `json:"last_page_link"`
+}
+
+type AlertsPageDataRule struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type"`
+	Webhooks       []string `json:"webhooks"`
+	Target         string   `json:"target"`
+	ValidatorCount uint64   `json:"validator_count"`
+	Threshold      float64  `json:"threshold"`
+}
+
+type AlertsPageDataDelivery struct {
+	RuleName    string    `json:"rule"`
+	WebhookName string    `json:"webhook"`
+	AlertType   string    `json:"type"`
+	Title       string    `json:"title"`
+	Delivered   bool      `json:"delivered"`
+	Attempts    uint32    `json:"attempts"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	DeliveredAt time.Time `json:"delivered_at"`
+}
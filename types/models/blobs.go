@@ -0,0 +1,42 @@
+package models
+
+// BlobsPageData is a struct to hold info for the blobs page
+type BlobsPageData struct {
+	FilterStartEpoch uint64 `json:"filter_start"`
+	FilterEndEpoch   uint64 `json:"filter_end"`
+	MaxEpochRange    uint64 `json:"max_epoch_range"`
+	FinalizedEpoch   uint64 `json:"finalized_epoch"`
+
+	BlockCount          uint64  `json:"block_count"`
+	BlobBlockCount      uint64  `json:"blob_block_count"`
+	BlobCount           uint64  `json:"blob_count"`
+	BlobsPerBlock       float64 `json:"blobs_per_block"`
+	BlobGasUsed         uint64  `json:"blob_gas_used"`
+	BlobFees            float64 `json:"blob_fees"` // ETH
+	TargetBlobsPerBlock uint64  `json:"target_blobs_per_block"`
+	MaxBlobsPerBlock    uint64  `json:"max_blobs_per_block"`
+
+	BucketSize     uint64                    `json:"bucket_size"`
+	Buckets        []*BlobsPageDataBucket    `json:"buckets"`
+	SubmitterCount uint64                    `json:"submitter_count"`
+	Submitters     []*BlobsPageDataSubmitter `json:"submitters"`
+}
+
+type BlobsPageDataBucket struct {
+	StartEpoch     uint64  `json:"start_epoch"`
+	EndEpoch       uint64  `json:"end_epoch"`
+	BlockCount     uint64  `json:"block_count"`
+	BlobCount      uint64  `json:"blob_count"`
+	BlobsPerBlock  float64 `json:"blobs_per_block"`
+	AvgBlobBaseFee float64 `json:"avg_blob_base_fee"` // gwei
+	MaxBlobBaseFee float64 `json:"max_blob_base_fee"` // gwei
+}
+
+type BlobsPageDataSubmitter struct {
+	Address   []byte  `json:"address"`
+	Name      string  `json:"name"`
+	TxCount   uint64  `json:"tx_count"`
+	BlobCount uint64  `json:"blob_count"`
+	Share     float64 `json:"share"`
+	BlobFees  float64 `json:"blob_fees"` // ETH
+}
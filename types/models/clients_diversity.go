@@ -0,0 +1,31 @@
+package models
+
+// ClientsDiversityPageData is a struct to hold info for the client diversity page
+type ClientsDiversityPageData struct {
+	FilterStartEpoch uint64 `json:"filter_start"`
+	FilterEndEpoch   uint64 `json:"filter_end"`
+	MaxEpochRange    uint64 `json:"max_epoch_range"`
+	FinalizedEpoch   uint64 `json:"finalized_epoch"`
+
+	BlockCount uint64                            `json:"block_count"`
+	ClClients  []*ClientsDiversityPageDataClient `json:"cl_clients"`
+	ElClients  []*ClientsDiversityPageDataClient `json:"el_clients"`
+	Pairs      []*ClientsDiversityPageDataClient `json:"pairs"`
+	BucketSize uint64                            `json:"bucket_size"`
+	Buckets    []*ClientsDiversityPageDataBucket `json:"buckets"`
+}
+
+type ClientsDiversityPageDataClient struct {
+	Name       string  `json:"name"`
+	BlockCount uint64  `json:"block_count"`
+	Share      float64 `json:"share"`
+	Color      string  `json:"color"`
+}
+
+type ClientsDiversityPageDataBucket struct {
+	StartEpoch uint64    `json:"start_epoch"`
+	EndEpoch   uint64    `json:"end_epoch"`
+	BlockCount uint64    `json:"block_count"`
+	ClShares   []float64 `json:"cl_shares"` // shares in order of ClClients
+	ElShares   []float64 `json:"el_shares"` // shares in order of ElClients
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+)
+
+// DebugConsistencyPageData is a struct to hold info for the db consistency debug page
+type DebugConsistencyPageData struct {
+	Enabled       bool   `json:"enabled"`
+	AutoRepair    bool   `json:"auto_repair"`
+	Running       bool   `json:"running"`
+	SyncRunning   bool   `json:"sync_running"`
+	SyncHeadEpoch uint64 `json:"sync_head_epoch"`
+
+	HasReport      bool                             `json:"has_report"`
+	StartTime      time.Time                        `json:"start_time"`
+	Duration       time.Duration                    `json:"duration"`
+	FirstEpoch     uint64                           `json:"first_epoch"`
+	LastEpoch      uint64                           `json:"last_epoch"`
+	Error          string                           `json:"error"`
+	IssueCount     uint64                           `json:"issue_count"`
+	IssueTypes     []*DebugConsistencyPageDataType  `json:"issue_types"`
+	Issues         []*DebugConsistencyPageDataIssue `json:"issues"`
+	AffectedEpochs []uint64                         `json:"affected_epochs"`
+	RepairTime     time.Time                        `json:"repair_time"`
+	RepairError    string                           `json:"repair_error"`
+	PendingEpochs  []uint64                         `json:"pending_epochs"`
+}
+
+type DebugConsistencyPageDataType struct {
+	Type  string `json:"type"`
+	Count uint64 `json:"count"`
+}
+
+type DebugConsistencyPageDataIssue struct {
+	Type    string `json:"type"`
+	Epoch   uint64 `json:"epoch"`
+	HasSlot bool   `json:"has_slot"`
+	Slot    uint64 `json:"slot"`
+	Details string `json:"details"`
+}
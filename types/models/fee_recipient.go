@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+)
+
+// FeeRecipientPageData is a struct to hold info for the fee recipient page
+type FeeRecipientPageData struct {
+	Address    []byte                       `json:"address"`
+	Blocks     []*FeeRecipientPageDataBlock `json:"blocks"`
+	BlockCount uint64                       `json:"block_count"`
+	TotalCount uint64                       `json:"total_count"`
+	FirstIndex uint64                       `json:"first_index"`
+	LastIndex  uint64                       `json:"last_index"`
+
+	IsDefaultPage    bool   `json:"default_page"`
+	TotalPages       uint64 `json:"total_pages"`
+	PageSize         uint64 `json:"page_size"`
+	CurrentPageIndex uint64 `json:"page_index"`
+	PrevPageIndex    uint64 `json:"prev_page_index"`
+	NextPageIndex    uint64 `json:"next_page_index"`
+	LastPageIndex    uint64 `json:"last_page_index"`
+
+	FirstPageLink string `json:"first_page_link"`
+	PrevPageLink  string `json:"prev_page_link"`
+	NextPageLink  string `json:"next_page_link"`
+	LastPageLink  string `json:"last_page_link"`
+}
+
+type FeeRecipientPageDataBlock struct {
+	Slot            uint64    `json:"slot"`
+	Ts              time.Time `json:"ts"`
+	BlockNumber     uint64    `json:"block_number"`
+	Proposer        uint64    `json:"proposer"`
+	ProposerName    string    `json:"proposer_name"`
+	IsPayment       bool      `json:"is_payment"` // received via builder payment transaction
+	IsBuilder       bool      `json:"is_builder"` // fee recipient of a payload that paid the proposer
+	HasPriorityFees bool      `json:"has_priority_fees"`
+	PriorityFees    uint64    `json:"priority_fees"`
+	HasPayment      bool      `json:"has_payment"`
+	PaymentValue    uint64    `json:"payment_value"`
+	BurntFees       uint64    `json:"burnt_fees"`
+	ReceivedKnown   bool      `json:"received_known"`
+	Received        uint64    `json:"received"`
+	ReceivedSource  string    `json:"received_source"`
+}
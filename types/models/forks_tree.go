@@ -0,0 +1,43 @@
+package models
+
+// ForksTreePageData is a struct to hold info for the fork choice tree page
+type ForksTreePageData struct {
+	ReplaySlot     uint64 `json:"replay_slot"`
+	IsReplay       bool   `json:"is_replay"`
+	CurrentSlot    uint64 `json:"current_slot"`
+	MinSlot        uint64 `json:"min_slot"`
+	MaxSlot        uint64 `json:"max_slot"`
+	HeadSlot       uint64 `json:"head_slot"`
+	HeadRoot       []byte `json:"head_root"`
+	JustifiedEpoch uint64 `json:"justified_epoch"`
+	JustifiedRoot  []byte `json:"justified_root"`
+	FinalizedEpoch uint64 `json:"finalized_epoch"`
+	FinalizedRoot  []byte `json:"finalized_root"`
+	TotalWeight    uint64 `json:"total_weight"`
+	AmountIsCount  bool   `json:"amount_is_count"`
+	LaneCount      uint64 `json:"lane_count"`
+
+	Nodes     []*ForksTreePageDataNode `json:"nodes"`
+	NodeCount uint64                   `json:"node_count"`
+}
+
+type ForksTreePageDataNode struct {
+	Slot         uint64   `json:"slot"`
+	Root         []byte   `json:"root"`
+	ParentRoot   []byte   `json:"parent_root"`
+	HasParent    bool     `json:"has_parent"`
+	ForkId       uint64   `json:"fork_id"`
+	Lane         uint64   `json:"lane"`
+	Proposer     uint64   `json:"proposer"`
+	ProposerName string   `json:"proposer_name"`
+	Weight       uint64   `json:"weight"`
+	BranchWeight uint64   `json:"branch_weight"`
+	BranchShare  float64  `json:"branch_share"`
+	VoteCount    uint64   `json:"vote_count"`
+	IsHeadChain  bool     `json:"is_head_chain"`
+	IsHead       bool     `json:"is_head"`
+	IsCanonical  bool     `json:"is_canonical"`
+	IsJustified  bool     `json:"is_justified"`
+	IsFinalized  bool     `json:"is_finalized"`
+	ClientHeads  []string `json:"client_heads"`
+}
@@ -0,0 +1,47 @@
+package models
+
+// GasPageData is a struct to hold info for the gas page
+type GasPageData struct {
+	FilterStartEpoch uint64 `json:"filter_start"`
+	FilterEndEpoch   uint64 `json:"filter_end"`
+	MaxEpochRange    uint64 `json:"max_epoch_range"`
+	FinalizedEpoch   uint64 `json:"finalized_epoch"`
+
+	BlockCount      uint64  `json:"block_count"`
+	GasUsed         uint64  `json:"gas_used"`
+	Utilization     float64 `json:"utilization"`
+	FirstGasLimit   uint64  `json:"first_gas_limit"`
+	LastGasLimit    uint64  `json:"last_gas_limit"`
+	UpCount         uint64  `json:"up_count"`
+	DownCount       uint64  `json:"down_count"`
+	SameCount       uint64  `json:"same_count"`
+	ChangingVoters  uint64  `json:"changing_voters"`
+	UnchangedVoters uint64  `json:"unchanged_voters"`
+
+	BucketSize uint64               `json:"bucket_size"`
+	Buckets    []*GasPageDataBucket `json:"buckets"`
+	VoterCount uint64               `json:"voter_count"`
+	Voters     []*GasPageDataVoter  `json:"voters"`
+}
+
+type GasPageDataBucket struct {
+	StartEpoch   uint64  `json:"start_epoch"`
+	EndEpoch     uint64  `json:"end_epoch"`
+	BlockCount   uint64  `json:"block_count"`
+	Utilization  float64 `json:"utilization"`
+	AvgGasLimit  uint64  `json:"avg_gas_limit"`
+	LastGasLimit uint64  `json:"last_gas_limit"`
+	AvgBaseFee   float64 `json:"avg_base_fee"` // gwei
+}
+
+type GasPageDataVoter struct {
+	Name           string `json:"name"`
+	ValidatorIndex uint64 `json:"validator_index"`
+	BlockCount     uint64 `json:"block_count"`
+	UpCount        uint64 `json:"up_count"`
+	DownCount      uint64 `json:"down_count"`
+	SameCount      uint64 `json:"same_count"`
+	Direction      int8   `json:"direction"` // 1 = pushing up, -1 = pushing down, 0 = mixed / unchanged
+	LastSlot       uint64 `json:"last_slot"`
+	LastGasLimit   uint64 `json:"last_gas_limit"`
+}
@@ -0,0 +1,55 @@
+package models
+
+// MevAnalyticsPageData is a struct to hold info for the mev builders & relays analytics pages
+type MevAnalyticsPageData struct {
+	FilterStartEpoch uint64 `json:"filter_start"`
+	FilterEndEpoch   uint64 `json:"filter_end"`
+	MaxEpochRange    uint64 `json:"max_epoch_range"`
+	FinalizedEpoch   uint64 `json:"finalized_epoch"`
+
+	PayloadCount    uint64  `json:"payload_count"`
+	MevBlockCount   uint64  `json:"mev_block_count"`
+	LocalBlockCount uint64  `json:"local_block_count"`
+	MevShare        float64 `json:"mev_share"`
+	LocalShare      float64 `json:"local_share"`
+	MevValue        uint64  `json:"mev_value"`
+	AvgMevValue     uint64  `json:"avg_mev_value"`
+
+	Builders   []*MevAnalyticsPageDataBuilder `json:"builders"`
+	Relays     []*MevAnalyticsPageDataRelay   `json:"relays"`
+	BucketSize uint64                         `json:"bucket_size"`
+	Buckets    []*MevAnalyticsPageDataBucket  `json:"buckets"`
+}
+
+type MevAnalyticsPageDataBuilder struct {
+	Pubkey     []byte                    `json:"pubkey"`
+	BlockCount uint64                    `json:"block_count"`
+	Share      float64                   `json:"share"` // share of all mev blocks
+	TotalValue uint64                    `json:"total_value"`
+	AvgValue   uint64                    `json:"avg_value"`
+	MaxValue   uint64                    `json:"max_value"`
+	LastSlot   uint64                    `json:"last_slot"`
+	Relays     []*MevBlocksPageDataRelay `json:"relays"`
+}
+
+type MevAnalyticsPageDataRelay struct {
+	Index          uint64  `json:"index"`
+	Name           string  `json:"name"`
+	BlockCount     uint64  `json:"block_count"`
+	Share          float64 `json:"share"` // share of all mev blocks
+	ExclusiveCount uint64  `json:"exclusive_count"`
+	ExclusiveShare float64 `json:"exclusive_share"` // share of this relays blocks
+	TotalValue     uint64  `json:"total_value"`
+	AvgValue       uint64  `json:"avg_value"`
+	BuilderCount   uint64  `json:"builder_count"`
+}
+
+type MevAnalyticsPageDataBucket struct {
+	StartEpoch    uint64  `json:"start_epoch"`
+	EndEpoch      uint64  `json:"end_epoch"`
+	PayloadCount  uint64  `json:"payload_count"`
+	MevBlockCount uint64  `json:"mev_block_count"`
+	MevShare      float64 `json:"mev_share"`
+	LocalShare    float64 `json:"local_share"`
+	MevValue      uint64  `json:"mev_value"`
+}
@@ -0,0 +1,38 @@
+package models
+
+// MevFaultsPageData is a struct to hold info for the mev relay faults page
+type MevFaultsPageData struct {
+	FilterStartEpoch uint64 `json:"filter_start"`
+	FilterEndEpoch   uint64 `json:"filter_end"`
+	MaxEpochRange    uint64 `json:"max_epoch_range"`
+
+	FaultCount uint64                    `json:"fault_count"`
+	Relays     []*MevFaultsPageDataRelay `json:"relays"`
+	Faults     []*MevFaultsPageDataFault `json:"faults"`
+	MoreFaults bool                      `json:"more_faults"`
+}
+
+type MevFaultsPageDataRelay struct {
+	Index             uint64  `json:"index"`
+	Name              string  `json:"name"`
+	DeliveredCount    uint64  `json:"delivered_count"`
+	MissedSlotCount   uint64  `json:"missed_slot_count"`
+	ReplacedCount     uint64  `json:"replaced_count"`
+	OrphanedCount     uint64  `json:"orphaned_count"`
+	FeeRecipientCount uint64  `json:"fee_recipient_count"`
+	FaultCount        uint64  `json:"fault_count"`
+	FaultRate         float64 `json:"fault_rate"`
+}
+
+type MevFaultsPageDataFault struct {
+	SlotNumber          uint64                    `json:"slot"`
+	BlockHash           []byte                    `json:"block_hash"`
+	Proposer            uint64                    `json:"proposer"`
+	ProposerName        string                    `json:"proposer_name"`
+	Relays              []*MevBlocksPageDataRelay `json:"relays"`
+	FaultType           uint8                     `json:"fault_type"`
+	FaultName           string                    `json:"fault_name"`
+	BlockValue          uint64                    `json:"block_value"`
+	FeeRecipient        []byte                    `json:"fee_recipient"`
+	PayloadFeeRecipient []byte                    `json:"payload_fee_recipient"`
+}
@@ -67,6 +67,7 @@ type SlotPageBlockData struct {
 	DepositRequestsCount       uint64                 `json:"deposit_receipts_count"`
 	WithdrawalRequestsCount    uint64                 `json:"withdrawal_requests_count"`
 	ConsolidationRequestsCount uint64                 `json:"consolidation_requests_count"`
+	BidsCount                  uint64                 `json:"bids_count"`
 
 	ExecutionData         *SlotPageExecutionData          `json:"execution_data"`
 	Attestations          []*SlotPageAttestation          `json:"attestations"`           // Attestations included in this block
@@ -81,6 +82,7 @@ type SlotPageBlockData struct {
 	DepositRequests       []*SlotPageDepositRequest       `json:"deposit_receipts"`       // DepositRequests included in this block
 	WithdrawalRequests    []*SlotPageWithdrawalRequest    `json:"withdrawal_requests"`    // WithdrawalRequests included in this block
 	ConsolidationRequests []*SlotPageConsolidationRequest `json:"consolidation_requests"` // ConsolidationRequests included in this block
+	Bids                  *SlotPageBids                   `json:"bids"`                   // Builder bids received by the relays for this slot
 }
 
 type SlotPageExecutionData struct {
@@ -187,6 +189,28 @@ type SlotPageWithdrawal struct {
 	Amount         uint64 `json:"amount"`
 }
 
+type SlotPageBids struct {
+	BuilderCount   uint64         `json:"builder_count"`
+	HighestValue   uint64         `json:"highest_value"`
+	WinningValue   uint64         `json:"winning_value"`
+	WinningDelay   int64          `json:"winning_delay"`
+	WinningBuilder []byte         `json:"winning_builder"`
+	HasWinningBid  bool           `json:"has_winning_bid"`
+	Builders       []string       `json:"builders"`
+	Bids           []*SlotPageBid `json:"bids"`
+	RelayNames     []string       `json:"relay_names"`
+	FirstBidDelay  int64          `json:"first_bid_delay"`
+	LastBidDelay   int64          `json:"last_bid_delay"`
+}
+
+type SlotPageBid struct {
+	Builder   int    `json:"b"` // index in Builders
+	Relay     int    `json:"r"` // index in RelayNames
+	Value     uint64 `json:"v"` // gwei
+	Delay     int64  `json:"d"` // ms after slot start
+	IsWinning bool   `json:"w"`
+}
+
 type SlotPageBlob struct {
 	Index         uint64 `json:"index"`
 	KzgCommitment []byte `json:"kzg_commitment"`
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// SlotComparePageData is a struct to hold info for the slot block comparison page
+type SlotComparePageData struct {
+	Slot       uint64                   `json:"slot"`
+	Epoch      uint64                   `json:"epoch"`
+	Ts         time.Time                `json:"ts"`
+	Blocks     []*SlotCompareBlockInfo  `json:"blocks"`
+	BlockCount uint64                   `json:"block_count"`
+	Left       *SlotCompareBlockInfo    `json:"left"`
+	Right      *SlotCompareBlockInfo    `json:"right"`
+	Fields     []*SlotCompareField      `json:"fields"`
+	Operations []*SlotCompareOperations `json:"operations"`
+
+	TransactionsOnlyLeft  []*SlotCompareTransaction `json:"txs_only_left"`
+	TransactionsOnlyRight []*SlotCompareTransaction `json:"txs_only_right"`
+	TransactionsCommon    uint64                    `json:"txs_common"`
+	TransactionsOrderDiff bool                      `json:"txs_order_diff"`
+}
+
+type SlotCompareBlockInfo struct {
+	Root         []byte    `json:"root"`
+	Orphaned     bool      `json:"orphaned"`
+	Proposer     uint64    `json:"proposer"`
+	ProposerName string    `json:"proposer_name"`
+	HasRecvTime  bool      `json:"has_recv_time"`
+	RecvTime     time.Time `json:"recv_time"`
+	RecvDelay    int64     `json:"recv_delay"` // milliseconds after slot start
+}
+
+type SlotCompareField struct {
+	Name    string `json:"name"`
+	Left    string `json:"left"`
+	Right   string `json:"right"`
+	Differs bool   `json:"differs"`
+}
+
+type SlotCompareOperations struct {
+	Name       string `json:"name"`
+	LeftCount  uint64 `json:"left_count"`
+	RightCount uint64 `json:"right_count"`
+	Common     uint64 `json:"common"`
+	OnlyLeft   uint64 `json:"only_left"`
+	OnlyRight  uint64 `json:"only_right"`
+}
+
+type SlotCompareTransaction struct {
+	Index uint64 `json:"index"`
+	Hash  []byte `json:"hash"`
+}
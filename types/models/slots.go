@@ -31,6 +31,8 @@ type SlotsPageDataSlot struct {
 	Finalized             bool                      `json:"scheduled"`
 	Scheduled             bool                      `json:"finalized"`
 	Status                uint8                     `json:"status"`
+	MissedCause           string                    `json:"missed_cause,omitempty"`
+	MissedCauseInfo       string                    `json:"missed_cause_info,omitempty"`
 	Synchronized          bool                      `json:"synchronized"`
 	Proposer              uint64                    `json:"proposer"`
 	ProposerName          string                    `json:"proposer_name"`
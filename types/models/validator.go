@@ -59,6 +59,17 @@ type ValidatorPageData struct {
 	WithdrawalRequests                  []*ValidatorPageDataWithdrawal    `json:"withdrawal_requests"`
 	WithdrawalRequestCount              uint64                            `json:"withdrawal_request_count"`
 	AdditionalWithdrawalRequestCount    uint64                            `json:"additional_withdrawal_request_count"`
+
+	ShowMevRegistrations        bool                                `json:"show_mev_registrations"`
+	MevRegistrations            []*ValidatorPageDataMevRegistration `json:"mev_registrations"`
+	MevRegistrationCount        uint64                              `json:"mev_registration_count"`
+	MevRegistrationHistory      []*ValidatorPageDataMevRegistration `json:"mev_registration_history"`
+	MevRegistrationHistoryCount uint64                              `json:"mev_registration_history_count"`
+
+	RevenueBlocks     []*ValidatorPageDataRevenue `json:"revenue_blocks"`
+	RevenueBlockCount uint64                      `json:"revenue_block_count"`
+	RevenueTotal      uint64                      `json:"revenue_total"`
+	RevenueBurntTotal uint64                      `json:"revenue_burnt_total"`
 }
 
 type ValidatorPageDataBlock struct {
@@ -70,6 +81,10 @@ type ValidatorPageDataBlock struct {
 	Status       uint64    `json:"status"`
 	BlockRoot    string    `json:"block_root"`
 	Graffiti     []byte    `json:"graffiti"`
+
+	FeeRecipient         []byte `json:"fee_recipient"`
+	RegisteredRecipient  []byte `json:"registered_recipient"`
+	FeeRecipientMismatch bool   `json:"fee_recipient_mismatch"`
 }
 
 type ValidatorPageDataAttestation struct {
@@ -167,3 +182,28 @@ type ValidatorPageDataWithdrawalTxDetails struct {
 	TxTarget    string `json:"tx_target"`
 	TxHash      string `json:"tx_hash"`
 }
+
+type ValidatorPageDataMevRegistration struct {
+	RelayIndex   uint64    `json:"relay_index"`
+	RelayName    string    `json:"relay_name"`
+	FeeRecipient []byte    `json:"fee_recipient"`
+	GasLimit     uint64    `json:"gas_limit"`
+	Time         time.Time `json:"time"`
+	FirstSeen    time.Time `json:"first_seen"`
+}
+
+type ValidatorPageDataRevenue struct {
+	Slot             uint64    `json:"slot"`
+	Ts               time.Time `json:"ts"`
+	BlockNumber      uint64    `json:"block_number"`
+	FeeRecipient     []byte    `json:"fee_recipient"`
+	PaymentRecipient []byte    `json:"payment_recipient"`
+	HasPriorityFees  bool      `json:"has_priority_fees"`
+	PriorityFees     uint64    `json:"priority_fees"`
+	HasPayment       bool      `json:"has_payment"`
+	PaymentValue     uint64    `json:"payment_value"`
+	BurntFees        uint64    `json:"burnt_fees"`
+	RevenueKnown     bool      `json:"revenue_known"`
+	Revenue          uint64    `json:"revenue"`
+	RevenueSource    string    `json:"revenue_source"`
+}
@@ -10,8 +10,13 @@ type ValidatorsPageData struct {
 	FilterIndex      string                           `json:"filter_index"`
 	FilterName       string                           `json:"filter_name"`
 	FilterStatus     string                           `json:"filter_status"`
+	FilterEpoch      string                           `json:"filter_epoch"`
 	FilterStatusOpts []ValidatorsPageDataStatusOption `json:"filter_status_opts"`
 
+	FilterEpochUnavailable bool   `json:"filter_epoch_unavailable"`
+	HistoryStartEpoch      uint64 `json:"history_start_epoch"`
+	HasHistoryStart        bool   `json:"has_history_start"`
+
 	Validators       []*ValidatorsPageDataValidator `json:"validators"`
 	ValidatorCount   uint64                         `json:"validator_count"`
 	FirstValidator   uint64                         `json:"first_validx"`
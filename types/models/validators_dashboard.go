@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsDashboardPageData is a struct to hold info for the validator dashboard page
+type ValidatorsDashboardPageData struct {
+	FilterValidators        string `json:"filter_validators"`
+	FilterWithdrawalAddress string `json:"filter_waddr"`
+	FilterValidatorName     string `json:"filter_vname"`
+
+	DashboardKey   string `json:"dashboard_key"`
+	DashboardName  string `json:"dashboard_name"`
+	CanSave        bool   `json:"can_save"`
+	ShareLink      string `json:"share_link"`
+	DutiesLink     string `json:"duties_link"`
+	HasSetSelected bool   `json:"has_set"`
+
+	ValidatorCount        uint64 `json:"validator_count"`
+	ValidatorLimit        uint64 `json:"validator_limit"`
+	ValidatorsCapped      bool   `json:"validators_capped"`
+	TotalBalance          uint64 `json:"total_balance"`
+	TotalEffectiveBalance uint64 `json:"total_effective_balance"`
+
+	StatusCounts    []*ValidatorsDashboardPageDataStatus `json:"status_counts"`
+	ActiveCount     uint64                               `json:"active_count"`
+	OnlineCount     uint64                               `json:"online_count"`
+	OfflineCount    uint64                               `json:"offline_count"`
+	LivenessPercent float64                              `json:"liveness_percent"`
+
+	RecentBlocks     []*ValidatorsDashboardPageDataBlock `json:"recent_blocks"`
+	RecentBlockCount uint64                              `json:"recent_block_count"`
+	NextDuties       []*ValidatorsDutiesPageDataDuty     `json:"next_duties"`
+	NextDutyCount    uint64                              `json:"next_duty_count"`
+
+	Validators          []*ValidatorsDashboardPageDataValidator `json:"validators"`
+	ShownValidatorCount uint64                                  `json:"shown_validator_count"`
+}
+
+type ValidatorsDashboardPageDataStatus struct {
+	Status string `json:"status"`
+	Count  uint64 `json:"count"`
+}
+
+type ValidatorsDashboardPageDataBlock struct {
+	Epoch          uint64    `json:"epoch"`
+	Slot           uint64    `json:"slot"`
+	Ts             time.Time `json:"ts"`
+	Status         uint64    `json:"status"`
+	ValidatorIndex uint64    `json:"vindex"`
+	ValidatorName  string    `json:"vname"`
+	BlockRoot      string    `json:"block_root"`
+	WithEthBlock   bool      `json:"with_eth_block"`
+	EthBlock       uint64    `json:"eth_block"`
+}
+
+type ValidatorsDashboardPageDataValidator struct {
+	Index            uint64 `json:"index"`
+	Name             string `json:"name"`
+	State            string `json:"state"`
+	Balance          uint64 `json:"balance"`
+	EffectiveBalance uint64 `json:"eff_balance"`
+	ShowUpcheck      bool   `json:"show_upcheck"`
+	UpcheckActivity  uint8  `json:"upcheck_act"`
+	UpcheckMaximum   uint8  `json:"upcheck_max"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+)
+
+// ValidatorsDutiesPageData is a struct to hold info for the validator duties lookahead page
+type ValidatorsDutiesPageData struct {
+	FilterValidators    string `json:"filter_validators"`
+	FilterValidatorName string `json:"filter_vname"`
+
+	ValidatorCount   uint64 `json:"validator_count"`
+	ValidatorLimit   uint64 `json:"validator_limit"`
+	ValidatorsCapped bool   `json:"validators_capped"`
+	CurrentEpoch     uint64 `json:"current_epoch"`
+	CurrentSlot      uint64 `json:"current_slot"`
+
+	Duties         []*ValidatorsDutiesPageDataDuty `json:"duties"`
+	DutyCount      uint64                          `json:"duty_count"`
+	ProposalCount  uint64                          `json:"proposal_count"`
+	SyncDutyCount  uint64                          `json:"sync_duty_count"`
+	ICalLink       string                          `json:"ical_link"`
+	ShareLink      string                          `json:"share_link"`
+	HasSetSelected bool                            `json:"has_set"`
+}
+
+type ValidatorsDutiesPageDataDuty struct {
+	Type           string    `json:"type"`
+	ValidatorIndex uint64    `json:"vindex"`
+	ValidatorName  string    `json:"vname"`
+	Slot           uint64    `json:"slot"`
+	EndSlot        uint64    `json:"end_slot"`
+	Epoch          uint64    `json:"epoch"`
+	SyncPeriod     uint64    `json:"sync_period"`
+	Time           time.Time `json:"time"`
+	EndTime        time.Time `json:"end_time"`
+	Predicted      bool      `json:"predicted"`
+}
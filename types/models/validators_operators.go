@@ -0,0 +1,39 @@
+package models
+
+// ValidatorsOperatorsPageData is a struct to hold info for the operator leaderboard page
+type ValidatorsOperatorsPageData struct {
+	FilterStartEpoch    uint64 `json:"filter_start"`
+	FilterEndEpoch      uint64 `json:"filter_end"`
+	FilterGroupByPrefix bool   `json:"filter_prefix"`
+	FilterName          string `json:"filter_name"`
+	FilterOrder         string `json:"filter_order"`
+
+	MaxEpochRange      uint64 `json:"max_epoch_range"`
+	HasActivity        bool   `json:"has_activity"`
+	ActivityStartEpoch uint64 `json:"activity_start"`
+	ActivityEndEpoch   uint64 `json:"activity_end"`
+
+	Operators     []*ValidatorsOperatorsPageDataOperator `json:"operators"`
+	OperatorCount uint64                                 `json:"operator_count"`
+}
+
+type ValidatorsOperatorsPageDataOperator struct {
+	Name                string  `json:"name"`
+	ValidatorCount      uint64  `json:"validator_count"`
+	ProposalsHit        uint64  `json:"proposals_hit"`
+	ProposalsMissed     uint64  `json:"proposals_missed"`
+	ProposalsOrphaned   uint64  `json:"proposals_orphaned"`
+	MissedOffline       uint64  `json:"missed_offline"`
+	MissedRelay         uint64  `json:"missed_relay"`
+	MissedLate          uint64  `json:"missed_late"`
+	MissedOrphaned      uint64  `json:"missed_orphaned"`
+	MissedUnknown       uint64  `json:"missed_unknown"`
+	ProposalRate        float64 `json:"proposal_rate"`
+	AttestationsDue     uint64  `json:"attestations_due"`
+	AttestationsHit     uint64  `json:"attestations_hit"`
+	AttestationLiveness float64 `json:"attestation_liveness"`
+	SyncDue             uint64  `json:"sync_due"`
+	SyncHit             uint64  `json:"sync_hit"`
+	SyncParticipation   float64 `json:"sync_participation"`
+	Slashings           uint64  `json:"slashings"`
+}
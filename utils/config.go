@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 
 	"github.com/kelseyhightower/envconfig"
 	"gopkg.in/yaml.v3"
@@ -92,3 +93,66 @@ func readConfigFile(cfg *types.Config, path string) error {
 func readConfigEnv(cfg *types.Config) error {
 	return envconfig.Process("", cfg)
 }
+
+// ValidateConfig checks the config for unknown settings and invalid values.
+// It returns a list of problems found, which is empty for a valid config.
+func ValidateConfig(cfg *types.Config, path string) []string {
+	problems := []string{}
+
+	// check for unknown (misspelled) settings
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return append(problems, fmt.Sprintf("error opening config file %v: %v", path, err))
+		}
+		defer f.Close()
+
+		decoder := yaml.NewDecoder(f)
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&types.Config{}); err != nil {
+			if typeErr, ok := err.(*yaml.TypeError); ok {
+				for _, fieldErr := range typeErr.Errors {
+					// strip the verbose go type description from unknown field errors
+					if idx := strings.Index(fieldErr, " not found in type "); idx > 0 {
+						fieldErr = fieldErr[:idx] + " is not a known setting"
+					}
+					problems = append(problems, fieldErr)
+				}
+			} else {
+				problems = append(problems, fmt.Sprintf("invalid config file: %v", err))
+			}
+		}
+	}
+
+	switch cfg.Database.Engine {
+	case "sqlite":
+		if cfg.Database.Sqlite.File == "" {
+			problems = append(problems, "database.sqlite.file is required for the sqlite database engine")
+		}
+	case "pgsql":
+		if cfg.Database.Pgsql.Host == "" {
+			problems = append(problems, "database.pgsql.host is required for the pgsql database engine")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown database engine: %q", cfg.Database.Engine))
+	}
+
+	checkEndpointUrl := func(kind string, endpoint types.EndpointConfig) {
+		endpointUrl, err := url.Parse(endpoint.Url)
+		if err != nil || (endpointUrl.Scheme != "http" && endpointUrl.Scheme != "https") || endpointUrl.Host == "" {
+			problems = append(problems, fmt.Sprintf("invalid %v endpoint url for %v: %q", kind, endpoint.Name, GetRedactedUrl(endpoint.Url)))
+		}
+	}
+	for _, endpoint := range cfg.BeaconApi.Endpoints {
+		checkEndpointUrl("beacon", endpoint)
+	}
+	for _, endpoint := range cfg.ExecutionApi.Endpoints {
+		checkEndpointUrl("execution", endpoint)
+	}
+
+	if cfg.Frontend.Enabled && cfg.Server.Port == "" {
+		problems = append(problems, "server.port is required when the frontend is enabled")
+	}
+
+	return problems
+}